@@ -3,19 +3,53 @@ package main
 import (
 	"context"
 	"database/sql"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
+	inventoryv1 "github.com/jamal23041989/go-marketplace-inventory-service/api/proto/inventory/v1"
+	ah "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/audit/handler"
+	ar "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/audit/repository"
+	as "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/audit/service"
+	ch "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/category/handler"
+	cr "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/category/repository"
+	cs "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/category/service"
+	fh "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/feed/handler"
+	fs "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/feed/service"
+	obp "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/outbox/publisher"
+	obr "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/outbox/repository"
+	obs "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/outbox/service"
+	ph "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/price/handler"
+	pr "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/price/repository"
+	ps "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/price/service"
 	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/product/handler"
 	rp "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/product/repository"
 	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/product/service"
+	rh "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/reservation/handler"
+	rr "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/reservation/repository"
+	rs "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/reservation/service"
+	sh "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/stock/handler"
+	sr "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/stock/repository"
+	ss "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/stock/service"
+	th "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/transfer/handler"
+	tr "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/transfer/repository"
+	ts "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/transfer/service"
+	wh "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/warehouse/handler"
+	wr "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/warehouse/repository"
+	ws "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/warehouse/service"
+	hh "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/webhook/handler"
+	hr "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/webhook/repository"
+	hs "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/webhook/service"
 	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/config"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
 	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/logger"
 	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/middleware"
 	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/repository"
+	"google.golang.org/grpc"
 )
 
 func main() {
@@ -31,53 +65,148 @@ func main() {
 		lg.Fatal("failed to connect to database: %v", err)
 	}
 
+	tx := repository.NewTransactor(db)
+
 	// Initial repository, service, handler
+	outboxRepo := obr.NewPostgresOutboxRepository(db)
+	outboxSvc := obs.NewOutboxService(outboxRepo)
+
+	stockRepo := sr.NewPostgresStockRepository(db)
+	stockSvc := ss.NewStockService(stockRepo, outboxSvc, tx)
+	stockHdl := sh.NewStockHandler(stockSvc, lg)
+
+	auditRepo := ar.NewPostgresAuditRepository(db)
+	auditSvc := as.NewAuditService(auditRepo)
+	auditHdl := ah.NewAuditHandler(auditSvc, lg)
+
+	exchangeRateRepo := pr.NewPostgresExchangeRateRepository(db)
+	exchangeRateSvc := ps.NewExchangeRateService(exchangeRateRepo, tx)
+	exchangeRateHdl := ph.NewExchangeRateHandler(exchangeRateSvc, cfg.Import.MaxFileSize, lg)
+
+	priceListRepo := pr.NewPostgresPriceListRepository(db)
+	priceListSvc := ps.NewPriceListService(priceListRepo, exchangeRateRepo, tx)
+	priceListHdl := ph.NewPriceListHandler(priceListSvc, lg)
+
 	repo := rp.NewPostgresProductRepository(db)
-	svc := service.NewProductService(repo)
-	hdl := handler.NewProductHandler(svc, lg)
+	svc := service.NewProductService(repo, stockSvc, auditSvc, outboxSvc, tx)
+	hdl := handler.NewProductHandler(svc, priceListSvc, lg)
+
+	importJobRepo := rp.NewPostgresImportJobRepository(db)
+	importJobSvc := service.NewImportJobService(importJobRepo, svc)
+	importHdl := handler.NewImportHandler(svc, importJobSvc, cfg.Import.MaxFileSize, cfg.Import.SyncRows, lg)
+
+	categoryRepo := cr.NewPostgresCategoryRepository(db)
+	categorySvc := cs.NewCategoryService(categoryRepo, svc, tx)
+	categoryHdl := ch.NewCategoryHandler(categorySvc, priceListSvc, lg)
+
+	attributeRepo := cr.NewPostgresAttributeRepository(db)
+	attributeSvc := cs.NewAttributeService(attributeRepo, categoryRepo, svc, tx)
+	attributeHdl := ch.NewAttributeHandler(attributeSvc, lg)
+
+	warehouseRepo := wr.NewPostgresWarehouseRepository(db)
+	warehouseSvc := ws.NewWarehouseService(warehouseRepo, tx)
+	warehouseHdl := wh.NewWarehouseHandler(warehouseSvc, lg)
+
+	transferRepo := tr.NewPostgresTransferRepository(db)
+	transferSvc := ts.NewTransferService(transferRepo, stockSvc, warehouseSvc, tx)
+	transferHdl := th.NewTransferHandler(transferSvc, lg)
+
+	reservationRepo := rr.NewPostgresReservationRepository(db)
+	reservationSvc := rs.NewReservationService(
+		reservationRepo,
+		stockSvc,
+		tx,
+		cfg.Reservation.DefaultTTL,
+		cfg.Reservation.MaxTTL,
+	)
+	reservationHdl := rh.NewReservationHandler(reservationSvc, lg)
+
+	webhookRepo := hr.NewPostgresWebhookRepository(db)
+	webhookSvc := hs.NewWebhookService(webhookRepo)
+	webhookHdl := hh.NewWebhookHandler(webhookSvc, lg)
+
+	feedSvc := fs.NewFeedService(svc, domain.FeedShop{
+		Name:     cfg.Feed.ShopName,
+		Company:  cfg.Feed.ShopCompany,
+		URL:      strings.TrimSuffix(cfg.Feed.ShopURL, "/"),
+		Currency: cfg.Feed.Currency,
+	}, cfg.Feed.Dir)
+	feedHdl := fh.NewFeedHandler(feedSvc, lg)
+
+	// background workers
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	sweeper := rs.NewSweeper(reservationSvc, cfg.Reservation.SweepInterval, lg)
+	go sweeper.Run(workersCtx)
+
+	purger := service.NewPurger(svc, cfg.Product.PurgeRetention, cfg.Product.PurgeInterval, lg)
+	go purger.Run(workersCtx)
+
+	importWorker := service.NewImportWorker(importJobSvc, cfg.Import.WorkerInterval, lg)
+	go importWorker.Run(workersCtx)
+
+	relay := obs.NewRelay(
+		outboxRepo,
+		tx,
+		obp.Multi(newPublisher(cfg.Outbox, lg), webhookSvc),
+		cfg.Outbox.BatchSize,
+		cfg.Outbox.RelayInterval,
+		lg,
+	)
+	go relay.Run(workersCtx)
+
+	dispatcher := hs.NewDispatcher(
+		webhookRepo,
+		tx,
+		hs.NewClient(cfg.Webhook.Timeout),
+		cfg.Webhook.MaxAttempts,
+		cfg.Webhook.BatchSize,
+		cfg.Webhook.DispatchInterval,
+		lg,
+	)
+	go dispatcher.Run(workersCtx)
+
+	feedRefresher := fs.NewRefresher(feedSvc, cfg.Feed.RefreshInterval, lg)
+	go feedRefresher.Run(workersCtx)
 
 	// init middlerware
 	mw := middleware.New(lg)
 
+	idempotencyStore := repository.NewPostgresIdempotencyStore(db)
+	idempotencyPurger := middleware.NewIdempotencyPurger(idempotencyStore, cfg.Idempotency.PurgeInterval, lg)
+	go idempotencyPurger.Run(workersCtx)
+
 	// router
 	mux := http.NewServeMux()
 
-	mux.HandleFunc("/ping", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte("pong"))
-	})
-
-	mux.HandleFunc("/products", func(w http.ResponseWriter, r *http.Request) {
-		switch r.Method {
-		case http.MethodPost:
-			hdl.Create(w, r)
-		case http.MethodGet:
-			hdl.GetAll(w, r)
-		default:
-			lg.Warn("invalid method: %s", r.Method)
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		}
-	})
-
-	mux.HandleFunc("/product/{id}", func(w http.ResponseWriter, r *http.Request) {
-		switch r.Method {
-		case http.MethodPatch:
-			hdl.Update(w, r)
-		case http.MethodDelete:
-			hdl.Delete(w, r)
-		case http.MethodGet:
-			hdl.GetById(w, r)
-		default:
-			lg.Warn("invalid method: %s", r.Method)
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		}
-	})
+	registerRoutes(mux, handlers{
+		product:       hdl,
+		productImport: importHdl,
+		stock:         stockHdl,
+		audit:         auditHdl,
+		warehouse:     warehouseHdl,
+		transfer:      transferHdl,
+		reservation:   reservationHdl,
+		webhook:       webhookHdl,
+		feed:          feedHdl,
+		category:      categoryHdl,
+		attribute:     attributeHdl,
+		priceList:     priceListHdl,
+		exchangeRate:  exchangeRateHdl,
+	}, lg)
 
 	// Server
 	server := &http.Server{
 		Addr: ":8080",
 		Handler: mw.Recovery(
-			mw.Logging(mux),
+			mw.RequestID(
+				mw.Logging(
+					mw.Identity(
+						mw.Idempotency(idempotencyStore, cfg.Idempotency.TTL, cfg.Idempotency.MaxBodySize)(mux),
+					),
+				),
+			),
 		),
 	}
 
@@ -87,18 +216,39 @@ func main() {
 		}
 	}()
 
+	// gRPC server
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(mw.UnaryInterceptor),
+		grpc.StreamInterceptor(mw.StreamInterceptor),
+	)
+	inventoryv1.RegisterInventoryServiceServer(grpcServer, handler.NewInventoryServer(svc, stockSvc, lg))
+
+	grpcListener, err := net.Listen("tcp", ":"+cfg.GRPC.Port)
+	if err != nil {
+		lg.Fatal("failed to listen on grpc port: %v", err)
+	}
+
+	go func() {
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			lg.Error("failed to start grpc server: %v", err)
+		}
+	}()
+
 	// shutdown
 	sg := make(chan os.Signal, 1)
 	signal.Notify(sg, syscall.SIGINT, syscall.SIGTERM)
 
 	<-sg
 
+	stopWorkers()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	if err := server.Shutdown(ctx); err != nil {
 		lg.Fatal("failed to shutdown server: %v", err)
 	}
+	grpcServer.GracefulStop()
 
 	func(db *sql.DB) {
 		if err := db.Close(); err != nil {
@@ -108,3 +258,20 @@ func main() {
 
 	lg.Info("Server exiting")
 }
+
+func newPublisher(cfg config.OutboxConfig, lg logger.Logger) obp.Publisher {
+	switch cfg.Publisher {
+	case "webhook":
+		if cfg.WebhookURL == "" {
+			lg.Fatal("OUTBOX_WEBHOOK_URL is required for the webhook publisher")
+		}
+		return obp.NewWebhookPublisher(cfg.WebhookURL, cfg.WebhookTimeout)
+	case "memory":
+		return obp.NewBrokerPublisher(obp.NewMemoryBroker(), "inventory")
+	case "log":
+		return obp.NewLogPublisher(lg)
+	default:
+		lg.Fatal("unknown outbox publisher %q", cfg.Publisher)
+		return nil
+	}
+}
@@ -0,0 +1,328 @@
+package main
+
+import (
+	"net/http"
+
+	ah "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/audit/handler"
+	ch "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/category/handler"
+	fh "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/feed/handler"
+	ph "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/price/handler"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/product/handler"
+	rh "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/reservation/handler"
+	sh "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/stock/handler"
+	th "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/transfer/handler"
+	wh "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/warehouse/handler"
+	hh "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/webhook/handler"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/logger"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/openapi"
+)
+
+// router is the part of *http.ServeMux that registerRoutes needs, so tests can
+// record the registered patterns.
+type router interface {
+	Handle(pattern string, handler http.Handler)
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+}
+
+type handlers struct {
+	product       *handler.ProductHandler
+	productImport *handler.ImportHandler
+	stock         *sh.StockHandler
+	audit         *ah.AuditHandler
+	warehouse     *wh.WarehouseHandler
+	transfer      *th.TransferHandler
+	reservation   *rh.ReservationHandler
+	webhook       *hh.WebhookHandler
+	feed          *fh.FeedHandler
+	category      *ch.CategoryHandler
+	attribute     *ch.AttributeHandler
+	priceList     *ph.PriceListHandler
+	exchangeRate  *ph.ExchangeRateHandler
+}
+
+// registerRoutes wires every HTTP route. Keep internal/core/openapi/openapi.json
+// in sync: TestRoutes_DocumentedInSpec fails on undocumented routes.
+func registerRoutes(mux router, h handlers, lg logger.Logger) {
+	mux.HandleFunc("/ping", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("pong"))
+	})
+
+	mux.HandleFunc("/openapi.json", openapi.SpecHandler)
+
+	mux.Handle("/docs/", openapi.DocsHandler())
+
+	mux.HandleFunc("/products", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			h.product.Create(w, r)
+		case http.MethodGet:
+			h.product.GetAll(w, r)
+		default:
+			lg.Warn("invalid method: %s", r.Method)
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/products/search", h.product.Search)
+
+	mux.HandleFunc("/products/export", h.product.Export)
+
+	mux.HandleFunc("/products/batch", h.product.Batch)
+
+	mux.HandleFunc("/products/import", h.productImport.Import)
+
+	mux.HandleFunc("/products/import/{id}", h.productImport.GetJob)
+
+	mux.HandleFunc("/products/by-sku/{sku}", h.product.GetBySKU)
+
+	mux.HandleFunc("/products/by-barcode/{code}", h.product.GetByBarcode)
+
+	mux.HandleFunc("/product/{id}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPatch:
+			h.product.Update(w, r)
+		case http.MethodDelete:
+			h.product.Delete(w, r)
+		case http.MethodGet:
+			h.product.GetById(w, r)
+		default:
+			lg.Warn("invalid method: %s", r.Method)
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/product/{id}/restore", h.product.Restore)
+
+	mux.HandleFunc("/product/{id}/history", h.audit.ProductHistory)
+
+	mux.HandleFunc("/product/{id}/movements", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			h.stock.Record(w, r)
+		case http.MethodGet:
+			h.stock.List(w, r)
+		default:
+			lg.Warn("invalid method: %s", r.Method)
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/product/{id}/stock/adjust", h.stock.Adjust)
+
+	mux.HandleFunc("/product/{id}/variant-attributes", h.product.SetVariantAttributes)
+
+	mux.HandleFunc("/product/{id}/variants", h.product.CreateVariant)
+
+	mux.HandleFunc("/product/{id}/variants/generate", h.product.GenerateVariants)
+
+	mux.HandleFunc("/product/{id}/categories", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			h.category.SetProductCategories(w, r)
+		case http.MethodGet:
+			h.category.ProductCategories(w, r)
+		default:
+			lg.Warn("invalid method: %s", r.Method)
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/product/{id}/attributes", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			h.attribute.SetValues(w, r)
+		case http.MethodGet:
+			h.attribute.Values(w, r)
+		default:
+			lg.Warn("invalid method: %s", r.Method)
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/categories", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			h.category.Create(w, r)
+		case http.MethodGet:
+			h.category.Tree(w, r)
+		default:
+			lg.Warn("invalid method: %s", r.Method)
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/category/{id}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPatch:
+			h.category.Update(w, r)
+		case http.MethodDelete:
+			h.category.Delete(w, r)
+		case http.MethodGet:
+			h.category.GetById(w, r)
+		default:
+			lg.Warn("invalid method: %s", r.Method)
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/categories/{id}/products", h.category.Products)
+
+	mux.HandleFunc("/categories/{id}/attributes", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			h.attribute.Create(w, r)
+		case http.MethodGet:
+			h.attribute.ForCategory(w, r)
+		default:
+			lg.Warn("invalid method: %s", r.Method)
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/attribute/{id}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPatch:
+			h.attribute.Update(w, r)
+		case http.MethodDelete:
+			h.attribute.Delete(w, r)
+		case http.MethodGet:
+			h.attribute.GetById(w, r)
+		default:
+			lg.Warn("invalid method: %s", r.Method)
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/price-lists", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			h.priceList.Create(w, r)
+		case http.MethodGet:
+			h.priceList.GetAll(w, r)
+		default:
+			lg.Warn("invalid method: %s", r.Method)
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/price-list/{id}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodDelete:
+			h.priceList.Delete(w, r)
+		case http.MethodGet:
+			h.priceList.GetById(w, r)
+		default:
+			lg.Warn("invalid method: %s", r.Method)
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/price-list/{id}/items", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			h.priceList.SetItems(w, r)
+		case http.MethodGet:
+			h.priceList.Items(w, r)
+		default:
+			lg.Warn("invalid method: %s", r.Method)
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/price-list/{id}/items/{product_id}", h.priceList.DeleteItem)
+
+	mux.HandleFunc("/exchange-rates", h.exchangeRate.GetAll)
+
+	mux.HandleFunc("/exchange-rates/import", h.exchangeRate.Import)
+
+	mux.HandleFunc("/warehouses", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			h.warehouse.Create(w, r)
+		case http.MethodGet:
+			h.warehouse.GetAll(w, r)
+		default:
+			lg.Warn("invalid method: %s", r.Method)
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/warehouse/{id}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPatch:
+			h.warehouse.Update(w, r)
+		case http.MethodDelete:
+			h.warehouse.Delete(w, r)
+		case http.MethodGet:
+			h.warehouse.GetById(w, r)
+		default:
+			lg.Warn("invalid method: %s", r.Method)
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/warehouse/{id}/stock", h.warehouse.Stock)
+
+	mux.HandleFunc("/transfers", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			h.transfer.Create(w, r)
+		case http.MethodGet:
+			h.transfer.GetAll(w, r)
+		default:
+			lg.Warn("invalid method: %s", r.Method)
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/transfers/in-transit", h.transfer.InTransit)
+
+	mux.HandleFunc("/transfer/{id}", h.transfer.GetById)
+
+	mux.HandleFunc("/transfer/{id}/ship", h.transfer.Ship)
+
+	mux.HandleFunc("/transfer/{id}/receive", h.transfer.Receive)
+
+	mux.HandleFunc("/transfer/{id}/cancel", h.transfer.Cancel)
+
+	mux.HandleFunc("/reservations", h.reservation.Create)
+
+	mux.HandleFunc("/reservation/{id}", h.reservation.GetById)
+
+	mux.HandleFunc("/reservation/{id}/confirm", h.reservation.Confirm)
+
+	mux.HandleFunc("/reservation/{id}/cancel", h.reservation.Cancel)
+
+	mux.HandleFunc("/audit", h.audit.List)
+
+	mux.HandleFunc("/webhooks", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			h.webhook.Create(w, r)
+		case http.MethodGet:
+			h.webhook.GetAll(w, r)
+		default:
+			lg.Warn("invalid method: %s", r.Method)
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/webhook/{id}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			h.webhook.GetById(w, r)
+		case http.MethodDelete:
+			h.webhook.Delete(w, r)
+		default:
+			lg.Warn("invalid method: %s", r.Method)
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/webhook/{id}/deliveries", h.webhook.Deliveries)
+
+	mux.HandleFunc("/webhook/{id}/deliveries/{delivery_id}/retry", h.webhook.Retry)
+
+	mux.HandleFunc("/feeds/{file}", h.feed.Get)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/logger"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/openapi"
+)
+
+type recordingRouter struct {
+	patterns []string
+}
+
+func (r *recordingRouter) Handle(pattern string, handler http.Handler) {
+	r.patterns = append(r.patterns, pattern)
+}
+
+func (r *recordingRouter) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	r.patterns = append(r.patterns, pattern)
+}
+
+func specPaths(t *testing.T) map[string]bool {
+	t.Helper()
+
+	var spec struct {
+		OpenAPI string                     `json:"openapi"`
+		Paths   map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(openapi.Spec, &spec); err != nil {
+		t.Fatalf("openapi.json is not valid JSON: %s", err)
+	}
+	if spec.OpenAPI == "" {
+		t.Fatalf("openapi.json has no openapi version")
+	}
+
+	paths := make(map[string]bool, len(spec.Paths))
+	for path := range spec.Paths {
+		paths[path] = true
+	}
+	return paths
+}
+
+func TestRoutes_DocumentedInSpec(t *testing.T) {
+	mux := &recordingRouter{}
+	registerRoutes(mux, handlers{}, logger.New(io.Discard))
+
+	paths := specPaths(t)
+	for _, pattern := range mux.patterns {
+		if !paths[pattern] {
+			t.Errorf("route %s is registered but missing from openapi.json", pattern)
+		}
+	}
+}
+
+func TestRoutes_SpecHasNoStalePaths(t *testing.T) {
+	mux := &recordingRouter{}
+	registerRoutes(mux, handlers{}, logger.New(io.Discard))
+
+	registered := make(map[string]bool, len(mux.patterns))
+	for _, pattern := range mux.patterns {
+		registered[pattern] = true
+	}
+
+	for path := range specPaths(t) {
+		if !registered[path] {
+			t.Errorf("openapi.json documents %s but no route is registered for it", path)
+		}
+	}
+}
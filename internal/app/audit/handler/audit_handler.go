@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/audit/service"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/logger"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/response"
+)
+
+type AuditHandler struct {
+	service service.AuditService
+	logger  logger.Logger
+}
+
+func NewAuditHandler(service service.AuditService, logger logger.Logger) *AuditHandler {
+	return &AuditHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *AuditHandler) List(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodGet) {
+		return
+	}
+
+	filter, err := auditFilterFromQuery(r)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	if value := r.URL.Query().Get("entity_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			response.Error(w, h.logger, fmt.Errorf("%w: invalid entity_id", ers.ErrInvalidInput))
+			return
+		}
+		filter.EntityID = &id
+	}
+	filter.EntityType = r.URL.Query().Get("entity_type")
+
+	entries, err := h.service.List(r.Context(), filter)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusOK, entries)
+}
+
+func (h *AuditHandler) ProductHistory(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodGet) {
+		return
+	}
+
+	id, err := response.PathID(r, h.logger, "id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	filter, err := auditFilterFromQuery(r)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+	filter.EntityType = domain.AuditEntityProduct
+	filter.EntityID = &id
+
+	entries, err := h.service.List(r.Context(), filter)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusOK, entries)
+}
+
+func auditFilterFromQuery(r *http.Request) (domain.AuditFilter, error) {
+	query := r.URL.Query()
+	filter := domain.AuditFilter{
+		Actor:  query.Get("actor"),
+		Action: domain.AuditAction(query.Get("action")),
+	}
+
+	var err error
+	if value := query.Get("limit"); value != "" {
+		if filter.Limit, err = strconv.Atoi(value); err != nil {
+			return domain.AuditFilter{}, fmt.Errorf("%w: limit must be an integer", ers.ErrInvalidInput)
+		}
+	}
+	if filter.From, err = response.QueryTime(r, "from"); err != nil {
+		return domain.AuditFilter{}, err
+	}
+	if filter.To, err = response.QueryTime(r, "to"); err != nil {
+		return domain.AuditFilter{}, err
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	core "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/repository"
+)
+
+type PostgresAuditRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresAuditRepository(db *sql.DB) *PostgresAuditRepository {
+	return &PostgresAuditRepository{
+		db: db,
+	}
+}
+
+func (i *PostgresAuditRepository) Create(
+	ctx context.Context,
+	e *domain.AuditEntry,
+) error {
+	query := `
+		INSERT INTO audit_log (id, entity_type, entity_id, action, actor, request_id, before, after, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	if _, err := core.Conn(ctx, i.db).ExecContext(
+		ctx,
+		query,
+		e.ID,
+		e.EntityType,
+		e.EntityID,
+		e.Action,
+		e.Actor,
+		e.RequestID,
+		nullJSON(e.Before),
+		nullJSON(e.After),
+		e.CreatedAt,
+	); err != nil {
+		return fmt.Errorf("error inserting audit entry: %w", err)
+	}
+
+	return nil
+}
+
+func (i *PostgresAuditRepository) List(
+	ctx context.Context,
+	filter domain.AuditFilter,
+) ([]domain.AuditEntry, error) {
+	entries := []domain.AuditEntry{}
+
+	query := `
+		SELECT id, entity_type, entity_id, action, actor, request_id, before, after, created_at
+		FROM audit_log
+		WHERE ($1 = '' OR entity_type = $1)
+		  AND ($2::uuid IS NULL OR entity_id = $2)
+		  AND ($3 = '' OR actor = $3)
+		  AND ($4 = '' OR action = $4)
+		  AND ($5::timestamptz IS NULL OR created_at >= $5)
+		  AND ($6::timestamptz IS NULL OR created_at < $6)
+		ORDER BY created_at DESC, id
+		LIMIT $7
+	`
+
+	rows, err := core.Conn(ctx, i.db).QueryContext(
+		ctx,
+		query,
+		filter.EntityType,
+		filter.EntityID,
+		filter.Actor,
+		filter.Action,
+		filter.From,
+		filter.To,
+		filter.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		if err := rows.Close(); err != nil {
+		}
+	}(rows)
+
+	for rows.Next() {
+		var (
+			entry         domain.AuditEntry
+			before, after []byte
+		)
+
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.EntityType,
+			&entry.EntityID,
+			&entry.Action,
+			&entry.Actor,
+			&entry.RequestID,
+			&before,
+			&after,
+			&entry.CreatedAt,
+		); err != nil {
+			return entries, err
+		}
+		entry.Before = before
+		entry.After = after
+
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return entries, nil
+}
+
+func nullJSON(raw []byte) interface{} {
+	if len(raw) == 0 {
+		return nil
+	}
+	return raw
+}
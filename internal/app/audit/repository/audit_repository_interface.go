@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+type AuditRepository interface {
+	Create(ctx context.Context, e *domain.AuditEntry) error
+	List(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error)
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/audit/repository"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/auth"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/requestid"
+)
+
+const (
+	DefaultAuditLimit = 100
+	MaxAuditLimit     = 1000
+)
+
+type auditService struct {
+	repo repository.AuditRepository
+}
+
+func NewAuditService(repo repository.AuditRepository) AuditService {
+	return &auditService{
+		repo: repo,
+	}
+}
+
+// Record stores before/after snapshots with the actor and request id from ctx.
+// Call it inside the transaction of the change so both commit together.
+func (s *auditService) Record(
+	ctx context.Context,
+	entityType string,
+	entityID uuid.UUID,
+	action domain.AuditAction,
+	before interface{},
+	after interface{},
+) error {
+	entry := domain.AuditEntry{
+		ID:         uuid.New(),
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		Actor:      auth.ActorFrom(ctx).ID,
+		RequestID:  requestid.FromContext(ctx),
+		CreatedAt:  time.Now(),
+	}
+
+	var err error
+	if entry.Before, err = snapshot(before); err != nil {
+		return err
+	}
+	if entry.After, err = snapshot(after); err != nil {
+		return err
+	}
+
+	return s.repo.Create(ctx, &entry)
+}
+
+func (s *auditService) List(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	switch {
+	case filter.Limit == 0:
+		filter.Limit = DefaultAuditLimit
+	case filter.Limit < 0 || filter.Limit > MaxAuditLimit:
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", ers.ErrInvalidInput, MaxAuditLimit)
+	}
+
+	switch filter.Action {
+	case "", domain.AuditCreate, domain.AuditUpdate, domain.AuditDelete, domain.AuditRestore:
+	default:
+		return nil, fmt.Errorf("%w: unknown audit action %q", ers.ErrInvalidInput, filter.Action)
+	}
+
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, fmt.Errorf("%w: 'from' must be before 'to'", ers.ErrInvalidInput)
+	}
+
+	return s.repo.List(ctx, filter)
+}
+
+func snapshot(v interface{}) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding audit snapshot: %w", err)
+	}
+	if string(raw) == "null" {
+		return nil, nil
+	}
+	return raw, nil
+}
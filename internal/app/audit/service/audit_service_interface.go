@@ -0,0 +1,20 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+type AuditService interface {
+	Record(
+		ctx context.Context,
+		entityType string,
+		entityID uuid.UUID,
+		action domain.AuditAction,
+		before interface{},
+		after interface{},
+	) error
+	List(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error)
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/audit/repository"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/auth"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/requestid"
+)
+
+type memoryAudit struct {
+	repository.AuditRepository
+	entries []domain.AuditEntry
+	filters []domain.AuditFilter
+}
+
+func (m *memoryAudit) Create(ctx context.Context, e *domain.AuditEntry) error {
+	m.entries = append(m.entries, *e)
+	return nil
+}
+
+func (m *memoryAudit) List(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	m.filters = append(m.filters, filter)
+	return m.entries, nil
+}
+
+func TestRecord_ActorAndSnapshots(t *testing.T) {
+	repo := &memoryAudit{}
+	s := &auditService{repo: repo}
+
+	ctx := auth.WithActor(context.Background(), auth.Actor{ID: "manager-7"})
+	ctx = requestid.WithID(ctx, "req-42")
+
+	after := domain.Product{ID: uuid.New(), Name: "Клавиатура"}
+	if err := s.Record(ctx, domain.AuditEntityProduct, after.ID, domain.AuditCreate, nil, after); err != nil {
+		t.Fatalf("audit record failed: %s", err)
+	}
+
+	if len(repo.entries) != 1 {
+		t.Fatalf("expected one audit entry, but got %d", len(repo.entries))
+	}
+
+	entry := repo.entries[0]
+	if entry.Actor != "manager-7" || entry.RequestID != "req-42" {
+		t.Errorf("expected actor manager-7 and request req-42, but got %q and %q", entry.Actor, entry.RequestID)
+	}
+	if entry.Before != nil {
+		t.Errorf("expected no before snapshot, but got %s", entry.Before)
+	}
+	if len(entry.After) == 0 {
+		t.Errorf("expected an after snapshot, but got none")
+	}
+}
+
+func TestList_DefaultLimit(t *testing.T) {
+	repo := &memoryAudit{}
+	s := &auditService{repo: repo}
+
+	if _, err := s.List(context.Background(), domain.AuditFilter{}); err != nil {
+		t.Fatalf("audit list failed: %s", err)
+	}
+
+	if len(repo.filters) != 1 || repo.filters[0].Limit != DefaultAuditLimit {
+		t.Errorf("expected limit %d, but got %+v", DefaultAuditLimit, repo.filters)
+	}
+}
+
+func TestList_UnknownAction(t *testing.T) {
+	s := &auditService{repo: &memoryAudit{}}
+
+	_, err := s.List(context.Background(), domain.AuditFilter{Action: "purge"})
+	if !errors.Is(err, ers.ErrInvalidInput) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrInvalidInput, err)
+	}
+}
+
+func TestList_InvalidPeriod(t *testing.T) {
+	s := &auditService{repo: &memoryAudit{}}
+
+	from := time.Now()
+	to := from.Add(-time.Hour)
+	_, err := s.List(context.Background(), domain.AuditFilter{From: &from, To: &to})
+	if !errors.Is(err, ers.ErrInvalidInput) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrInvalidInput, err)
+	}
+}
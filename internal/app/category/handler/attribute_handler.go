@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/category/service"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/logger"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/response"
+)
+
+type AttributeHandler struct {
+	service service.AttributeService
+	logger  logger.Logger
+}
+
+func NewAttributeHandler(service service.AttributeService, logger logger.Logger) *AttributeHandler {
+	return &AttributeHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *AttributeHandler) Create(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodPost) {
+		return
+	}
+
+	categoryID, err := response.PathID(r, h.logger, "id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	var req CreateAttributeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, h.logger, fmt.Errorf("%w: invalid body", ers.ErrInvalidInput))
+		return
+	}
+
+	attribute, err := h.service.Create(r.Context(), categoryID, req.ToDomain())
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusCreated, attribute)
+}
+
+func (h *AttributeHandler) ForCategory(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodGet) {
+		return
+	}
+
+	categoryID, err := response.PathID(r, h.logger, "id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	attributes, err := h.service.ForCategory(r.Context(), categoryID)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusOK, attributes)
+}
+
+func (h *AttributeHandler) GetById(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodGet) {
+		return
+	}
+
+	id, err := response.PathID(r, h.logger, "id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	attribute, err := h.service.GetById(r.Context(), id)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusOK, attribute)
+}
+
+func (h *AttributeHandler) Update(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodPatch) {
+		return
+	}
+
+	id, err := response.PathID(r, h.logger, "id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	var req UpdateAttributeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, h.logger, fmt.Errorf("%w: invalid body", ers.ErrInvalidInput))
+		return
+	}
+
+	attribute, err := h.service.Update(r.Context(), id, req.ToUpdateDTO())
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusOK, attribute)
+}
+
+func (h *AttributeHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodDelete) {
+		return
+	}
+
+	id, err := response.PathID(r, h.logger, "id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), id); err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusNoContent, nil)
+}
+
+func (h *AttributeHandler) Values(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodGet) {
+		return
+	}
+
+	productID, err := response.PathID(r, h.logger, "id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	values, err := h.service.Values(r.Context(), productID)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusOK, values)
+}
+
+func (h *AttributeHandler) SetValues(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodPut) {
+		return
+	}
+
+	productID, err := response.PathID(r, h.logger, "id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	var req ProductAttributesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, h.logger, fmt.Errorf("%w: invalid body", ers.ErrInvalidInput))
+		return
+	}
+
+	values, err := h.service.SetValues(r.Context(), productID, req.Values)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusOK, values)
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+type CreateAttributeRequest struct {
+	Code     string               `json:"code"`
+	Name     string               `json:"name"`
+	Type     domain.AttributeType `json:"type"`
+	Unit     string               `json:"unit"`
+	Required bool                 `json:"required"`
+	Options  []string             `json:"options"`
+}
+
+func (r *CreateAttributeRequest) ToDomain() domain.AttributeDefinition {
+	return domain.AttributeDefinition{
+		Code:     r.Code,
+		Name:     r.Name,
+		Type:     r.Type,
+		Unit:     r.Unit,
+		Required: r.Required,
+		Options:  r.Options,
+	}
+}
+
+type UpdateAttributeRequest struct {
+	Name     *string   `json:"name"`
+	Unit     *string   `json:"unit"`
+	Required *bool     `json:"required"`
+	Options  *[]string `json:"options"`
+}
+
+func (r *UpdateAttributeRequest) ToUpdateDTO() domain.UpdateAttributeDTO {
+	return domain.UpdateAttributeDTO{
+		Name:     r.Name,
+		Unit:     r.Unit,
+		Required: r.Required,
+		Options:  r.Options,
+	}
+}
+
+// ProductAttributesRequest maps attribute codes to JSON strings, numbers or
+// booleans according to the attribute type.
+type ProductAttributesRequest struct {
+	Values map[string]interface{} `json:"values"`
+}
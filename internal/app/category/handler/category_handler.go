@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/category/service"
+	ps "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/price/service"
+	product "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/product/handler"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/logger"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/response"
+)
+
+type CategoryHandler struct {
+	service service.CategoryService
+	prices  ps.PriceListService
+	logger  logger.Logger
+}
+
+func NewCategoryHandler(service service.CategoryService, prices ps.PriceListService, logger logger.Logger) *CategoryHandler {
+	return &CategoryHandler{
+		service: service,
+		prices:  prices,
+		logger:  logger,
+	}
+}
+
+func (h *CategoryHandler) Create(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodPost) {
+		return
+	}
+
+	var req CreateCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, h.logger, fmt.Errorf("%w: invalid body", ers.ErrInvalidInput))
+		return
+	}
+
+	category, err := h.service.Create(r.Context(), req.ToDomain())
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusCreated, category)
+}
+
+func (h *CategoryHandler) Tree(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodGet) {
+		return
+	}
+
+	tree, err := h.service.Tree(r.Context())
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusOK, tree)
+}
+
+func (h *CategoryHandler) GetById(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodGet) {
+		return
+	}
+
+	id, err := response.PathID(r, h.logger, "id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	category, err := h.service.GetById(r.Context(), id)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusOK, category)
+}
+
+func (h *CategoryHandler) Update(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodPatch) {
+		return
+	}
+
+	id, err := response.PathID(r, h.logger, "id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	var req UpdateCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, h.logger, fmt.Errorf("%w: invalid body", ers.ErrInvalidInput))
+		return
+	}
+
+	dto, err := req.ToUpdateDTO()
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	category, err := h.service.Update(r.Context(), id, dto)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusOK, category)
+}
+
+func (h *CategoryHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodDelete) {
+		return
+	}
+
+	id, err := response.PathID(r, h.logger, "id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), id); err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusNoContent, nil)
+}
+
+// Products takes the same filters, sorting and cursor as GET /products.
+func (h *CategoryHandler) Products(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodGet) {
+		return
+	}
+
+	id, err := response.PathID(r, h.logger, "id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	filter, err := product.ProductFilterFromQuery(r)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	page, err := h.service.Products(r.Context(), id, filter)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+	if err := product.ResolvePrices(r, h.prices, page.Items); err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusOK, page)
+}
+
+func (h *CategoryHandler) ProductCategories(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodGet) {
+		return
+	}
+
+	id, err := response.PathID(r, h.logger, "id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	categories, err := h.service.ProductCategories(r.Context(), id)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusOK, categories)
+}
+
+func (h *CategoryHandler) SetProductCategories(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodPut) {
+		return
+	}
+
+	id, err := response.PathID(r, h.logger, "id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	var req ProductCategoriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, h.logger, fmt.Errorf("%w: invalid body", ers.ErrInvalidInput))
+		return
+	}
+
+	categories, err := h.service.SetProductCategories(r.Context(), id, req.CategoryIDs)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusOK, categories)
+}
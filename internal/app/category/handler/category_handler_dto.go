@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+)
+
+type CreateCategoryRequest struct {
+	Name     string     `json:"name"`
+	ParentID *uuid.UUID `json:"parent_id"`
+}
+
+func (r *CreateCategoryRequest) ToDomain() domain.Category {
+	return domain.Category{
+		Name:     r.Name,
+		ParentID: r.ParentID,
+	}
+}
+
+// UpdateCategoryRequest takes the parent as a string so that an empty one can
+// move the category to the root while an absent one leaves it in place.
+type UpdateCategoryRequest struct {
+	Name     *string `json:"name"`
+	ParentID *string `json:"parent_id"`
+}
+
+func (r *UpdateCategoryRequest) ToUpdateDTO() (domain.UpdateCategoryDTO, error) {
+	dto := domain.UpdateCategoryDTO{Name: r.Name}
+
+	if r.ParentID != nil {
+		parentID := uuid.Nil
+		if *r.ParentID != "" {
+			var err error
+			if parentID, err = uuid.Parse(*r.ParentID); err != nil {
+				return domain.UpdateCategoryDTO{}, fmt.Errorf("%w: parent_id must be a uuid or empty", ers.ErrInvalidInput)
+			}
+		}
+		dto.ParentID = &parentID
+	}
+
+	return dto, nil
+}
+
+type ProductCategoriesRequest struct {
+	CategoryIDs []uuid.UUID `json:"category_ids"`
+}
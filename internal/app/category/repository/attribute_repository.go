@@ -0,0 +1,368 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	core "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/repository"
+	"github.com/lib/pq"
+)
+
+const attributeColumns = `a.id, a.category_id, a.code, a.name, a.type, a.unit, a.required, a.options, a.created_at, a.updated_at`
+
+type PostgresAttributeRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresAttributeRepository(db *sql.DB) *PostgresAttributeRepository {
+	return &PostgresAttributeRepository{
+		db: db,
+	}
+}
+
+func (i *PostgresAttributeRepository) Create(
+	ctx context.Context,
+	a *domain.AttributeDefinition,
+) (domain.AttributeDefinition, error) {
+	query := `
+		INSERT INTO category_attributes (id, category_id, code, name, type, unit, required, options, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	if _, err := core.Conn(ctx, i.db).ExecContext(
+		ctx,
+		query,
+		a.ID,
+		a.CategoryID,
+		a.Code,
+		a.Name,
+		a.Type,
+		a.Unit,
+		a.Required,
+		optionsArray(a.Options),
+		a.CreatedAt,
+		a.UpdatedAt,
+	); err != nil {
+		if isViolation(err, uniqueViolation) {
+			return domain.AttributeDefinition{}, fmt.Errorf("%w: code %q is taken", ers.ErrAttributeExists, a.Code)
+		}
+		if isViolation(err, foreignKeyViolation) {
+			return domain.AttributeDefinition{}, fmt.Errorf("%w: category not found", ers.ErrCategoryNotFound)
+		}
+		return domain.AttributeDefinition{}, fmt.Errorf("error inserting attribute: %w", err)
+	}
+
+	return *a, nil
+}
+
+func (i *PostgresAttributeRepository) GetById(
+	ctx context.Context,
+	id uuid.UUID,
+) (domain.AttributeDefinition, error) {
+	query := `
+		SELECT ` + attributeColumns + `
+		FROM category_attributes a
+		WHERE a.id = $1
+	`
+
+	attribute, err := scanAttribute(core.Conn(ctx, i.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.AttributeDefinition{}, fmt.Errorf("%w: attribute not found", ers.ErrAttributeNotFound)
+		}
+		return domain.AttributeDefinition{}, err
+	}
+
+	return attribute, nil
+}
+
+// ForCategory returns the attributes in effect for a category: its own and
+// those inherited from its ancestors, the root's first.
+func (i *PostgresAttributeRepository) ForCategory(
+	ctx context.Context,
+	categoryID uuid.UUID,
+) ([]domain.AttributeDefinition, error) {
+	query := `
+		SELECT ` + attributeColumns + `
+		FROM categories c
+		JOIN categories ac ON c.path LIKE ac.path || '%'
+		JOIN category_attributes a ON a.category_id = ac.id
+		WHERE c.id = $1
+		ORDER BY length(ac.path), a.code
+	`
+
+	return i.list(ctx, query, categoryID)
+}
+
+// ForProduct returns the attributes in effect for any category of the product.
+// Variants take the categories of their parent.
+func (i *PostgresAttributeRepository) ForProduct(
+	ctx context.Context,
+	productID uuid.UUID,
+) ([]domain.AttributeDefinition, error) {
+	query := `
+		SELECT ` + attributeColumns + `
+		FROM category_attributes a
+		JOIN categories ac ON ac.id = a.category_id
+		WHERE EXISTS (
+			SELECT 1
+			FROM products p
+			JOIN product_categories pc ON pc.product_id = COALESCE(p.parent_id, p.id)
+			JOIN categories c ON c.id = pc.category_id
+			WHERE p.id = $1 AND c.path LIKE ac.path || '%'
+		)
+		ORDER BY length(ac.path), a.code
+	`
+
+	return i.list(ctx, query, productID)
+}
+
+// CodeTaken reports whether the code is defined on the category at
+// categoryPath, one of its ancestors or one of its descendants.
+func (i *PostgresAttributeRepository) CodeTaken(ctx context.Context, categoryPath string, code string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM category_attributes a
+			JOIN categories ac ON ac.id = a.category_id
+			WHERE a.code = $2 AND ($1 LIKE ac.path || '%' OR ac.path LIKE $1 || '%')
+		)
+	`
+
+	var taken bool
+	if err := core.Conn(ctx, i.db).QueryRowContext(ctx, query, categoryPath, code).Scan(&taken); err != nil {
+		return false, err
+	}
+
+	return taken, nil
+}
+
+func (i *PostgresAttributeRepository) Update(
+	ctx context.Context,
+	id uuid.UUID,
+	a domain.AttributeDefinition,
+) (domain.AttributeDefinition, error) {
+	query := `
+		UPDATE category_attributes a
+		SET name = $1, unit = $2, required = $3, options = $4, updated_at = $5
+		WHERE a.id = $6
+		RETURNING ` + attributeColumns + `
+	`
+
+	updated, err := scanAttribute(core.Conn(ctx, i.db).QueryRowContext(
+		ctx,
+		query,
+		a.Name, a.Unit, a.Required, optionsArray(a.Options), a.UpdatedAt, id,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.AttributeDefinition{}, fmt.Errorf("%w: attribute not found", ers.ErrAttributeNotFound)
+		}
+		return domain.AttributeDefinition{}, fmt.Errorf("error updating attribute: %w", err)
+	}
+
+	return updated, nil
+}
+
+// Delete removes the attribute together with its values on products.
+func (i *PostgresAttributeRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := core.Conn(ctx, i.db).ExecContext(ctx, `DELETE FROM category_attributes WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: attribute not found", ers.ErrAttributeNotFound)
+	}
+
+	return nil
+}
+
+// UsedOptions returns the distinct values products hold for the attribute.
+func (i *PostgresAttributeRepository) UsedOptions(ctx context.Context, id uuid.UUID) ([]string, error) {
+	var used pq.StringArray
+	if err := core.Conn(ctx, i.db).QueryRowContext(
+		ctx,
+		`SELECT ARRAY(SELECT DISTINCT value_text FROM product_attribute_values WHERE attribute_id = $1 AND value_text IS NOT NULL)`,
+		id,
+	).Scan(&used); err != nil {
+		return nil, err
+	}
+
+	return used, nil
+}
+
+func (i *PostgresAttributeRepository) Values(
+	ctx context.Context,
+	productID uuid.UUID,
+) ([]domain.AttributeValue, error) {
+	values := []domain.AttributeValue{}
+
+	query := `
+		SELECT a.id, a.code, a.name, a.type, a.unit, v.value_text, v.value_number
+		FROM product_attribute_values v
+		JOIN category_attributes a ON a.id = v.attribute_id
+		WHERE v.product_id = $1
+		ORDER BY a.code
+	`
+
+	rows, err := core.Conn(ctx, i.db).QueryContext(ctx, query, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		if err := rows.Close(); err != nil {
+		}
+	}(rows)
+
+	for rows.Next() {
+		var (
+			value  domain.AttributeValue
+			text   sql.NullString
+			number sql.NullFloat64
+		)
+
+		if err := rows.Scan(
+			&value.AttributeID,
+			&value.Code,
+			&value.Name,
+			&value.Type,
+			&value.Unit,
+			&text,
+			&number,
+		); err != nil {
+			return values, err
+		}
+
+		switch value.Type {
+		case domain.AttributeNumber:
+			value.Value = number.Float64
+		case domain.AttributeBoolean:
+			value.Value, _ = strconv.ParseBool(text.String)
+		default:
+			value.Value = text.String
+		}
+
+		values = append(values, value)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return values, nil
+}
+
+// SetValues replaces the attribute values of a product. Values must already
+// carry the Go type matching their attribute type.
+func (i *PostgresAttributeRepository) SetValues(
+	ctx context.Context,
+	productID uuid.UUID,
+	values []domain.AttributeValue,
+) error {
+	conn := core.Conn(ctx, i.db)
+
+	if _, err := conn.ExecContext(ctx, `DELETE FROM product_attribute_values WHERE product_id = $1`, productID); err != nil {
+		return fmt.Errorf("error clearing attribute values: %w", err)
+	}
+
+	for _, value := range values {
+		var (
+			text   sql.NullString
+			number sql.NullFloat64
+		)
+		switch v := value.Value.(type) {
+		case float64:
+			number = sql.NullFloat64{Float64: v, Valid: true}
+		case bool:
+			text = sql.NullString{String: strconv.FormatBool(v), Valid: true}
+		case string:
+			text = sql.NullString{String: v, Valid: true}
+		default:
+			return fmt.Errorf("unsupported value %T for attribute %s", value.Value, value.Code)
+		}
+
+		query := `
+			INSERT INTO product_attribute_values (product_id, attribute_id, value_text, value_number)
+			VALUES ($1, $2, $3, $4)
+		`
+
+		if _, err := conn.ExecContext(ctx, query, productID, value.AttributeID, text, number); err != nil {
+			if isViolation(err, foreignKeyViolation) {
+				return fmt.Errorf("%w: attribute %s not found", ers.ErrAttributeNotFound, value.Code)
+			}
+			return fmt.Errorf("error inserting attribute value: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (i *PostgresAttributeRepository) list(
+	ctx context.Context,
+	query string,
+	args ...interface{},
+) ([]domain.AttributeDefinition, error) {
+	attributes := []domain.AttributeDefinition{}
+
+	rows, err := core.Conn(ctx, i.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		if err := rows.Close(); err != nil {
+		}
+	}(rows)
+
+	for rows.Next() {
+		attribute, err := scanAttribute(rows)
+		if err != nil {
+			return attributes, err
+		}
+		attributes = append(attributes, attribute)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return attributes, nil
+}
+
+// optionsArray keeps the NOT NULL options column at '{}' for non-enum attributes.
+func optionsArray(options []string) pq.StringArray {
+	if options == nil {
+		return pq.StringArray{}
+	}
+	return options
+}
+
+func scanAttribute(row rowScanner) (domain.AttributeDefinition, error) {
+	var attribute domain.AttributeDefinition
+
+	if err := row.Scan(
+		&attribute.ID,
+		&attribute.CategoryID,
+		&attribute.Code,
+		&attribute.Name,
+		&attribute.Type,
+		&attribute.Unit,
+		&attribute.Required,
+		(*pq.StringArray)(&attribute.Options),
+		&attribute.CreatedAt,
+		&attribute.UpdatedAt,
+	); err != nil {
+		return domain.AttributeDefinition{}, err
+	}
+
+	return attribute, nil
+}
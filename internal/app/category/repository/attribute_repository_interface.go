@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+type AttributeRepository interface {
+	Create(ctx context.Context, a *domain.AttributeDefinition) (domain.AttributeDefinition, error)
+	GetById(ctx context.Context, id uuid.UUID) (domain.AttributeDefinition, error)
+	ForCategory(ctx context.Context, categoryID uuid.UUID) ([]domain.AttributeDefinition, error)
+	ForProduct(ctx context.Context, productID uuid.UUID) ([]domain.AttributeDefinition, error)
+	CodeTaken(ctx context.Context, categoryPath string, code string) (bool, error)
+	Update(ctx context.Context, id uuid.UUID, a domain.AttributeDefinition) (domain.AttributeDefinition, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	UsedOptions(ctx context.Context, id uuid.UUID) ([]string, error)
+	Values(ctx context.Context, productID uuid.UUID) ([]domain.AttributeValue, error)
+	SetValues(ctx context.Context, productID uuid.UUID, values []domain.AttributeValue) error
+}
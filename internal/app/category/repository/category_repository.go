@@ -0,0 +1,316 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	core "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/repository"
+	"github.com/lib/pq"
+)
+
+const (
+	uniqueViolation     = "23505"
+	foreignKeyViolation = "23503"
+)
+
+const categoryColumns = `c.id, c.parent_id, c.name, c.path, c.created_at, c.updated_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+type PostgresCategoryRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresCategoryRepository(db *sql.DB) *PostgresCategoryRepository {
+	return &PostgresCategoryRepository{
+		db: db,
+	}
+}
+
+func (i *PostgresCategoryRepository) Create(
+	ctx context.Context,
+	c *domain.Category,
+) (domain.Category, error) {
+	query := `
+		INSERT INTO categories (id, parent_id, name, path, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	if _, err := core.Conn(ctx, i.db).ExecContext(
+		ctx,
+		query,
+		c.ID,
+		c.ParentID,
+		c.Name,
+		c.Path,
+		c.CreatedAt,
+		c.UpdatedAt,
+	); err != nil {
+		if isViolation(err, uniqueViolation) {
+			return domain.Category{}, fmt.Errorf("%w: %q already exists at this level", ers.ErrCategoryExists, c.Name)
+		}
+		if isViolation(err, foreignKeyViolation) {
+			return domain.Category{}, fmt.Errorf("%w: parent category not found", ers.ErrCategoryNotFound)
+		}
+		return domain.Category{}, fmt.Errorf("error inserting category: %w", err)
+	}
+
+	return *c, nil
+}
+
+func (i *PostgresCategoryRepository) GetById(
+	ctx context.Context,
+	id uuid.UUID,
+) (domain.Category, error) {
+	query := `
+		SELECT ` + categoryColumns + `
+		FROM categories c
+		WHERE c.id = $1
+	`
+
+	category, err := scanCategory(core.Conn(ctx, i.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Category{}, fmt.Errorf("%w: category not found", ers.ErrCategoryNotFound)
+		}
+		return domain.Category{}, err
+	}
+
+	return category, nil
+}
+
+// GetAll returns every category as a flat list ordered by name.
+func (i *PostgresCategoryRepository) GetAll(ctx context.Context) ([]domain.Category, error) {
+	query := `
+		SELECT ` + categoryColumns + `
+		FROM categories c
+		ORDER BY lower(c.name), c.id
+	`
+
+	return i.list(ctx, query)
+}
+
+func (i *PostgresCategoryRepository) Update(
+	ctx context.Context,
+	id uuid.UUID,
+	c domain.Category,
+) (domain.Category, error) {
+	query := `
+		UPDATE categories c
+		SET name = $1, parent_id = $2, updated_at = $3
+		WHERE c.id = $4
+		RETURNING ` + categoryColumns + `
+	`
+
+	updated, err := scanCategory(core.Conn(ctx, i.db).QueryRowContext(
+		ctx,
+		query,
+		c.Name, c.ParentID, c.UpdatedAt, id,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Category{}, fmt.Errorf("%w: category not found", ers.ErrCategoryNotFound)
+		}
+		if isViolation(err, uniqueViolation) {
+			return domain.Category{}, fmt.Errorf("%w: %q already exists at this level", ers.ErrCategoryExists, c.Name)
+		}
+		return domain.Category{}, fmt.Errorf("error updating category: %w", err)
+	}
+
+	return updated, nil
+}
+
+// Move rewrites the path prefix of a category and all of its descendants.
+func (i *PostgresCategoryRepository) Move(ctx context.Context, oldPath string, newPath string) error {
+	query := `
+		UPDATE categories
+		SET path = $2 || substr(path, length($1) + 1)
+		WHERE path LIKE $1 || '%'
+	`
+
+	if _, err := core.Conn(ctx, i.db).ExecContext(ctx, query, oldPath, newPath); err != nil {
+		return fmt.Errorf("error moving categories: %w", err)
+	}
+
+	return nil
+}
+
+// SubtreeDepth returns the depth of the deepest category under path, the category itself included.
+func (i *PostgresCategoryRepository) SubtreeDepth(ctx context.Context, path string) (int, error) {
+	query := `
+		SELECT COALESCE(MAX(length(path) - length(replace(path, '/', ''))) - 1, 0)
+		FROM categories
+		WHERE path LIKE $1 || '%'
+	`
+
+	var depth int
+	if err := core.Conn(ctx, i.db).QueryRowContext(ctx, query, path).Scan(&depth); err != nil {
+		return 0, err
+	}
+
+	return depth, nil
+}
+
+// Delete removes a category without subcategories or live products. Links to
+// soft-deleted products are dropped with it.
+func (i *PostgresCategoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	conn := core.Conn(ctx, i.db)
+
+	var hasChildren bool
+	if err := conn.QueryRowContext(
+		ctx,
+		`SELECT EXISTS (SELECT 1 FROM categories WHERE parent_id = $1)`,
+		id,
+	).Scan(&hasChildren); err != nil {
+		return err
+	}
+	if hasChildren {
+		return fmt.Errorf("%w: category has subcategories", ers.ErrCategoryNotEmpty)
+	}
+
+	var products int
+	if err := conn.QueryRowContext(
+		ctx,
+		`SELECT COUNT(*)
+		 FROM product_categories pc
+		 JOIN products p ON p.id = pc.product_id
+		 WHERE pc.category_id = $1 AND p.deleted_at IS NULL`,
+		id,
+	).Scan(&products); err != nil {
+		return err
+	}
+	if products > 0 {
+		return fmt.Errorf("%w: category holds %d products", ers.ErrCategoryNotEmpty, products)
+	}
+
+	if _, err := conn.ExecContext(ctx, `DELETE FROM product_categories WHERE category_id = $1`, id); err != nil {
+		return err
+	}
+
+	result, err := conn.ExecContext(ctx, `DELETE FROM categories WHERE id = $1`, id)
+	if err != nil {
+		if isViolation(err, foreignKeyViolation) {
+			return fmt.Errorf("%w: category is still referenced", ers.ErrCategoryNotEmpty)
+		}
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: category not found", ers.ErrCategoryNotFound)
+	}
+
+	return nil
+}
+
+func (i *PostgresCategoryRepository) ProductCategories(
+	ctx context.Context,
+	productID uuid.UUID,
+) ([]domain.Category, error) {
+	query := `
+		SELECT ` + categoryColumns + `
+		FROM product_categories pc
+		JOIN categories c ON c.id = pc.category_id
+		WHERE pc.product_id = $1
+		ORDER BY c.path
+	`
+
+	return i.list(ctx, query, productID)
+}
+
+// SetProductCategories replaces the categories a product is assigned to.
+func (i *PostgresCategoryRepository) SetProductCategories(
+	ctx context.Context,
+	productID uuid.UUID,
+	categoryIDs []uuid.UUID,
+) error {
+	conn := core.Conn(ctx, i.db)
+
+	if _, err := conn.ExecContext(ctx, `DELETE FROM product_categories WHERE product_id = $1`, productID); err != nil {
+		return fmt.Errorf("error clearing product categories: %w", err)
+	}
+
+	for _, categoryID := range categoryIDs {
+		query := `
+			INSERT INTO product_categories (product_id, category_id)
+			VALUES ($1, $2)
+		`
+
+		if _, err := conn.ExecContext(ctx, query, productID, categoryID); err != nil {
+			if isViolation(err, foreignKeyViolation) {
+				return fmt.Errorf("%w: category %s not found", ers.ErrCategoryNotFound, categoryID)
+			}
+			return fmt.Errorf("error assigning category: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (i *PostgresCategoryRepository) list(
+	ctx context.Context,
+	query string,
+	args ...interface{},
+) ([]domain.Category, error) {
+	categories := []domain.Category{}
+
+	rows, err := core.Conn(ctx, i.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		if err := rows.Close(); err != nil {
+		}
+	}(rows)
+
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return categories, err
+		}
+		categories = append(categories, category)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return categories, nil
+}
+
+func scanCategory(row rowScanner) (domain.Category, error) {
+	var (
+		category domain.Category
+		parentID uuid.NullUUID
+	)
+
+	if err := row.Scan(
+		&category.ID,
+		&parentID,
+		&category.Name,
+		&category.Path,
+		&category.CreatedAt,
+		&category.UpdatedAt,
+	); err != nil {
+		return domain.Category{}, err
+	}
+	if parentID.Valid {
+		category.ParentID = &parentID.UUID
+	}
+
+	return category, nil
+}
+
+func isViolation(err error, code pq.ErrorCode) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == code
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+type CategoryRepository interface {
+	Create(ctx context.Context, c *domain.Category) (domain.Category, error)
+	GetById(ctx context.Context, id uuid.UUID) (domain.Category, error)
+	GetAll(ctx context.Context) ([]domain.Category, error)
+	Update(ctx context.Context, id uuid.UUID, c domain.Category) (domain.Category, error)
+	Move(ctx context.Context, oldPath string, newPath string) error
+	SubtreeDepth(ctx context.Context, path string) (int, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	ProductCategories(ctx context.Context, productID uuid.UUID) ([]domain.Category, error)
+	SetProductCategories(ctx context.Context, productID uuid.UUID, categoryIDs []uuid.UUID) error
+}
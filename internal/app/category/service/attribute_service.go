@@ -0,0 +1,358 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/category/repository"
+	product "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/product/service"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	core "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/repository"
+)
+
+const (
+	MaxAttributeOptions     = 200
+	maxAttributeNameLength  = 200
+	maxAttributeUnitLength  = 32
+	maxAttributeValueLength = 500
+)
+
+type attributeService struct {
+	repo       repository.AttributeRepository
+	categories repository.CategoryRepository
+	products   product.ProductService
+	tx         core.Transactor
+}
+
+func NewAttributeService(
+	repo repository.AttributeRepository,
+	categories repository.CategoryRepository,
+	products product.ProductService,
+	tx core.Transactor,
+) AttributeService {
+	return &attributeService{
+		repo:       repo,
+		categories: categories,
+		products:   products,
+		tx:         tx,
+	}
+}
+
+// Create defines an attribute on a category. The code must be unique along the
+// category's branch, so a product never sees two attributes with one code.
+func (s *attributeService) Create(
+	ctx context.Context,
+	categoryID uuid.UUID,
+	a domain.AttributeDefinition,
+) (domain.AttributeDefinition, error) {
+	if categoryID == uuid.Nil {
+		return domain.AttributeDefinition{}, fmt.Errorf("%w: invalid category id", ers.ErrInvalidInput)
+	}
+
+	a.Code = strings.TrimSpace(a.Code)
+	a.Name = strings.TrimSpace(a.Name)
+	a.Unit = strings.TrimSpace(a.Unit)
+	if !domain.ValidAttributeCode(a.Code) {
+		return domain.AttributeDefinition{}, fmt.Errorf(
+			"%w: attribute code must be 1-64 lowercase latin letters, digits or '_' starting with a letter",
+			ers.ErrInvalidInput,
+		)
+	}
+	if err := validateAttributeDefinition(&a); err != nil {
+		return domain.AttributeDefinition{}, err
+	}
+
+	var created domain.AttributeDefinition
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		category, err := s.categories.GetById(ctx, categoryID)
+		if err != nil {
+			return err
+		}
+
+		taken, err := s.repo.CodeTaken(ctx, category.Path, a.Code)
+		if err != nil {
+			return err
+		}
+		if taken {
+			return fmt.Errorf("%w: %q is already defined in this branch of the tree", ers.ErrAttributeExists, a.Code)
+		}
+
+		a.ID = uuid.New()
+		a.CategoryID = categoryID
+		a.CreatedAt = time.Now()
+		a.UpdatedAt = time.Now()
+
+		created, err = s.repo.Create(ctx, &a)
+		return err
+	})
+	if err != nil {
+		return domain.AttributeDefinition{}, err
+	}
+
+	return created, nil
+}
+
+func (s *attributeService) GetById(ctx context.Context, id uuid.UUID) (domain.AttributeDefinition, error) {
+	if id == uuid.Nil {
+		return domain.AttributeDefinition{}, fmt.Errorf("%w: invalid attribute id", ers.ErrInvalidInput)
+	}
+	return s.repo.GetById(ctx, id)
+}
+
+// ForCategory lists the attributes in effect for the category, inherited ones included.
+func (s *attributeService) ForCategory(ctx context.Context, categoryID uuid.UUID) ([]domain.AttributeDefinition, error) {
+	if categoryID == uuid.Nil {
+		return nil, fmt.Errorf("%w: invalid category id", ers.ErrInvalidInput)
+	}
+	if _, err := s.categories.GetById(ctx, categoryID); err != nil {
+		return nil, err
+	}
+	return s.repo.ForCategory(ctx, categoryID)
+}
+
+// Update changes everything but the code and type. Enum options that
+// products still hold cannot be removed.
+func (s *attributeService) Update(
+	ctx context.Context,
+	id uuid.UUID,
+	dto domain.UpdateAttributeDTO,
+) (domain.AttributeDefinition, error) {
+	if id == uuid.Nil {
+		return domain.AttributeDefinition{}, fmt.Errorf("%w: invalid attribute id", ers.ErrInvalidInput)
+	}
+
+	var updated domain.AttributeDefinition
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := s.repo.GetById(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if dto.Name != nil {
+			current.Name = strings.TrimSpace(*dto.Name)
+		}
+		if dto.Unit != nil {
+			current.Unit = strings.TrimSpace(*dto.Unit)
+		}
+		if dto.Required != nil {
+			current.Required = *dto.Required
+		}
+		if dto.Options != nil {
+			current.Options = *dto.Options
+		}
+		current.UpdatedAt = time.Now()
+
+		if err := validateAttributeDefinition(&current); err != nil {
+			return err
+		}
+
+		if dto.Options != nil {
+			used, err := s.repo.UsedOptions(ctx, id)
+			if err != nil {
+				return err
+			}
+			for _, option := range used {
+				if !contains(current.Options, option) {
+					return fmt.Errorf("%w: products still have %q", ers.ErrAttributeInUse, option)
+				}
+			}
+		}
+
+		updated, err = s.repo.Update(ctx, id, current)
+		return err
+	})
+	if err != nil {
+		return domain.AttributeDefinition{}, err
+	}
+
+	return updated, nil
+}
+
+// Delete removes the attribute and its values on every product.
+func (s *attributeService) Delete(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return fmt.Errorf("%w: invalid attribute id", ers.ErrInvalidInput)
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *attributeService) Values(ctx context.Context, productID uuid.UUID) ([]domain.AttributeValue, error) {
+	if _, err := s.products.GetById(ctx, productID, false); err != nil {
+		return nil, err
+	}
+	return s.repo.Values(ctx, productID)
+}
+
+// SetValues replaces the attribute values of a product. Every code must belong
+// to an attribute of the product's categories, every value must fit its type
+// and every required attribute must be given; a null value leaves it unset.
+func (s *attributeService) SetValues(
+	ctx context.Context,
+	productID uuid.UUID,
+	values map[string]interface{},
+) ([]domain.AttributeValue, error) {
+	var stored []domain.AttributeValue
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := s.products.GetById(ctx, productID, false); err != nil {
+			return err
+		}
+
+		definitions, err := s.repo.ForProduct(ctx, productID)
+		if err != nil {
+			return err
+		}
+
+		checked, err := validateAttributeValues(definitions, values)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repo.SetValues(ctx, productID, checked); err != nil {
+			return err
+		}
+		stored, err = s.repo.Values(ctx, productID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return stored, nil
+}
+
+func validateAttributeDefinition(a *domain.AttributeDefinition) error {
+	if a.Name == "" || utf8.RuneCountInString(a.Name) > maxAttributeNameLength {
+		return fmt.Errorf("%w: attribute name must be 1 to %d characters", ers.ErrInvalidInput, maxAttributeNameLength)
+	}
+	if utf8.RuneCountInString(a.Unit) > maxAttributeUnitLength {
+		return fmt.Errorf("%w: attribute unit is too long", ers.ErrInvalidInput)
+	}
+
+	switch a.Type {
+	case domain.AttributeString, domain.AttributeNumber, domain.AttributeBoolean:
+		if len(a.Options) > 0 {
+			return fmt.Errorf("%w: only enum attributes have options", ers.ErrInvalidInput)
+		}
+	case domain.AttributeEnum:
+		if len(a.Options) == 0 || len(a.Options) > MaxAttributeOptions {
+			return fmt.Errorf("%w: an enum needs 1 to %d options", ers.ErrInvalidInput, MaxAttributeOptions)
+		}
+		options := make([]string, 0, len(a.Options))
+		for _, option := range a.Options {
+			option = strings.TrimSpace(option)
+			if option == "" || utf8.RuneCountInString(option) > maxAttributeValueLength {
+				return fmt.Errorf("%w: enum options must be 1 to %d characters", ers.ErrInvalidInput, maxAttributeValueLength)
+			}
+			if contains(options, option) {
+				return fmt.Errorf("%w: option %q is listed twice", ers.ErrInvalidInput, option)
+			}
+			options = append(options, option)
+		}
+		a.Options = options
+	default:
+		return fmt.Errorf("%w: attribute type must be string, number, enum or boolean", ers.ErrInvalidInput)
+	}
+
+	if a.Type == domain.AttributeBoolean && a.Unit != "" {
+		return fmt.Errorf("%w: boolean attributes have no unit", ers.ErrInvalidInput)
+	}
+	return nil
+}
+
+// validateAttributeValues checks raw JSON values against the definitions and
+// returns them typed for storage.
+func validateAttributeValues(
+	definitions []domain.AttributeDefinition,
+	values map[string]interface{},
+) ([]domain.AttributeValue, error) {
+	byCode := make(map[string]domain.AttributeDefinition, len(definitions))
+	for _, definition := range definitions {
+		if _, ok := byCode[definition.Code]; !ok {
+			byCode[definition.Code] = definition
+		}
+	}
+
+	for code := range values {
+		if _, ok := byCode[code]; !ok {
+			return nil, fmt.Errorf("%w: attribute %q does not apply to this product", ers.ErrInvalidInput, code)
+		}
+	}
+
+	checked := []domain.AttributeValue{}
+	for _, definition := range definitions {
+		if byCode[definition.Code].ID != definition.ID {
+			continue
+		}
+
+		raw := values[definition.Code]
+		if raw == nil {
+			if definition.Required {
+				return nil, fmt.Errorf("%w: attribute %q is required", ers.ErrInvalidInput, definition.Code)
+			}
+			continue
+		}
+
+		value, err := validateAttributeValue(definition, raw)
+		if err != nil {
+			return nil, err
+		}
+		checked = append(checked, domain.AttributeValue{
+			AttributeID: definition.ID,
+			Code:        definition.Code,
+			Name:        definition.Name,
+			Type:        definition.Type,
+			Unit:        definition.Unit,
+			Value:       value,
+		})
+	}
+
+	return checked, nil
+}
+
+func validateAttributeValue(definition domain.AttributeDefinition, raw interface{}) (interface{}, error) {
+	switch definition.Type {
+	case domain.AttributeNumber:
+		n, ok := raw.(float64)
+		if !ok || math.IsNaN(n) || math.IsInf(n, 0) {
+			return nil, fmt.Errorf("%w: attribute %q must be a number", ers.ErrInvalidInput, definition.Code)
+		}
+		return n, nil
+	case domain.AttributeBoolean:
+		b, ok := raw.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%w: attribute %q must be true or false", ers.ErrInvalidInput, definition.Code)
+		}
+		return b, nil
+	case domain.AttributeEnum:
+		s, ok := raw.(string)
+		if !ok || !contains(definition.Options, s) {
+			return nil, fmt.Errorf("%w: attribute %q must be one of %s", ers.ErrInvalidInput, definition.Code, strings.Join(definition.Options, ", "))
+		}
+		return s, nil
+	default:
+		s, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: attribute %q must be a string", ers.ErrInvalidInput, definition.Code)
+		}
+		s = strings.TrimSpace(s)
+		if s == "" || utf8.RuneCountInString(s) > maxAttributeValueLength {
+			return nil, fmt.Errorf("%w: attribute %q must be 1 to %d characters", ers.ErrInvalidInput, definition.Code, maxAttributeValueLength)
+		}
+		return s, nil
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+type AttributeService interface {
+	Create(ctx context.Context, categoryID uuid.UUID, a domain.AttributeDefinition) (domain.AttributeDefinition, error)
+	GetById(ctx context.Context, id uuid.UUID) (domain.AttributeDefinition, error)
+	ForCategory(ctx context.Context, categoryID uuid.UUID) ([]domain.AttributeDefinition, error)
+	Update(ctx context.Context, id uuid.UUID, dto domain.UpdateAttributeDTO) (domain.AttributeDefinition, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	Values(ctx context.Context, productID uuid.UUID) ([]domain.AttributeValue, error)
+	SetValues(ctx context.Context, productID uuid.UUID, values map[string]interface{}) ([]domain.AttributeValue, error)
+}
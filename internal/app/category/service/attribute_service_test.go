@@ -0,0 +1,83 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+)
+
+func attributes() []domain.AttributeDefinition {
+	return []domain.AttributeDefinition{
+		{ID: uuid.New(), Code: "color", Name: "Цвет", Type: domain.AttributeEnum, Options: []string{"red", "black"}, Required: true},
+		{ID: uuid.New(), Code: "weight", Name: "Вес", Type: domain.AttributeNumber, Unit: "kg"},
+		{ID: uuid.New(), Code: "waterproof", Name: "Водонепроницаемые", Type: domain.AttributeBoolean},
+	}
+}
+
+func TestValidateAttributeValues_TypesValues(t *testing.T) {
+	values, err := validateAttributeValues(attributes(), map[string]interface{}{
+		"color":      "red",
+		"weight":     1.5,
+		"waterproof": true,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, but got %v", err)
+	}
+	if len(values) != 3 {
+		t.Fatalf("expected 3 values, but got %d", len(values))
+	}
+	if values[1].Value != 1.5 || values[1].Unit != "kg" {
+		t.Errorf("expected weight 1.5 kg, but got %v %s", values[1].Value, values[1].Unit)
+	}
+}
+
+func TestValidateAttributeValues_RejectsTypeMismatch(t *testing.T) {
+	_, err := validateAttributeValues(attributes(), map[string]interface{}{"color": "red", "weight": "heavy"})
+	if !errors.Is(err, ers.ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, but got %v", err)
+	}
+}
+
+func TestValidateAttributeValues_RejectsUnknownOption(t *testing.T) {
+	_, err := validateAttributeValues(attributes(), map[string]interface{}{"color": "green"})
+	if !errors.Is(err, ers.ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, but got %v", err)
+	}
+}
+
+func TestValidateAttributeValues_RequiresRequired(t *testing.T) {
+	_, err := validateAttributeValues(attributes(), map[string]interface{}{"weight": 2.0})
+	if !errors.Is(err, ers.ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, but got %v", err)
+	}
+}
+
+func TestValidateAttributeValues_RejectsUnknownCode(t *testing.T) {
+	_, err := validateAttributeValues(attributes(), map[string]interface{}{"color": "red", "size": "42"})
+	if !errors.Is(err, ers.ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, but got %v", err)
+	}
+}
+
+func TestValidateAttributeDefinition_Enum(t *testing.T) {
+	a := domain.AttributeDefinition{Code: "size", Name: "Размер", Type: domain.AttributeEnum, Options: []string{" S ", "M"}}
+	if err := validateAttributeDefinition(&a); err != nil {
+		t.Fatalf("expected no error, but got %v", err)
+	}
+	if a.Options[0] != "S" {
+		t.Errorf("expected trimmed option S, but got %q", a.Options[0])
+	}
+
+	a.Options = []string{"M", "M"}
+	if err := validateAttributeDefinition(&a); !errors.Is(err, ers.ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for duplicate options, but got %v", err)
+	}
+
+	b := domain.AttributeDefinition{Code: "weight", Name: "Вес", Type: domain.AttributeNumber, Options: []string{"1"}}
+	if err := validateAttributeDefinition(&b); !errors.Is(err, ers.ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for options on a number, but got %v", err)
+	}
+}
@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/category/repository"
+	product "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/product/service"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	core "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/repository"
+)
+
+const (
+	MaxCategoryDepth      = 8
+	MaxProductCategories  = 20
+	maxCategoryNameLength = 200
+)
+
+type categoryService struct {
+	repo     repository.CategoryRepository
+	products product.ProductService
+	tx       core.Transactor
+}
+
+func NewCategoryService(
+	repo repository.CategoryRepository,
+	products product.ProductService,
+	tx core.Transactor,
+) CategoryService {
+	return &categoryService{
+		repo:     repo,
+		products: products,
+		tx:       tx,
+	}
+}
+
+func (s *categoryService) Create(ctx context.Context, c domain.Category) (domain.Category, error) {
+	c.Name = strings.TrimSpace(c.Name)
+	if err := validateCategory(c); err != nil {
+		return domain.Category{}, err
+	}
+
+	c.ID = uuid.New()
+	c.Path = "/" + c.ID.String() + "/"
+	if c.ParentID != nil {
+		parent, err := s.GetById(ctx, *c.ParentID)
+		if err != nil {
+			return domain.Category{}, err
+		}
+		if parent.Depth() >= MaxCategoryDepth {
+			return domain.Category{}, fmt.Errorf("%w: categories nest at most %d levels deep", ers.ErrInvalidInput, MaxCategoryDepth)
+		}
+		c.Path = parent.Path + c.ID.String() + "/"
+	}
+	c.CreatedAt = time.Now()
+	c.UpdatedAt = time.Now()
+
+	return s.repo.Create(ctx, &c)
+}
+
+func (s *categoryService) GetById(ctx context.Context, id uuid.UUID) (domain.Category, error) {
+	if id == uuid.Nil {
+		return domain.Category{}, fmt.Errorf("%w: invalid category id", ers.ErrInvalidInput)
+	}
+	return s.repo.GetById(ctx, id)
+}
+
+func (s *categoryService) Tree(ctx context.Context) ([]domain.Category, error) {
+	categories, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return buildTree(categories), nil
+}
+
+// Update renames the category and, when ParentID is set, moves it together
+// with its subtree. A category cannot move under itself or its descendants.
+func (s *categoryService) Update(
+	ctx context.Context,
+	id uuid.UUID,
+	dto domain.UpdateCategoryDTO,
+) (domain.Category, error) {
+	if id == uuid.Nil {
+		return domain.Category{}, fmt.Errorf("%w: invalid category id", ers.ErrInvalidInput)
+	}
+
+	var updated domain.Category
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := s.repo.GetById(ctx, id)
+		if err != nil {
+			return err
+		}
+		oldPath := current.Path
+
+		if dto.Name != nil {
+			current.Name = strings.TrimSpace(*dto.Name)
+		}
+		if dto.ParentID != nil {
+			if err := s.reparent(ctx, &current, *dto.ParentID); err != nil {
+				return err
+			}
+		}
+		current.UpdatedAt = time.Now()
+
+		if err := validateCategory(current); err != nil {
+			return err
+		}
+
+		if updated, err = s.repo.Update(ctx, id, current); err != nil {
+			return err
+		}
+		if current.Path != oldPath {
+			if err := s.repo.Move(ctx, oldPath, current.Path); err != nil {
+				return err
+			}
+			updated.Path = current.Path
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.Category{}, err
+	}
+
+	return updated, nil
+}
+
+// reparent points the category at a new parent, uuid.Nil meaning the root,
+// after checking that the move keeps the tree acyclic and within MaxCategoryDepth.
+func (s *categoryService) reparent(ctx context.Context, c *domain.Category, parentID uuid.UUID) error {
+	parentPath := "/"
+	if parentID != uuid.Nil {
+		parent, err := s.repo.GetById(ctx, parentID)
+		if err != nil {
+			return err
+		}
+		if isWithin(parent.Path, c.Path) {
+			return fmt.Errorf("%w: a category cannot move under itself or its descendants", ers.ErrInvalidInput)
+		}
+		parentPath = parent.Path
+	}
+
+	subtreeDepth, err := s.repo.SubtreeDepth(ctx, c.Path)
+	if err != nil {
+		return err
+	}
+	parentDepth := strings.Count(parentPath, "/") - 1
+	if parentDepth+subtreeDepth-c.Depth()+1 > MaxCategoryDepth {
+		return fmt.Errorf("%w: categories nest at most %d levels deep", ers.ErrInvalidInput, MaxCategoryDepth)
+	}
+
+	c.ParentID = nil
+	if parentID != uuid.Nil {
+		c.ParentID = &parentID
+	}
+	c.Path = parentPath + c.ID.String() + "/"
+	return nil
+}
+
+// Delete refuses categories that still have subcategories or live products.
+func (s *categoryService) Delete(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return fmt.Errorf("%w: invalid category id", ers.ErrInvalidInput)
+	}
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		return s.repo.Delete(ctx, id)
+	})
+}
+
+// Products lists the products of the category and of all its descendants.
+func (s *categoryService) Products(
+	ctx context.Context,
+	id uuid.UUID,
+	filter domain.ProductFilter,
+) (domain.ProductPage, error) {
+	if _, err := s.GetById(ctx, id); err != nil {
+		return domain.ProductPage{}, err
+	}
+
+	filter.CategoryID = &id
+	return s.products.GetAll(ctx, filter)
+}
+
+func (s *categoryService) ProductCategories(ctx context.Context, productID uuid.UUID) ([]domain.Category, error) {
+	if _, err := s.products.GetById(ctx, productID, false); err != nil {
+		return nil, err
+	}
+	return s.repo.ProductCategories(ctx, productID)
+}
+
+// SetProductCategories replaces the product's categories. Variants are
+// classified through their parent and cannot be assigned directly.
+func (s *categoryService) SetProductCategories(
+	ctx context.Context,
+	productID uuid.UUID,
+	categoryIDs []uuid.UUID,
+) ([]domain.Category, error) {
+	if len(categoryIDs) > MaxProductCategories {
+		return nil, fmt.Errorf("%w: a product can be in at most %d categories", ers.ErrInvalidInput, MaxProductCategories)
+	}
+
+	ids := make([]uuid.UUID, 0, len(categoryIDs))
+	seen := make(map[uuid.UUID]bool, len(categoryIDs))
+	for _, id := range categoryIDs {
+		if id == uuid.Nil {
+			return nil, fmt.Errorf("%w: invalid category id", ers.ErrInvalidInput)
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	var categories []domain.Category
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		p, err := s.products.GetById(ctx, productID, false)
+		if err != nil {
+			return err
+		}
+		if p.IsVariant() {
+			return fmt.Errorf("%w: assign categories to the parent product", ers.ErrInvalidInput)
+		}
+
+		if err := s.repo.SetProductCategories(ctx, productID, ids); err != nil {
+			return err
+		}
+		categories, err = s.repo.ProductCategories(ctx, productID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func validateCategory(c domain.Category) error {
+	if c.Name == "" {
+		return fmt.Errorf("%w: category name is required", ers.ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(c.Name) > maxCategoryNameLength {
+		return fmt.Errorf("%w: category name is too long", ers.ErrInvalidInput)
+	}
+	return nil
+}
+
+// isWithin reports whether path is the category at ancestorPath or one of its descendants.
+func isWithin(path string, ancestorPath string) bool {
+	return strings.HasPrefix(path, ancestorPath)
+}
+
+// buildTree nests a flat list of categories under their parents, keeping the
+// order of the list among siblings.
+func buildTree(categories []domain.Category) []domain.Category {
+	children := make(map[uuid.UUID][]domain.Category, len(categories))
+	var roots []domain.Category
+	for _, category := range categories {
+		if category.ParentID == nil {
+			roots = append(roots, category)
+			continue
+		}
+		children[*category.ParentID] = append(children[*category.ParentID], category)
+	}
+
+	var attach func(nodes []domain.Category) []domain.Category
+	attach = func(nodes []domain.Category) []domain.Category {
+		for i := range nodes {
+			nodes[i].Children = attach(children[nodes[i].ID])
+		}
+		return nodes
+	}
+
+	tree := attach(roots)
+	if tree == nil {
+		tree = []domain.Category{}
+	}
+	return tree
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+type CategoryService interface {
+	Create(ctx context.Context, c domain.Category) (domain.Category, error)
+	GetById(ctx context.Context, id uuid.UUID) (domain.Category, error)
+	Tree(ctx context.Context) ([]domain.Category, error)
+	Update(ctx context.Context, id uuid.UUID, dto domain.UpdateCategoryDTO) (domain.Category, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	Products(ctx context.Context, id uuid.UUID, filter domain.ProductFilter) (domain.ProductPage, error)
+	ProductCategories(ctx context.Context, productID uuid.UUID) ([]domain.Category, error)
+	SetProductCategories(ctx context.Context, productID uuid.UUID, categoryIDs []uuid.UUID) ([]domain.Category, error)
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+func category(name string, parent *domain.Category) domain.Category {
+	c := domain.Category{ID: uuid.New(), Name: name}
+	c.Path = "/" + c.ID.String() + "/"
+	if parent != nil {
+		c.ParentID = &parent.ID
+		c.Path = parent.Path + c.ID.String() + "/"
+	}
+	return c
+}
+
+func TestBuildTree_NestsChildren(t *testing.T) {
+	clothing := category("Одежда", nil)
+	shoes := category("Обувь", &clothing)
+	sneakers := category("Кроссовки", &shoes)
+	electronics := category("Electronics", nil)
+
+	tree := buildTree([]domain.Category{electronics, sneakers, clothing, shoes})
+
+	if len(tree) != 2 || tree[0].ID != electronics.ID || tree[1].ID != clothing.ID {
+		t.Fatalf("expected roots Electronics and Одежда, but got %v", tree)
+	}
+	if len(tree[1].Children) != 1 || len(tree[1].Children[0].Children) != 1 {
+		t.Fatalf("expected Одежда > Обувь > Кроссовки, but got %v", tree[1].Children)
+	}
+	if tree[1].Children[0].Children[0].ID != sneakers.ID {
+		t.Errorf("expected %s at depth 3, but got %s", sneakers.ID, tree[1].Children[0].Children[0].ID)
+	}
+	if sneakers.Depth() != 3 {
+		t.Errorf("expected depth 3, but got %d", sneakers.Depth())
+	}
+}
+
+func TestIsWithin_Cycle(t *testing.T) {
+	clothing := category("Одежда", nil)
+	shoes := category("Обувь", &clothing)
+	electronics := category("Electronics", nil)
+
+	if !isWithin(shoes.Path, clothing.Path) {
+		t.Errorf("expected a child to be within its parent")
+	}
+	if !isWithin(clothing.Path, clothing.Path) {
+		t.Errorf("expected a category to be within itself")
+	}
+	if isWithin(electronics.Path, clothing.Path) {
+		t.Errorf("expected unrelated categories not to be within each other")
+	}
+}
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/feed/service"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/logger"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/response"
+)
+
+type FeedHandler struct {
+	service service.FeedService
+	logger  logger.Logger
+}
+
+func NewFeedHandler(service service.FeedService, logger logger.Logger) *FeedHandler {
+	return &FeedHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Get serves /feeds/{format}.xml from the on-disk cache. ServeContent answers
+// conditional and range requests, which marketplace crawlers rely on.
+func (h *FeedHandler) Get(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodGet) {
+		return
+	}
+
+	name := r.PathValue("file")
+	format, ok := strings.CutSuffix(name, ".xml")
+	if !ok {
+		response.Error(w, h.logger, fmt.Errorf("%w: %s", ers.ErrFeedNotFound, name))
+		return
+	}
+
+	file, err := h.service.Open(r.Context(), domain.FeedFormat(format))
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			h.logger.Error("error closing feed file: %v", err)
+		}
+	}()
+
+	info, err := file.Stat()
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	http.ServeContent(w, r, name, info.ModTime(), file)
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+// productSource calls fn for every product that goes into a feed.
+type productSource func(fn func(domain.Product) error) error
+
+type feedWriter func(w io.Writer, shop domain.FeedShop, generatedAt time.Time, products productSource) error
+
+var feedWriters = map[domain.FeedFormat]feedWriter{
+	domain.FeedYandex: writeYML,
+	domain.FeedGoogle: writeGoogle,
+}
+
+func productURL(shop domain.FeedShop, id uuid.UUID) string {
+	return shop.URL + "/product/" + id.String()
+}
+
+// availableCount is what a marketplace may sell: on-hand stock minus active reservations.
+func availableCount(p domain.Product) int {
+	if p.Available < 0 {
+		return 0
+	}
+	return p.Available
+}
+
+func startElement(enc *xml.Encoder, name string, attrs ...xml.Attr) error {
+	return enc.EncodeToken(xml.StartElement{Name: xml.Name{Local: name}, Attr: attrs})
+}
+
+func endElement(enc *xml.Encoder, name string) error {
+	return enc.EncodeToken(xml.EndElement{Name: xml.Name{Local: name}})
+}
+
+func attr(name, value string) xml.Attr {
+	return xml.Attr{Name: xml.Name{Local: name}, Value: value}
+}
+
+func writeHeader(w io.Writer) error {
+	_, err := fmt.Fprint(w, xml.Header)
+	return err
+}
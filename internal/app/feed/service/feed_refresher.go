@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/logger"
+)
+
+type Refresher struct {
+	service  FeedService
+	interval time.Duration
+	logger   logger.Logger
+}
+
+func NewRefresher(service FeedService, interval time.Duration, logger logger.Logger) *Refresher {
+	return &Refresher{
+		service:  service,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+// Run rebuilds the feeds right away and then every interval until ctx is cancelled.
+func (r *Refresher) Run(ctx context.Context) {
+	r.refresh(ctx)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.refresh(ctx)
+		}
+	}
+}
+
+func (r *Refresher) refresh(ctx context.Context) {
+	if err := r.service.Regenerate(ctx); err != nil && ctx.Err() == nil {
+		r.logger.Error("failed to regenerate feeds: %v", err)
+	}
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	product "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/product/service"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+)
+
+type feedService struct {
+	products product.ProductService
+	shop     domain.FeedShop
+	dir      string
+	mu       sync.Mutex
+}
+
+// NewFeedService keeps the generated feeds as files in dir, so requests are
+// served from disk and never hit the database.
+func NewFeedService(products product.ProductService, shop domain.FeedShop, dir string) FeedService {
+	return &feedService{
+		products: products,
+		shop:     shop,
+		dir:      dir,
+	}
+}
+
+func (f *feedService) Regenerate(ctx context.Context) error {
+	var errs []error
+	for _, format := range domain.FeedFormats {
+		if err := f.generate(ctx, format); err != nil {
+			errs = append(errs, fmt.Errorf("%s feed: %w", format, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Open returns the cached feed, generating it first if it has never been built.
+func (f *feedService) Open(ctx context.Context, format domain.FeedFormat) (*os.File, error) {
+	if _, ok := feedWriters[format]; !ok {
+		return nil, fmt.Errorf("%w: unknown format %q", ers.ErrFeedNotFound, format)
+	}
+
+	file, err := os.Open(f.path(format))
+	if !errors.Is(err, os.ErrNotExist) {
+		return file, err
+	}
+
+	if err := f.generate(ctx, format); err != nil {
+		return nil, err
+	}
+	return os.Open(f.path(format))
+}
+
+// generate writes the feed to a temporary file and renames it into place, so
+// readers always see either the previous or the new complete feed.
+func (f *feedService) generate(ctx context.Context, format domain.FeedFormat) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := os.MkdirAll(f.dir, 0o755); err != nil {
+		return fmt.Errorf("error creating feed directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(f.dir, string(format)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("error creating feed file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	err = feedWriters[format](w, f.shop, time.Now(), func(fn func(domain.Product) error) error {
+		return f.products.Export(ctx, domain.ProductFilter{}, func(p domain.Product) error {
+			// a feed is published in the shop currency; other prices are left out
+			if p.Price.Currency != f.shop.Currency {
+				return nil
+			}
+			return fn(p)
+		})
+	})
+	if err == nil {
+		err = w.Flush()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("error writing feed: %w", err)
+	}
+
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path(format))
+}
+
+func (f *feedService) path(format domain.FeedFormat) string {
+	return filepath.Join(f.dir, string(format)+".xml")
+}
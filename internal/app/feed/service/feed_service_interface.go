@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+	"os"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+type FeedService interface {
+	Regenerate(ctx context.Context) error
+	Open(ctx context.Context, format domain.FeedFormat) (*os.File, error)
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"bytes"
+	"encoding/xml"
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden feed files in testdata")
+
+var testShop = domain.FeedShop{
+	Name:     "Маркет",
+	Company:  "ООО «Маркет»",
+	URL:      "https://market.example",
+	Currency: "RUB",
+}
+
+func testProducts(fn func(domain.Product) error) error {
+	products := []domain.Product{
+		{
+			ID:          uuid.MustParse("5b1f0a52-6d1c-4b8e-9f38-1d2a3c4b5e6f"),
+			SKU:         "KB-101",
+			Name:        "Клавиатура механическая",
+			Description: "Переключатели <Brown> & подсветка",
+			Price:       domain.NewMoney(799000, "RUB"),
+			Quantity:    12,
+			Reserved:    2,
+			Available:   10,
+		},
+		{
+			ID:          uuid.MustParse("c3d4e5f6-0718-4293-a4b5-c6d7e8f90a1b"),
+			Name:        "Wireless mouse",
+			Description: "2.4 GHz, silent buttons",
+			Price:       domain.NewMoney(149050, "RUB"),
+			Quantity:    3,
+			Reserved:    3,
+			Available:   0,
+		},
+	}
+	for _, p := range products {
+		if err := fn(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func renderFeed(t *testing.T, format domain.FeedFormat) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	generatedAt := time.Date(2026, 10, 18, 9, 30, 0, 0, time.FixedZone("MSK", 3*60*60))
+	if err := feedWriters[format](&buf, testShop, generatedAt, testProducts); err != nil {
+		t.Fatalf("feed generation failed: %s", err)
+	}
+	return buf.Bytes()
+}
+
+func compareGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("updating golden file failed: %s", err)
+		}
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden file failed: %s", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s differs from the generated feed:\n%s", path, got)
+	}
+}
+
+type ymlDocument struct {
+	XMLName xml.Name `xml:"yml_catalog"`
+	Date    string   `xml:"date,attr"`
+	Shop    struct {
+		Name       string `xml:"name"`
+		Company    string `xml:"company"`
+		URL        string `xml:"url"`
+		Currencies []struct {
+			ID string `xml:"id,attr"`
+		} `xml:"currencies>currency"`
+		Categories []struct {
+			ID string `xml:"id,attr"`
+		} `xml:"categories>category"`
+		Offers []struct {
+			ID         string `xml:"id,attr"`
+			Available  string `xml:"available,attr"`
+			URL        string `xml:"url"`
+			Price      string `xml:"price"`
+			CurrencyID string `xml:"currencyId"`
+			CategoryID string `xml:"categoryId"`
+			Name       string `xml:"name"`
+		} `xml:"offers>offer"`
+	} `xml:"shop"`
+}
+
+// checkYML verifies the elements Yandex Market requires in a YML catalogue.
+func checkYML(t *testing.T, data []byte) int {
+	t.Helper()
+
+	var doc ymlDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("feed is not well-formed: %s", err)
+	}
+	if _, err := time.Parse("2006-01-02T15:04-07:00", doc.Date); err != nil {
+		t.Errorf("bad catalogue date %q", doc.Date)
+	}
+	if doc.Shop.Name == "" || doc.Shop.Company == "" || doc.Shop.URL == "" {
+		t.Errorf("shop name, company and url are required")
+	}
+	if len(doc.Shop.Currencies) == 0 || len(doc.Shop.Categories) == 0 {
+		t.Errorf("at least one currency and category are required")
+	}
+
+	price := regexp.MustCompile(`^\d+(\.\d{1,2})?$`)
+	for _, o := range doc.Shop.Offers {
+		if o.ID == "" || o.URL == "" || o.CurrencyID == "" || o.CategoryID == "" || o.Name == "" {
+			t.Errorf("offer %q misses a required element", o.ID)
+		}
+		if !price.MatchString(o.Price) {
+			t.Errorf("offer %q has bad price %q", o.ID, o.Price)
+		}
+		if o.Available != "" && o.Available != "true" && o.Available != "false" {
+			t.Errorf("offer %q has bad availability %q", o.ID, o.Available)
+		}
+	}
+	return len(doc.Shop.Offers)
+}
+
+type googleDocument struct {
+	XMLName xml.Name `xml:"rss"`
+	Version string   `xml:"version,attr"`
+	Channel struct {
+		Title string `xml:"title"`
+		Link  string `xml:"link"`
+		Items []struct {
+			ID           string `xml:"http://base.google.com/ns/1.0 id"`
+			Title        string `xml:"http://base.google.com/ns/1.0 title"`
+			Description  string `xml:"http://base.google.com/ns/1.0 description"`
+			Link         string `xml:"http://base.google.com/ns/1.0 link"`
+			Price        string `xml:"http://base.google.com/ns/1.0 price"`
+			Availability string `xml:"http://base.google.com/ns/1.0 availability"`
+			Condition    string `xml:"http://base.google.com/ns/1.0 condition"`
+		} `xml:"item"`
+	} `xml:"channel"`
+}
+
+// checkGoogle verifies the attributes Google Merchant Center requires for every item.
+func checkGoogle(t *testing.T, data []byte) int {
+	t.Helper()
+
+	var doc googleDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("feed is not well-formed: %s", err)
+	}
+	if doc.Version != "2.0" || doc.Channel.Title == "" || doc.Channel.Link == "" {
+		t.Errorf("an RSS 2.0 channel with title and link is required")
+	}
+
+	price := regexp.MustCompile(`^\d+(\.\d{1,2})? [A-Z]{3}$`)
+	availability := map[string]bool{"in_stock": true, "out_of_stock": true, "preorder": true, "backorder": true}
+	condition := map[string]bool{"new": true, "refurbished": true, "used": true}
+	for _, item := range doc.Channel.Items {
+		if item.ID == "" || item.Title == "" || item.Description == "" || item.Link == "" {
+			t.Errorf("item %q misses a required attribute", item.ID)
+		}
+		if !price.MatchString(item.Price) {
+			t.Errorf("item %q has bad price %q", item.ID, item.Price)
+		}
+		if !availability[item.Availability] || !condition[item.Condition] {
+			t.Errorf("item %q has bad availability %q or condition %q", item.ID, item.Availability, item.Condition)
+		}
+	}
+	return len(doc.Channel.Items)
+}
+
+func TestYML_Golden(t *testing.T) {
+	got := renderFeed(t, domain.FeedYandex)
+
+	if n := checkYML(t, got); n != 2 {
+		t.Errorf("expected 2 offers, but got %d", n)
+	}
+	compareGolden(t, "yml.golden.xml", got)
+}
+
+func TestGoogle_Golden(t *testing.T) {
+	got := renderFeed(t, domain.FeedGoogle)
+
+	if n := checkGoogle(t, got); n != 2 {
+		t.Errorf("expected 2 items, but got %d", n)
+	}
+	compareGolden(t, "google.golden.xml", got)
+}
+
+// The checks themselves are validated against the marketplaces' sample feeds.
+func TestFeedChecks_AcceptSamples(t *testing.T) {
+	yml, err := os.ReadFile(filepath.Join("testdata", "yml.sample.xml"))
+	if err != nil {
+		t.Fatalf("reading sample failed: %s", err)
+	}
+	if n := checkYML(t, yml); n == 0 {
+		t.Errorf("expected offers in the YML sample")
+	}
+
+	google, err := os.ReadFile(filepath.Join("testdata", "google.sample.xml"))
+	if err != nil {
+		t.Fatalf("reading sample failed: %s", err)
+	}
+	if n := checkGoogle(t, google); n == 0 {
+		t.Errorf("expected items in the Google sample")
+	}
+}
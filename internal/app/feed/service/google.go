@@ -0,0 +1,86 @@
+package service
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+const googleNamespace = "http://base.google.com/ns/1.0"
+
+type googleItem struct {
+	XMLName          xml.Name `xml:"item"`
+	ID               string   `xml:"g:id"`
+	Title            string   `xml:"g:title"`
+	Description      string   `xml:"g:description"`
+	Link             string   `xml:"g:link"`
+	Price            string   `xml:"g:price"`
+	Availability     string   `xml:"g:availability"`
+	Condition        string   `xml:"g:condition"`
+	MPN              string   `xml:"g:mpn,omitempty"`
+	IdentifierExists string   `xml:"g:identifier_exists"`
+}
+
+// writeGoogle writes a Google Merchant Center RSS 2.0 feed with one item per product.
+func writeGoogle(w io.Writer, shop domain.FeedShop, generatedAt time.Time, products productSource) error {
+	if err := writeHeader(w); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	if err := startElement(enc, "rss", attr("version", "2.0"), attr("xmlns:g", googleNamespace)); err != nil {
+		return err
+	}
+	if err := startElement(enc, "channel"); err != nil {
+		return err
+	}
+	for _, field := range []struct{ name, value string }{
+		{"title", shop.Name},
+		{"link", shop.URL},
+		{"description", shop.Company},
+		{"lastBuildDate", generatedAt.Format(time.RFC1123Z)},
+	} {
+		if err := enc.EncodeElement(field.value, xml.StartElement{Name: xml.Name{Local: field.name}}); err != nil {
+			return err
+		}
+	}
+
+	err := products(func(p domain.Product) error {
+		availability := "out_of_stock"
+		if availableCount(p) > 0 {
+			availability = "in_stock"
+		}
+
+		return enc.Encode(googleItem{
+			ID:           p.ID.String(),
+			Title:        p.Name,
+			Description:  p.Description,
+			Link:         productURL(shop, p.ID),
+			Price:        p.Price.String(),
+			Availability: availability,
+			Condition:    "new",
+			MPN:          p.SKU,
+			// products carry neither GTIN nor brand yet
+			IdentifierExists: "no",
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, name := range []string{"channel", "rss"} {
+		if err := endElement(enc, name); err != nil {
+			return err
+		}
+	}
+	if err := enc.Flush(); err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "\n")
+	return err
+}
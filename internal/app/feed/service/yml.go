@@ -0,0 +1,115 @@
+package service
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+// Every offer goes to a single root category on purpose: YML wants one integer
+// categoryId per offer that stays the same between feeds, while our categories
+// are UUIDs and a product may sit in several of them. Marketplaces map offers
+// to their own taxonomy anyway.
+const ymlRootCategory = 1
+
+type ymlCurrency struct {
+	ID   string `xml:"id,attr"`
+	Rate string `xml:"rate,attr"`
+}
+
+type ymlCurrencies struct {
+	XMLName xml.Name      `xml:"currencies"`
+	Items   []ymlCurrency `xml:"currency"`
+}
+
+type ymlCategory struct {
+	ID   int    `xml:"id,attr"`
+	Name string `xml:",chardata"`
+}
+
+type ymlCategories struct {
+	XMLName xml.Name      `xml:"categories"`
+	Items   []ymlCategory `xml:"category"`
+}
+
+type ymlOffer struct {
+	XMLName     xml.Name `xml:"offer"`
+	ID          string   `xml:"id,attr"`
+	Available   bool     `xml:"available,attr"`
+	URL         string   `xml:"url"`
+	Price       string   `xml:"price"`
+	CurrencyID  string   `xml:"currencyId"`
+	CategoryID  int      `xml:"categoryId"`
+	Name        string   `xml:"name"`
+	VendorCode  string   `xml:"vendorCode,omitempty"`
+	Description string   `xml:"description"`
+	Count       int      `xml:"count"`
+}
+
+// writeYML writes a Yandex Market Language catalogue with one offer per product.
+func writeYML(w io.Writer, shop domain.FeedShop, generatedAt time.Time, products productSource) error {
+	if err := writeHeader(w); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	if err := startElement(enc, "yml_catalog", attr("date", generatedAt.Format("2006-01-02T15:04-07:00"))); err != nil {
+		return err
+	}
+	if err := startElement(enc, "shop"); err != nil {
+		return err
+	}
+	for _, field := range []struct{ name, value string }{
+		{"name", shop.Name},
+		{"company", shop.Company},
+		{"url", shop.URL},
+	} {
+		if err := enc.EncodeElement(field.value, xml.StartElement{Name: xml.Name{Local: field.name}}); err != nil {
+			return err
+		}
+	}
+	if err := enc.Encode(ymlCurrencies{Items: []ymlCurrency{{ID: shop.Currency, Rate: "1"}}}); err != nil {
+		return err
+	}
+	if err := enc.Encode(ymlCategories{Items: []ymlCategory{{ID: ymlRootCategory, Name: shop.Name}}}); err != nil {
+		return err
+	}
+
+	if err := startElement(enc, "offers"); err != nil {
+		return err
+	}
+	err := products(func(p domain.Product) error {
+		count := availableCount(p)
+		return enc.Encode(ymlOffer{
+			ID:          p.ID.String(),
+			Available:   count > 0,
+			URL:         productURL(shop, p.ID),
+			Price:       p.Price.Decimal(),
+			CurrencyID:  p.Price.Currency,
+			CategoryID:  ymlRootCategory,
+			Name:        p.Name,
+			VendorCode:  p.SKU,
+			Description: p.Description,
+			Count:       count,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, name := range []string{"offers", "shop", "yml_catalog"} {
+		if err := endElement(enc, name); err != nil {
+			return err
+		}
+	}
+	if err := enc.Flush(); err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "\n")
+	return err
+}
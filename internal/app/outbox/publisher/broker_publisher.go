@@ -0,0 +1,68 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+// Broker is the minimal surface of a message broker client (Kafka, NATS,
+// RabbitMQ...). The key keeps events of one aggregate on one partition.
+type Broker interface {
+	Send(ctx context.Context, topic string, key string, body []byte) error
+}
+
+// BrokerPublisher sends every event to topic, keyed by aggregate id.
+type BrokerPublisher struct {
+	broker Broker
+	topic  string
+}
+
+func NewBrokerPublisher(broker Broker, topic string) *BrokerPublisher {
+	return &BrokerPublisher{
+		broker: broker,
+		topic:  topic,
+	}
+}
+
+func (p *BrokerPublisher) Publish(ctx context.Context, event domain.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("error encoding event: %w", err)
+	}
+	return p.broker.Send(ctx, p.topic, event.AggregateID.String(), body)
+}
+
+type Message struct {
+	Topic string
+	Key   string
+	Body  []byte
+}
+
+// MemoryBroker keeps sent messages in memory. It is meant for tests and local runs.
+type MemoryBroker struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{}
+}
+
+func (b *MemoryBroker) Send(ctx context.Context, topic string, key string, body []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.messages = append(b.messages, Message{Topic: topic, Key: key, Body: body})
+	return nil
+}
+
+func (b *MemoryBroker) Messages() []Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return append([]Message(nil), b.messages...)
+}
@@ -0,0 +1,25 @@
+package publisher
+
+import (
+	"context"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/logger"
+)
+
+// LogPublisher only writes events to the log. It is the default when no
+// downstream transport is configured.
+type LogPublisher struct {
+	logger logger.Logger
+}
+
+func NewLogPublisher(logger logger.Logger) *LogPublisher {
+	return &LogPublisher{
+		logger: logger,
+	}
+}
+
+func (p *LogPublisher) Publish(ctx context.Context, event domain.Event) error {
+	p.logger.Info("event %s %s %s/%s: %s", event.ID, event.Type, event.AggregateType, event.AggregateID, event.Payload)
+	return nil
+}
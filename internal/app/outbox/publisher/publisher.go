@@ -0,0 +1,30 @@
+package publisher
+
+import (
+	"context"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+// Publisher delivers an outbox event to the outside world.
+type Publisher interface {
+	Publish(ctx context.Context, event domain.Event) error
+}
+
+type multiPublisher []Publisher
+
+// Multi hands every event to all publishers in order. The first failure stops
+// the chain and the event is retried later, so publishers must tolerate
+// receiving the same event more than once.
+func Multi(publishers ...Publisher) Publisher {
+	return multiPublisher(publishers)
+}
+
+func (m multiPublisher) Publish(ctx context.Context, event domain.Event) error {
+	for _, p := range m {
+		if err := p.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package publisher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+const (
+	HeaderEventID   = "X-Event-ID"
+	HeaderEventType = "X-Event-Type"
+)
+
+// WebhookPublisher POSTs each event as JSON to a fixed URL. Any non-2xx
+// response counts as a failure and the event is retried.
+type WebhookPublisher struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookPublisher(url string, timeout time.Duration) *WebhookPublisher {
+	return &WebhookPublisher{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (p *WebhookPublisher) Publish(ctx context.Context, event domain.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("error encoding event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEventID, event.ID.String())
+	req.Header.Set(HeaderEventType, string(event.Type))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	core "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/repository"
+)
+
+type PostgresOutboxRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresOutboxRepository(db *sql.DB) *PostgresOutboxRepository {
+	return &PostgresOutboxRepository{
+		db: db,
+	}
+}
+
+func (i *PostgresOutboxRepository) Create(ctx context.Context, event *domain.Event) error {
+	query := `
+		INSERT INTO outbox (id, event_type, aggregate_type, aggregate_id, payload, request_id, created_at, next_attempt_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+	`
+
+	if _, err := core.Conn(ctx, i.db).ExecContext(
+		ctx,
+		query,
+		event.ID,
+		event.Type,
+		event.AggregateType,
+		event.AggregateID,
+		[]byte(event.Payload),
+		event.RequestID,
+		event.CreatedAt,
+	); err != nil {
+		return fmt.Errorf("error inserting outbox event: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimPending leases due events in commit order: their next attempt moves to
+// leaseUntil, so other relays skip them while they are published outside the
+// claiming transaction. SKIP LOCKED keeps concurrent claims from waiting on
+// each other or handing out the same event twice.
+func (i *PostgresOutboxRepository) ClaimPending(ctx context.Context, limit int, leaseUntil time.Time) ([]domain.Event, error) {
+	events := []domain.Event{}
+
+	query := `
+		WITH due AS (
+			SELECT seq
+			FROM outbox
+			WHERE published_at IS NULL AND next_attempt_at <= now()
+			ORDER BY seq
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		), claimed AS (
+			UPDATE outbox o
+			SET next_attempt_at = $2
+			FROM due
+			WHERE o.seq = due.seq
+			RETURNING o.seq, o.id, o.event_type, o.aggregate_type, o.aggregate_id, o.payload, o.request_id, o.created_at, o.attempts
+		)
+		SELECT id, event_type, aggregate_type, aggregate_id, payload, request_id, created_at, attempts
+		FROM claimed
+		ORDER BY seq
+	`
+
+	rows, err := core.Conn(ctx, i.db).QueryContext(ctx, query, limit, leaseUntil)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		if err := rows.Close(); err != nil {
+		}
+	}(rows)
+
+	for rows.Next() {
+		var (
+			event   domain.Event
+			payload []byte
+		)
+
+		if err := rows.Scan(
+			&event.ID,
+			&event.Type,
+			&event.AggregateType,
+			&event.AggregateID,
+			&payload,
+			&event.RequestID,
+			&event.CreatedAt,
+			&event.Attempts,
+		); err != nil {
+			return events, err
+		}
+		event.Payload = payload
+
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return events, nil
+}
+
+func (i *PostgresOutboxRepository) MarkPublished(ctx context.Context, id uuid.UUID, at time.Time) error {
+	query := `UPDATE outbox SET published_at = $2, attempts = attempts + 1, last_error = '' WHERE id = $1`
+
+	if _, err := core.Conn(ctx, i.db).ExecContext(ctx, query, id, at); err != nil {
+		return fmt.Errorf("error marking outbox event published: %w", err)
+	}
+	return nil
+}
+
+func (i *PostgresOutboxRepository) MarkFailed(
+	ctx context.Context,
+	id uuid.UUID,
+	reason string,
+	retryAt time.Time,
+) error {
+	query := `
+		UPDATE outbox
+		SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3
+		WHERE id = $1
+	`
+
+	if _, err := core.Conn(ctx, i.db).ExecContext(ctx, query, id, reason, retryAt); err != nil {
+		return fmt.Errorf("error marking outbox event failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+type OutboxRepository interface {
+	Create(ctx context.Context, event *domain.Event) error
+	ClaimPending(ctx context.Context, limit int, leaseUntil time.Time) ([]domain.Event, error)
+	MarkPublished(ctx context.Context, id uuid.UUID, at time.Time) error
+	MarkFailed(ctx context.Context, id uuid.UUID, reason string, retryAt time.Time) error
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/outbox/publisher"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/outbox/repository"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/backoff"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/logger"
+	core "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/repository"
+)
+
+const (
+	maxRetryDelay = 10 * time.Minute
+	// claimLease keeps a claimed event away from other relays while it is
+	// published; if this relay dies mid-batch, the event is picked up again
+	// once the lease runs out.
+	claimLease = 5 * time.Minute
+)
+
+// Relay publishes outbox events. Delivery is at-least-once: an event whose
+// publish succeeded may be sent again if marking it fails or its lease runs
+// out first, so consumers should dedupe by event id.
+type Relay struct {
+	repo      repository.OutboxRepository
+	tx        core.Transactor
+	publisher publisher.Publisher
+	batchSize int
+	interval  time.Duration
+	logger    logger.Logger
+}
+
+func NewRelay(
+	repo repository.OutboxRepository,
+	tx core.Transactor,
+	publisher publisher.Publisher,
+	batchSize int,
+	interval time.Duration,
+	logger logger.Logger,
+) *Relay {
+	return &Relay{
+		repo:      repo,
+		tx:        tx,
+		publisher: publisher,
+		batchSize: batchSize,
+		interval:  interval,
+		logger:    logger,
+	}
+}
+
+// Run relays pending events every interval until ctx is cancelled.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			published, err := r.RelayOnce(ctx)
+			if err != nil {
+				r.logger.Error("failed to relay outbox events: %v", err)
+				continue
+			}
+			if published > 0 {
+				r.logger.Info("published %d outbox events", published)
+			}
+		}
+	}
+}
+
+// RelayOnce publishes one batch of due events and returns how many went out.
+// The batch is claimed in a short transaction and published outside of it, so
+// a slow broker holds no locks; each event is then marked on its own. A failed
+// event is retried later with exponential backoff without holding back the
+// rest of the batch.
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	var events []domain.Event
+
+	err := r.tx.WithinTx(ctx, func(ctx context.Context) error {
+		claimed, err := r.repo.ClaimPending(ctx, r.batchSize, time.Now().Add(claimLease))
+		if err != nil {
+			return err
+		}
+		events = claimed
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for _, event := range events {
+		if err := r.publisher.Publish(ctx, event); err != nil {
+			r.logger.Warn("failed to publish event %s (%s): %v", event.ID, event.Type, err)
+			retryAt := time.Now().Add(retryDelay(event.Attempts + 1))
+			if err := r.repo.MarkFailed(ctx, event.ID, err.Error(), retryAt); err != nil {
+				return published, err
+			}
+			continue
+		}
+
+		if err := r.repo.MarkPublished(ctx, event.ID, time.Now()); err != nil {
+			return published, err
+		}
+		published++
+	}
+
+	return published, nil
+}
+
+func retryDelay(attempt int) time.Duration {
+	return backoff.Exponential(time.Second, maxRetryDelay, attempt)
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/outbox/publisher"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/logger"
+)
+
+type memoryOutbox struct {
+	events    []domain.Event
+	published map[uuid.UUID]bool
+	failed    map[uuid.UUID]string
+}
+
+func (m *memoryOutbox) Create(ctx context.Context, event *domain.Event) error {
+	m.events = append(m.events, *event)
+	return nil
+}
+
+func (m *memoryOutbox) ClaimPending(ctx context.Context, limit int, leaseUntil time.Time) ([]domain.Event, error) {
+	var pending []domain.Event
+	for _, event := range m.events {
+		if !m.published[event.ID] && len(pending) < limit {
+			pending = append(pending, event)
+		}
+	}
+	return pending, nil
+}
+
+func (m *memoryOutbox) MarkPublished(ctx context.Context, id uuid.UUID, at time.Time) error {
+	m.published[id] = true
+	return nil
+}
+
+func (m *memoryOutbox) MarkFailed(ctx context.Context, id uuid.UUID, reason string, retryAt time.Time) error {
+	m.failed[id] = reason
+	return nil
+}
+
+type inlineTx struct{}
+
+func (inlineTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// trackingTx reports whether a call is made while its transaction is open.
+type trackingTx struct {
+	open *bool
+}
+
+func (t trackingTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	*t.open = true
+	defer func() { *t.open = false }()
+	return fn(ctx)
+}
+
+type txCheckingPublisher struct {
+	open *bool
+}
+
+func (p txCheckingPublisher) Publish(ctx context.Context, event domain.Event) error {
+	if *p.open {
+		return errors.New("published inside the claim transaction")
+	}
+	return nil
+}
+
+type rejectingPublisher struct {
+	reject uuid.UUID
+	next   publisher.Publisher
+}
+
+func (p rejectingPublisher) Publish(ctx context.Context, event domain.Event) error {
+	if event.ID == p.reject {
+		return errors.New("broker unavailable")
+	}
+	return p.next.Publish(ctx, event)
+}
+
+func newMemoryOutbox() *memoryOutbox {
+	return &memoryOutbox{
+		published: map[uuid.UUID]bool{},
+		failed:    map[uuid.UUID]string{},
+	}
+}
+
+func TestRelay_PublishesPendingEvents(t *testing.T) {
+	repo := newMemoryOutbox()
+	events := NewOutboxService(repo)
+	productID := uuid.New()
+
+	ctx := context.Background()
+	if err := events.Add(ctx, domain.EventProductCreated, domain.AggregateProduct, productID, domain.Product{ID: productID}); err != nil {
+		t.Fatalf("add failed: %s", err)
+	}
+	if err := events.Add(ctx, domain.EventStockChanged, domain.AggregateProduct, productID, domain.StockChanged{ProductID: productID, Delta: 5}); err != nil {
+		t.Fatalf("add failed: %s", err)
+	}
+
+	broker := publisher.NewMemoryBroker()
+	relay := NewRelay(repo, inlineTx{}, publisher.NewBrokerPublisher(broker, "inventory"), 10, time.Second, logger.New(io.Discard))
+
+	published, err := relay.RelayOnce(ctx)
+	if err != nil {
+		t.Fatalf("relay failed: %s", err)
+	}
+	if published != 2 {
+		t.Fatalf("expected 2 published events, got %d", published)
+	}
+
+	messages := broker.Messages()
+	if len(messages) != 2 {
+		t.Fatalf("expected 2 broker messages, got %d", len(messages))
+	}
+
+	var sent domain.Event
+	if err := json.Unmarshal(messages[1].Body, &sent); err != nil {
+		t.Fatalf("invalid message body: %s", err)
+	}
+	if sent.Type != domain.EventStockChanged || messages[1].Key != productID.String() {
+		t.Errorf("expected stock.changed keyed by product, got %s keyed by %s", sent.Type, messages[1].Key)
+	}
+
+	if published, _ := relay.RelayOnce(ctx); published != 0 {
+		t.Errorf("expected nothing left to publish, got %d", published)
+	}
+}
+
+func TestRelay_FailedEventDoesNotBlockBatch(t *testing.T) {
+	repo := newMemoryOutbox()
+	events := NewOutboxService(repo)
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		if err := events.Add(ctx, domain.EventProductUpdated, domain.AggregateProduct, uuid.New(), struct{}{}); err != nil {
+			t.Fatalf("add failed: %s", err)
+		}
+	}
+	rejected := repo.events[1].ID
+
+	broker := publisher.NewMemoryBroker()
+	pub := rejectingPublisher{reject: rejected, next: publisher.NewBrokerPublisher(broker, "inventory")}
+	relay := NewRelay(repo, inlineTx{}, pub, 10, time.Second, logger.New(io.Discard))
+
+	published, err := relay.RelayOnce(ctx)
+	if err != nil {
+		t.Fatalf("relay failed: %s", err)
+	}
+	if published != 2 {
+		t.Errorf("expected 2 published events, got %d", published)
+	}
+	if repo.failed[rejected] == "" {
+		t.Errorf("expected rejected event to be marked failed")
+	}
+	if repo.published[rejected] {
+		t.Errorf("rejected event must stay pending")
+	}
+}
+
+func TestRelay_PublishesOutsideTransaction(t *testing.T) {
+	repo := newMemoryOutbox()
+	events := NewOutboxService(repo)
+
+	ctx := context.Background()
+	if err := events.Add(ctx, domain.EventProductUpdated, domain.AggregateProduct, uuid.New(), struct{}{}); err != nil {
+		t.Fatalf("add failed: %s", err)
+	}
+
+	open := false
+	relay := NewRelay(repo, trackingTx{open: &open}, txCheckingPublisher{open: &open}, 10, time.Second, logger.New(io.Discard))
+
+	published, err := relay.RelayOnce(ctx)
+	if err != nil {
+		t.Fatalf("relay failed: %s", err)
+	}
+	if published != 1 || len(repo.failed) != 0 {
+		t.Errorf("expected the event published outside the transaction, but got %d published and failures %v", published, repo.failed)
+	}
+}
+
+func TestRetryDelay_Capped(t *testing.T) {
+	if d := retryDelay(1); d != time.Second {
+		t.Errorf("expected 1s for the first retry, got %s", d)
+	}
+	if d := retryDelay(4); d != 8*time.Second {
+		t.Errorf("expected 8s for the fourth retry, got %s", d)
+	}
+	if d := retryDelay(50); d != maxRetryDelay {
+		t.Errorf("expected delay capped at %s, got %s", maxRetryDelay, d)
+	}
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/outbox/repository"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/requestid"
+)
+
+type outboxService struct {
+	repo repository.OutboxRepository
+}
+
+func NewOutboxService(repo repository.OutboxRepository) OutboxService {
+	return &outboxService{
+		repo: repo,
+	}
+}
+
+// Add stores an event for the relay. Call it inside the transaction of the
+// change so the event exists if and only if the change was committed.
+func (s *outboxService) Add(
+	ctx context.Context,
+	eventType domain.EventType,
+	aggregateType string,
+	aggregateID uuid.UUID,
+	payload interface{},
+) error {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("error encoding %s payload: %w", eventType, err)
+	}
+
+	return s.repo.Create(ctx, &domain.Event{
+		ID:            uuid.New(),
+		Type:          eventType,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Payload:       raw,
+		RequestID:     requestid.FromContext(ctx),
+		CreatedAt:     time.Now(),
+	})
+}
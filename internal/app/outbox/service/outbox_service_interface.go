@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+type OutboxService interface {
+	Add(
+		ctx context.Context,
+		eventType domain.EventType,
+		aggregateType string,
+		aggregateID uuid.UUID,
+		payload interface{},
+	) error
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/price/service"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/logger"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/response"
+)
+
+type ExchangeRateHandler struct {
+	service     service.ExchangeRateService
+	maxFileSize int64
+	logger      logger.Logger
+}
+
+func NewExchangeRateHandler(service service.ExchangeRateService, maxFileSize int64, logger logger.Logger) *ExchangeRateHandler {
+	return &ExchangeRateHandler{
+		service:     service,
+		maxFileSize: maxFileSize,
+		logger:      logger,
+	}
+}
+
+// Import accepts a CSV file as the "file" field of a multipart form or as the
+// raw request body.
+func (h *ExchangeRateHandler) Import(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodPost) {
+		return
+	}
+
+	data, err := h.readFile(w, r)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	rates, err := parseRatesCSV(data)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	imported, err := h.service.Import(r.Context(), rates)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusOK, ImportRatesResponse{Imported: imported})
+}
+
+func (h *ExchangeRateHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodGet) {
+		return
+	}
+
+	rates, err := h.service.GetAll(r.Context(), r.URL.Query().Get("base"), r.URL.Query().Get("quote"))
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusOK, rates)
+}
+
+func (h *ExchangeRateHandler) readFile(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxFileSize)
+
+	body := io.Reader(r.Body)
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		reader, err := r.MultipartReader()
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid multipart body", ers.ErrInvalidInput)
+		}
+		for {
+			part, err := reader.NextPart()
+			if err != nil {
+				return nil, h.readError(err, "multipart body has no file field")
+			}
+			if part.FormName() == "file" {
+				body = part
+				break
+			}
+		}
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, h.readError(err, "invalid body")
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w: rates file is empty", ers.ErrInvalidInput)
+	}
+
+	return data, nil
+}
+
+func (h *ExchangeRateHandler) readError(err error, msg string) error {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return fmt.Errorf("%w: rates file exceeds %d bytes", ers.ErrInvalidInput, h.maxFileSize)
+	}
+	return fmt.Errorf("%w: %s", ers.ErrInvalidInput, msg)
+}
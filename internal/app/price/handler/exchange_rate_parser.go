@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+)
+
+// rateColumns maps accepted header names to the ExchangeRate field they fill.
+var rateColumns = map[string]string{
+	"date":           "date",
+	"effective_date": "date",
+	"base":           "base",
+	"from":           "base",
+	"quote":          "quote",
+	"to":             "quote",
+	"rate":           "rate",
+	"дата":           "date",
+	"из":             "base",
+	"в":              "quote",
+	"курс":           "rate",
+}
+
+// rateDateLayouts are tried in order; the second is how Russian spreadsheets write dates.
+var rateDateLayouts = []string{"2006-01-02", "02.01.2006"}
+
+// parseRatesCSV reads a comma or semicolon separated file whose header names
+// the date, base, quote and rate columns, e.g. "date,base,quote,rate" followed
+// by "2026-10-01,RUB,KZT,5.12". Any bad line fails the whole file.
+func parseRatesCSV(data []byte) ([]domain.ExchangeRate, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	header, _, _ := bytes.Cut(data, []byte("\n"))
+	reader := csv.NewReader(bytes.NewReader(data))
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		reader.Comma = ';'
+	}
+	reader.FieldsPerRecord = -1
+
+	record, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: rates file has no header", ers.ErrInvalidInput)
+	}
+	columns := make(map[string]int)
+	for i, name := range record {
+		if field, ok := rateColumns[strings.ToLower(strings.TrimSpace(name))]; ok {
+			columns[field] = i
+		}
+	}
+	for _, field := range []string{"date", "base", "quote", "rate"} {
+		if _, ok := columns[field]; !ok {
+			return nil, fmt.Errorf("%w: rates file has no %s column", ers.ErrInvalidInput, field)
+		}
+	}
+
+	rates := []domain.ExchangeRate{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ers.ErrInvalidInput, err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		cell := func(field string) string {
+			if i := columns[field]; i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		if cell("date") == "" && cell("base") == "" && cell("quote") == "" && cell("rate") == "" {
+			continue
+		}
+
+		date, err := parseRateDate(cell("date"))
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: date %q is not YYYY-MM-DD", ers.ErrInvalidInput, line, cell("date"))
+		}
+		rate := domain.ExchangeRate{
+			Base:          strings.ToUpper(cell("base")),
+			Quote:         strings.ToUpper(cell("quote")),
+			EffectiveDate: date,
+			Rate:          strings.Replace(cell("rate"), ",", ".", 1),
+		}
+		if _, err := rate.Ratio(); err != nil {
+			return nil, fmt.Errorf("%w: line %d: rate %q is not a positive decimal", ers.ErrInvalidInput, line, cell("rate"))
+		}
+		rates = append(rates, rate)
+	}
+
+	return rates, nil
+}
+
+func parseRateDate(value string) (time.Time, error) {
+	var err error
+	for _, layout := range rateDateLayouts {
+		var date time.Time
+		if date, err = time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, err
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+)
+
+func TestParseRatesCSV_Semicolon(t *testing.T) {
+	data := "\xef\xbb\xbfДата;Из;В;Курс\n" +
+		"01.10.2026;rub;kzt;5,12\n" +
+		"\n" +
+		"2026-10-02;USD;RUB;96.5\n"
+
+	rates, err := parseRatesCSV([]byte(data))
+	if err != nil {
+		t.Fatalf("parsing failed: %s", err)
+	}
+
+	if len(rates) != 2 {
+		t.Fatalf("expected 2 rates, but got %d", len(rates))
+	}
+	first := rates[0]
+	if first.Base != "RUB" || first.Quote != "KZT" || first.Rate != "5.12" || first.EffectiveDate.Format("2006-01-02") != "2026-10-01" {
+		t.Errorf("unexpected first rate: %+v", first)
+	}
+	if rates[1].Base != "USD" || rates[1].Rate != "96.5" {
+		t.Errorf("unexpected second rate: %+v", rates[1])
+	}
+}
+
+func TestParseRatesCSV_BadRate(t *testing.T) {
+	_, err := parseRatesCSV([]byte("date,base,quote,rate\n2026-10-01,RUB,KZT,0\n"))
+	if !errors.Is(err, ers.ErrInvalidInput) || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected an %v on line 2, but got %v", ers.ErrInvalidInput, err)
+	}
+}
+
+func TestParseRatesCSV_NoRateColumn(t *testing.T) {
+	_, err := parseRatesCSV([]byte("date,base,quote\n2026-10-01,RUB,KZT\n"))
+	if !errors.Is(err, ers.ErrInvalidInput) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrInvalidInput, err)
+	}
+}
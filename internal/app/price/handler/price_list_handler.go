@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/price/service"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/logger"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/response"
+)
+
+type PriceListHandler struct {
+	service service.PriceListService
+	logger  logger.Logger
+}
+
+func NewPriceListHandler(service service.PriceListService, logger logger.Logger) *PriceListHandler {
+	return &PriceListHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *PriceListHandler) Create(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodPost) {
+		return
+	}
+
+	var req CreatePriceListRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, h.logger, fmt.Errorf("%w: invalid body", ers.ErrInvalidInput))
+		return
+	}
+
+	list, err := h.service.Create(r.Context(), req.ToDomain())
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusCreated, list)
+}
+
+func (h *PriceListHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodGet) {
+		return
+	}
+
+	lists, err := h.service.GetAll(r.Context())
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusOK, lists)
+}
+
+func (h *PriceListHandler) GetById(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodGet) {
+		return
+	}
+
+	id, err := response.PathID(r, h.logger, "id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	list, err := h.service.GetById(r.Context(), id)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusOK, list)
+}
+
+func (h *PriceListHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodDelete) {
+		return
+	}
+
+	id, err := response.PathID(r, h.logger, "id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), id); err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusNoContent, nil)
+}
+
+func (h *PriceListHandler) Items(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodGet) {
+		return
+	}
+
+	id, err := response.PathID(r, h.logger, "id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	items, err := h.service.Items(r.Context(), id)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusOK, items)
+}
+
+func (h *PriceListHandler) SetItems(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodPut) {
+		return
+	}
+
+	id, err := response.PathID(r, h.logger, "id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	var req SetPriceListItemsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, h.logger, fmt.Errorf("%w: invalid body", ers.ErrInvalidInput))
+		return
+	}
+
+	items, err := h.service.SetItems(r.Context(), id, req.ToDomain())
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusOK, items)
+}
+
+func (h *PriceListHandler) DeleteItem(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodDelete) {
+		return
+	}
+
+	id, err := response.PathID(r, h.logger, "id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+	productID, err := response.PathID(r, h.logger, "product_id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	if err := h.service.DeleteItem(r.Context(), id, productID); err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusNoContent, nil)
+}
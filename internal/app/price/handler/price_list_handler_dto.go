@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+type CreatePriceListRequest struct {
+	Currency string `json:"currency"`
+	Region   string `json:"region"`
+	Name     string `json:"name"`
+}
+
+func (r *CreatePriceListRequest) ToDomain() domain.PriceList {
+	return domain.PriceList{
+		Currency: r.Currency,
+		Region:   r.Region,
+		Name:     r.Name,
+	}
+}
+
+type PriceListItemRequest struct {
+	ProductID uuid.UUID    `json:"product_id"`
+	Price     domain.Money `json:"price"`
+}
+
+type SetPriceListItemsRequest struct {
+	Items []PriceListItemRequest `json:"items"`
+}
+
+func (r *SetPriceListItemsRequest) ToDomain() []domain.PriceListItem {
+	items := make([]domain.PriceListItem, 0, len(r.Items))
+	for _, item := range r.Items {
+		items = append(items, domain.PriceListItem{ProductID: item.ProductID, Price: item.Price})
+	}
+	return items
+}
+
+type ImportRatesResponse struct {
+	Imported int `json:"imported"`
+}
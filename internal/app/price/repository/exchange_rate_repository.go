@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	core "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/repository"
+)
+
+// rateColumns trims the NUMERIC scale so that a rate reads back as it was loaded.
+const rateColumns = `r.base, r.quote, r.effective_date, trim_scale(r.rate)::text`
+
+type PostgresExchangeRateRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresExchangeRateRepository(db *sql.DB) *PostgresExchangeRateRepository {
+	return &PostgresExchangeRateRepository{
+		db: db,
+	}
+}
+
+// Save adds the rates, replacing any already stored for the same pair and date.
+func (i *PostgresExchangeRateRepository) Save(ctx context.Context, rates []domain.ExchangeRate) error {
+	conn := core.Conn(ctx, i.db)
+
+	for _, rate := range rates {
+		query := `
+			INSERT INTO exchange_rates (base, quote, effective_date, rate)
+			VALUES ($1, $2, $3, $4::numeric)
+			ON CONFLICT (base, quote, effective_date) DO UPDATE SET rate = EXCLUDED.rate
+		`
+
+		if _, err := conn.ExecContext(ctx, query, rate.Base, rate.Quote, rate.EffectiveDate, rate.Rate); err != nil {
+			return fmt.Errorf("error saving exchange rate: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetAll lists stored rates, newest first; an empty base or quote matches any currency.
+func (i *PostgresExchangeRateRepository) GetAll(
+	ctx context.Context,
+	base string,
+	quote string,
+) ([]domain.ExchangeRate, error) {
+	var (
+		where []string
+		args  []interface{}
+	)
+	if base != "" {
+		args = append(args, base)
+		where = append(where, fmt.Sprintf("r.base = $%d", len(args)))
+	}
+	if quote != "" {
+		args = append(args, quote)
+		where = append(where, fmt.Sprintf("r.quote = $%d", len(args)))
+	}
+
+	query := `
+		SELECT ` + rateColumns + `
+		FROM exchange_rates r
+	`
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY r.base, r.quote, r.effective_date DESC"
+
+	rates := []domain.ExchangeRate{}
+
+	rows, err := core.Conn(ctx, i.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		if err := rows.Close(); err != nil {
+		}
+	}(rows)
+
+	for rows.Next() {
+		var rate domain.ExchangeRate
+		if err := rows.Scan(&rate.Base, &rate.Quote, &rate.EffectiveDate, &rate.Rate); err != nil {
+			return rates, err
+		}
+		rates = append(rates, rate)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return rates, nil
+}
+
+// Effective returns the latest rate for the pair that took effect on or before the given day.
+func (i *PostgresExchangeRateRepository) Effective(
+	ctx context.Context,
+	base string,
+	quote string,
+	on time.Time,
+) (domain.ExchangeRate, error) {
+	query := `
+		SELECT ` + rateColumns + `
+		FROM exchange_rates r
+		WHERE r.base = $1 AND r.quote = $2 AND r.effective_date <= $3::date
+		ORDER BY r.effective_date DESC
+		LIMIT 1
+	`
+
+	var rate domain.ExchangeRate
+	if err := core.Conn(ctx, i.db).QueryRowContext(ctx, query, base, quote, on.Format("2006-01-02")).Scan(
+		&rate.Base,
+		&rate.Quote,
+		&rate.EffectiveDate,
+		&rate.Rate,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ExchangeRate{}, fmt.Errorf("%w: no rate from %s to %s", ers.ErrExchangeRateNotFound, base, quote)
+		}
+		return domain.ExchangeRate{}, err
+	}
+
+	return rate, nil
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+type ExchangeRateRepository interface {
+	Save(ctx context.Context, rates []domain.ExchangeRate) error
+	GetAll(ctx context.Context, base string, quote string) ([]domain.ExchangeRate, error)
+	Effective(ctx context.Context, base string, quote string, on time.Time) (domain.ExchangeRate, error)
+}
@@ -0,0 +1,309 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	core "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/repository"
+	"github.com/lib/pq"
+)
+
+const (
+	uniqueViolation     = "23505"
+	foreignKeyViolation = "23503"
+)
+
+const priceListColumns = `l.id, l.currency, l.region, l.name, l.created_at, l.updated_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+type PostgresPriceListRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresPriceListRepository(db *sql.DB) *PostgresPriceListRepository {
+	return &PostgresPriceListRepository{
+		db: db,
+	}
+}
+
+func (i *PostgresPriceListRepository) Create(
+	ctx context.Context,
+	l *domain.PriceList,
+) (domain.PriceList, error) {
+	query := `
+		INSERT INTO price_lists (id, currency, region, name, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	if _, err := core.Conn(ctx, i.db).ExecContext(
+		ctx,
+		query,
+		l.ID,
+		l.Currency,
+		l.Region,
+		l.Name,
+		l.CreatedAt,
+		l.UpdatedAt,
+	); err != nil {
+		if isViolation(err, uniqueViolation) {
+			return domain.PriceList{}, fmt.Errorf("%w: %s already has a list for region %q", ers.ErrPriceListExists, l.Currency, l.Region)
+		}
+		return domain.PriceList{}, fmt.Errorf("error inserting price list: %w", err)
+	}
+
+	return *l, nil
+}
+
+func (i *PostgresPriceListRepository) GetById(ctx context.Context, id uuid.UUID) (domain.PriceList, error) {
+	query := `
+		SELECT ` + priceListColumns + `
+		FROM price_lists l
+		WHERE l.id = $1
+	`
+
+	return i.get(ctx, query, id)
+}
+
+func (i *PostgresPriceListRepository) GetAll(ctx context.Context) ([]domain.PriceList, error) {
+	query := `
+		SELECT ` + priceListColumns + `
+		FROM price_lists l
+		ORDER BY l.currency, l.region
+	`
+
+	lists := []domain.PriceList{}
+
+	rows, err := core.Conn(ctx, i.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		if err := rows.Close(); err != nil {
+		}
+	}(rows)
+
+	for rows.Next() {
+		list, err := scanPriceList(rows)
+		if err != nil {
+			return lists, err
+		}
+		lists = append(lists, list)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return lists, nil
+}
+
+func (i *PostgresPriceListRepository) Find(
+	ctx context.Context,
+	currency string,
+	region string,
+) (domain.PriceList, error) {
+	query := `
+		SELECT ` + priceListColumns + `
+		FROM price_lists l
+		WHERE l.currency = $1 AND l.region = $2
+	`
+
+	return i.get(ctx, query, currency, region)
+}
+
+// Delete removes the list together with its prices.
+func (i *PostgresPriceListRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := core.Conn(ctx, i.db).ExecContext(ctx, `DELETE FROM price_lists WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("error deleting price list: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: price list not found", ers.ErrPriceListNotFound)
+	}
+
+	return nil
+}
+
+func (i *PostgresPriceListRepository) Items(
+	ctx context.Context,
+	listID uuid.UUID,
+) ([]domain.PriceListItem, error) {
+	query := `
+		SELECT it.product_id, it.price_minor, l.currency, it.updated_at
+		FROM price_list_items it
+		JOIN price_lists l ON l.id = it.price_list_id
+		WHERE it.price_list_id = $1
+		ORDER BY it.product_id
+	`
+
+	items := []domain.PriceListItem{}
+
+	rows, err := core.Conn(ctx, i.db).QueryContext(ctx, query, listID)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		if err := rows.Close(); err != nil {
+		}
+	}(rows)
+
+	for rows.Next() {
+		var item domain.PriceListItem
+		if err := rows.Scan(&item.ProductID, &item.Price.Amount, &item.Price.Currency, &item.UpdatedAt); err != nil {
+			return items, err
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return items, nil
+}
+
+// ItemPrices returns the listed prices, in minor units, of those products that have one.
+func (i *PostgresPriceListRepository) ItemPrices(
+	ctx context.Context,
+	listID uuid.UUID,
+	productIDs []uuid.UUID,
+) (map[uuid.UUID]int64, error) {
+	query := `
+		SELECT product_id, price_minor
+		FROM price_list_items
+		WHERE price_list_id = $1 AND product_id = ANY($2)
+	`
+
+	ids := make(pq.StringArray, len(productIDs))
+	for n, id := range productIDs {
+		ids[n] = id.String()
+	}
+
+	rows, err := core.Conn(ctx, i.db).QueryContext(ctx, query, listID, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		if err := rows.Close(); err != nil {
+		}
+	}(rows)
+
+	prices := make(map[uuid.UUID]int64, len(productIDs))
+	for rows.Next() {
+		var (
+			productID uuid.UUID
+			price     int64
+		)
+		if err := rows.Scan(&productID, &price); err != nil {
+			return nil, err
+		}
+		prices[productID] = price
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return prices, nil
+}
+
+// SetItems adds or replaces the prices of the given products; other entries stay.
+func (i *PostgresPriceListRepository) SetItems(
+	ctx context.Context,
+	listID uuid.UUID,
+	items []domain.PriceListItem,
+) error {
+	conn := core.Conn(ctx, i.db)
+
+	for _, item := range items {
+		query := `
+			INSERT INTO price_list_items (price_list_id, product_id, price_minor, updated_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (price_list_id, product_id)
+			DO UPDATE SET price_minor = EXCLUDED.price_minor, updated_at = EXCLUDED.updated_at
+		`
+
+		if _, err := conn.ExecContext(ctx, query, listID, item.ProductID, item.Price.Amount, item.UpdatedAt); err != nil {
+			if isViolation(err, foreignKeyViolation) {
+				return fmt.Errorf("%w: product %s not found", ers.ErrProductNotFound, item.ProductID)
+			}
+			return fmt.Errorf("error saving price: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (i *PostgresPriceListRepository) DeleteItem(
+	ctx context.Context,
+	listID uuid.UUID,
+	productID uuid.UUID,
+) error {
+	result, err := core.Conn(ctx, i.db).ExecContext(
+		ctx,
+		`DELETE FROM price_list_items WHERE price_list_id = $1 AND product_id = $2`,
+		listID, productID,
+	)
+	if err != nil {
+		return fmt.Errorf("error deleting price: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: the list has no price for this product", ers.ErrProductNotFound)
+	}
+
+	return nil
+}
+
+func (i *PostgresPriceListRepository) get(
+	ctx context.Context,
+	query string,
+	args ...interface{},
+) (domain.PriceList, error) {
+	list, err := scanPriceList(core.Conn(ctx, i.db).QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.PriceList{}, fmt.Errorf("%w: price list not found", ers.ErrPriceListNotFound)
+		}
+		return domain.PriceList{}, err
+	}
+
+	return list, nil
+}
+
+func scanPriceList(row rowScanner) (domain.PriceList, error) {
+	var list domain.PriceList
+
+	if err := row.Scan(
+		&list.ID,
+		&list.Currency,
+		&list.Region,
+		&list.Name,
+		&list.CreatedAt,
+		&list.UpdatedAt,
+	); err != nil {
+		return domain.PriceList{}, err
+	}
+
+	return list, nil
+}
+
+func isViolation(err error, code pq.ErrorCode) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == code
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+type PriceListRepository interface {
+	Create(ctx context.Context, l *domain.PriceList) (domain.PriceList, error)
+	GetById(ctx context.Context, id uuid.UUID) (domain.PriceList, error)
+	GetAll(ctx context.Context) ([]domain.PriceList, error)
+	Find(ctx context.Context, currency string, region string) (domain.PriceList, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	Items(ctx context.Context, listID uuid.UUID) ([]domain.PriceListItem, error)
+	ItemPrices(ctx context.Context, listID uuid.UUID, productIDs []uuid.UUID) (map[uuid.UUID]int64, error)
+	SetItems(ctx context.Context, listID uuid.UUID, items []domain.PriceListItem) error
+	DeleteItem(ctx context.Context, listID uuid.UUID, productID uuid.UUID) error
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/price/repository"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	core "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/repository"
+)
+
+const MaxImportedRates = 10000
+
+type exchangeRateService struct {
+	repo repository.ExchangeRateRepository
+	tx   core.Transactor
+}
+
+func NewExchangeRateService(repo repository.ExchangeRateRepository, tx core.Transactor) ExchangeRateService {
+	return &exchangeRateService{
+		repo: repo,
+		tx:   tx,
+	}
+}
+
+// Import stores all rates or none of them.
+func (s *exchangeRateService) Import(ctx context.Context, rates []domain.ExchangeRate) (int, error) {
+	if len(rates) == 0 || len(rates) > MaxImportedRates {
+		return 0, fmt.Errorf("%w: an import needs 1 to %d rates", ers.ErrInvalidInput, MaxImportedRates)
+	}
+	for n := range rates {
+		if err := validateRate(&rates[n]); err != nil {
+			return 0, err
+		}
+	}
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		return s.repo.Save(ctx, rates)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(rates), nil
+}
+
+func (s *exchangeRateService) GetAll(ctx context.Context, base string, quote string) ([]domain.ExchangeRate, error) {
+	base = strings.ToUpper(strings.TrimSpace(base))
+	quote = strings.ToUpper(strings.TrimSpace(quote))
+	for _, currency := range []string{base, quote} {
+		if currency != "" && !domain.ValidCurrency(currency) {
+			return nil, fmt.Errorf("%w: unsupported currency %q", ers.ErrInvalidInput, currency)
+		}
+	}
+
+	return s.repo.GetAll(ctx, base, quote)
+}
+
+func validateRate(rate *domain.ExchangeRate) error {
+	rate.Base = strings.ToUpper(strings.TrimSpace(rate.Base))
+	rate.Quote = strings.ToUpper(strings.TrimSpace(rate.Quote))
+	rate.Rate = strings.TrimSpace(rate.Rate)
+
+	if !domain.ValidCurrency(rate.Base) || !domain.ValidCurrency(rate.Quote) {
+		return fmt.Errorf("%w: unsupported currency pair %s/%s", ers.ErrInvalidInput, rate.Base, rate.Quote)
+	}
+	if rate.Base == rate.Quote {
+		return fmt.Errorf("%w: a rate needs two different currencies", ers.ErrInvalidInput)
+	}
+	if rate.EffectiveDate.IsZero() {
+		return fmt.Errorf("%w: rate %s/%s has no effective date", ers.ErrInvalidInput, rate.Base, rate.Quote)
+	}
+	if _, err := rate.Ratio(); err != nil {
+		return err
+	}
+	return nil
+}
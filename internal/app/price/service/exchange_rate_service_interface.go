@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+type ExchangeRateService interface {
+	Import(ctx context.Context, rates []domain.ExchangeRate) (int, error)
+	GetAll(ctx context.Context, base string, quote string) ([]domain.ExchangeRate, error)
+}
@@ -0,0 +1,335 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/price/repository"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	core "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/repository"
+)
+
+const (
+	MaxPriceListItems      = 1000
+	maxPriceListNameLength = 200
+)
+
+var regionPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+
+type priceListService struct {
+	repo  repository.PriceListRepository
+	rates repository.ExchangeRateRepository
+	tx    core.Transactor
+}
+
+func NewPriceListService(
+	repo repository.PriceListRepository,
+	rates repository.ExchangeRateRepository,
+	tx core.Transactor,
+) PriceListService {
+	return &priceListService{
+		repo:  repo,
+		rates: rates,
+		tx:    tx,
+	}
+}
+
+func (s *priceListService) Create(ctx context.Context, l domain.PriceList) (domain.PriceList, error) {
+	l.Currency = strings.ToUpper(strings.TrimSpace(l.Currency))
+	l.Region = normalizeRegion(l.Region)
+	l.Name = strings.TrimSpace(l.Name)
+
+	if !domain.ValidCurrency(l.Currency) {
+		return domain.PriceList{}, fmt.Errorf("%w: unsupported currency %q", ers.ErrInvalidInput, l.Currency)
+	}
+	if err := validateRegion(l.Region); err != nil {
+		return domain.PriceList{}, err
+	}
+	if l.Name == "" || utf8.RuneCountInString(l.Name) > maxPriceListNameLength {
+		return domain.PriceList{}, fmt.Errorf("%w: price list name must be 1 to %d characters", ers.ErrInvalidInput, maxPriceListNameLength)
+	}
+
+	l.ID = uuid.New()
+	l.CreatedAt = time.Now()
+	l.UpdatedAt = time.Now()
+
+	return s.repo.Create(ctx, &l)
+}
+
+func (s *priceListService) GetById(ctx context.Context, id uuid.UUID) (domain.PriceList, error) {
+	if id == uuid.Nil {
+		return domain.PriceList{}, fmt.Errorf("%w: invalid price list id", ers.ErrInvalidInput)
+	}
+	return s.repo.GetById(ctx, id)
+}
+
+func (s *priceListService) GetAll(ctx context.Context) ([]domain.PriceList, error) {
+	return s.repo.GetAll(ctx)
+}
+
+func (s *priceListService) Delete(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return fmt.Errorf("%w: invalid price list id", ers.ErrInvalidInput)
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *priceListService) Items(ctx context.Context, listID uuid.UUID) ([]domain.PriceListItem, error) {
+	if _, err := s.GetById(ctx, listID); err != nil {
+		return nil, err
+	}
+	return s.repo.Items(ctx, listID)
+}
+
+// SetItems adds or replaces prices in the list. Prices without a currency are
+// taken to be in the list's currency; any other currency is rejected.
+func (s *priceListService) SetItems(
+	ctx context.Context,
+	listID uuid.UUID,
+	items []domain.PriceListItem,
+) ([]domain.PriceListItem, error) {
+	if len(items) == 0 || len(items) > MaxPriceListItems {
+		return nil, fmt.Errorf("%w: send 1 to %d prices at a time", ers.ErrInvalidInput, MaxPriceListItems)
+	}
+
+	var saved []domain.PriceListItem
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		list, err := s.GetById(ctx, listID)
+		if err != nil {
+			return err
+		}
+
+		seen := make(map[uuid.UUID]bool, len(items))
+		for n := range items {
+			item := &items[n]
+			if item.ProductID == uuid.Nil {
+				return fmt.Errorf("%w: invalid product id", ers.ErrInvalidInput)
+			}
+			if seen[item.ProductID] {
+				return fmt.Errorf("%w: product %s is listed twice", ers.ErrInvalidInput, item.ProductID)
+			}
+			seen[item.ProductID] = true
+
+			if item.Price.Currency == "" {
+				item.Price.Currency = list.Currency
+			}
+			if item.Price.Currency != list.Currency {
+				return fmt.Errorf("%w: the list is in %s, not %s", ers.ErrCurrencyMismatch, list.Currency, item.Price.Currency)
+			}
+			if item.Price.IsNegative() {
+				return fmt.Errorf("%w: price cannot be negative", ers.ErrInvalidInput)
+			}
+			item.UpdatedAt = time.Now()
+		}
+
+		if err := s.repo.SetItems(ctx, listID, items); err != nil {
+			return err
+		}
+		saved, err = s.repo.Items(ctx, listID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+func (s *priceListService) DeleteItem(ctx context.Context, listID uuid.UUID, productID uuid.UUID) error {
+	if listID == uuid.Nil || productID == uuid.Nil {
+		return fmt.Errorf("%w: invalid id", ers.ErrInvalidInput)
+	}
+	return s.repo.DeleteItem(ctx, listID, productID)
+}
+
+// Resolve sets ResolvedPrice on the products and their nested variants. The
+// list for the currency and region wins, then the currency's default list,
+// then the product's own price, converted at today's rate when needed.
+func (s *priceListService) Resolve(
+	ctx context.Context,
+	products []domain.Product,
+	currency string,
+	region string,
+) error {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	region = normalizeRegion(region)
+	if !domain.ValidCurrency(currency) {
+		return fmt.Errorf("%w: unsupported currency %q", ers.ErrInvalidInput, currency)
+	}
+	if err := validateRegion(region); err != nil {
+		return err
+	}
+
+	r := resolver{currency: currency, rates: map[string]rateRatio{}}
+
+	list, err := s.findList(ctx, currency, region)
+	if err != nil {
+		return err
+	}
+	if list != nil {
+		r.list = list
+		if r.listed, err = s.repo.ItemPrices(ctx, list.ID, productIDs(products)); err != nil {
+			return err
+		}
+	}
+
+	return s.resolve(ctx, &r, products)
+}
+
+func (s *priceListService) resolve(ctx context.Context, r *resolver, products []domain.Product) error {
+	for n := range products {
+		product := &products[n]
+
+		resolved, ok := r.listedPrice(*product)
+		if !ok && product.Price.Currency == r.currency {
+			resolved, ok = domain.ResolvedPrice{Price: product.Price, Source: domain.PriceSourceBase}, true
+		}
+		if !ok {
+			rate, err := s.rate(ctx, r, product.Price.Currency)
+			if err != nil {
+				return err
+			}
+			if resolved, err = convertedPrice(product.Price, r.currency, rate); err != nil {
+				return err
+			}
+		}
+		product.ResolvedPrice = &resolved
+
+		if err := s.resolve(ctx, r, product.Variants); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findList returns nil when neither the region nor the currency has a list.
+func (s *priceListService) findList(ctx context.Context, currency string, region string) (*domain.PriceList, error) {
+	regions := []string{region}
+	if region != "" {
+		regions = append(regions, "")
+	}
+
+	for _, region := range regions {
+		list, err := s.repo.Find(ctx, currency, region)
+		if err == nil {
+			return &list, nil
+		}
+		if !errors.Is(err, ers.ErrPriceListNotFound) {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// rate looks up today's rate from a currency into the resolver's, falling back
+// to the inverse of a stored rate for the opposite direction.
+func (s *priceListService) rate(ctx context.Context, r *resolver, from string) (rateRatio, error) {
+	if rate, ok := r.rates[from]; ok {
+		return rate, nil
+	}
+
+	today := time.Now()
+	rate, err := s.rates.Effective(ctx, from, r.currency, today)
+	inverse := false
+	if errors.Is(err, ers.ErrExchangeRateNotFound) {
+		var inverseErr error
+		if rate, inverseErr = s.rates.Effective(ctx, r.currency, from, today); inverseErr == nil {
+			err, inverse = nil, true
+		}
+	}
+	if err != nil {
+		return rateRatio{}, err
+	}
+
+	ratio, err := rate.Ratio()
+	if err != nil {
+		return rateRatio{}, err
+	}
+	if inverse {
+		ratio.Inv(ratio)
+	}
+
+	r.rates[from] = rateRatio{rate: rate, ratio: ratio}
+	return r.rates[from], nil
+}
+
+type rateRatio struct {
+	rate  domain.ExchangeRate
+	ratio *big.Rat
+}
+
+// resolver carries the lookups shared by all products of one Resolve call.
+type resolver struct {
+	currency string
+	list     *domain.PriceList
+	listed   map[uuid.UUID]int64
+	rates    map[string]rateRatio
+}
+
+// listedPrice finds the product in the list; a variant that inherits its price
+// also inherits the parent's entry.
+func (r *resolver) listedPrice(product domain.Product) (domain.ResolvedPrice, bool) {
+	if r.list == nil {
+		return domain.ResolvedPrice{}, false
+	}
+
+	amount, ok := r.listed[product.ID]
+	if !ok && product.IsVariant() && product.InheritsPrice {
+		amount, ok = r.listed[*product.ParentID]
+	}
+	if !ok {
+		return domain.ResolvedPrice{}, false
+	}
+
+	return domain.ResolvedPrice{
+		Price:       domain.NewMoney(amount, r.list.Currency),
+		Source:      domain.PriceSourceList,
+		PriceListID: &r.list.ID,
+	}, true
+}
+
+func convertedPrice(price domain.Money, currency string, rate rateRatio) (domain.ResolvedPrice, error) {
+	converted, err := price.Convert(currency, rate.ratio)
+	if err != nil {
+		return domain.ResolvedPrice{}, err
+	}
+
+	return domain.ResolvedPrice{
+		Price:         converted,
+		Source:        domain.PriceSourceConverted,
+		ConvertedFrom: &price,
+		Rate:          &rate.rate,
+	}, nil
+}
+
+// productIDs collects the products, their variants and the parents of variants.
+func productIDs(products []domain.Product) []uuid.UUID {
+	var ids []uuid.UUID
+	for _, product := range products {
+		ids = append(ids, product.ID)
+		if product.ParentID != nil {
+			ids = append(ids, *product.ParentID)
+		}
+		ids = append(ids, productIDs(product.Variants)...)
+	}
+	return ids
+}
+
+func normalizeRegion(region string) string {
+	return strings.ToLower(strings.TrimSpace(region))
+}
+
+func validateRegion(region string) error {
+	if region != "" && !regionPattern.MatchString(region) {
+		return fmt.Errorf("%w: region must be 1-32 lowercase letters, digits, '-' or '_'", ers.ErrInvalidInput)
+	}
+	return nil
+}
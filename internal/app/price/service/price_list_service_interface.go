@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+type PriceListService interface {
+	Create(ctx context.Context, l domain.PriceList) (domain.PriceList, error)
+	GetById(ctx context.Context, id uuid.UUID) (domain.PriceList, error)
+	GetAll(ctx context.Context) ([]domain.PriceList, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	Items(ctx context.Context, listID uuid.UUID) ([]domain.PriceListItem, error)
+	SetItems(ctx context.Context, listID uuid.UUID, items []domain.PriceListItem) ([]domain.PriceListItem, error)
+	DeleteItem(ctx context.Context, listID uuid.UUID, productID uuid.UUID) error
+	Resolve(ctx context.Context, products []domain.Product, currency string, region string) error
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+func TestResolver_ListedPriceInheritedByVariant(t *testing.T) {
+	parentID := uuid.New()
+	list := &domain.PriceList{ID: uuid.New(), Currency: "KZT"}
+	r := resolver{currency: "KZT", list: list, listed: map[uuid.UUID]int64{parentID: 990000}}
+
+	variant := domain.Product{ID: uuid.New(), ParentID: &parentID, InheritsPrice: true}
+	resolved, ok := r.listedPrice(variant)
+	if !ok {
+		t.Fatalf("expected the variant to inherit the parent's list price")
+	}
+	if resolved.Price != domain.NewMoney(990000, "KZT") || resolved.Source != domain.PriceSourceList || *resolved.PriceListID != list.ID {
+		t.Errorf("unexpected resolved price: %+v", resolved)
+	}
+
+	variant.InheritsPrice = false
+	if _, ok := r.listedPrice(variant); ok {
+		t.Errorf("expected a variant with its own price not to use the parent's entry")
+	}
+}
+
+func TestConvertedPrice_KeepsSource(t *testing.T) {
+	rate := rateRatio{
+		rate:  domain.ExchangeRate{Base: "RUB", Quote: "KZT", Rate: "5.12"},
+		ratio: big.NewRat(512, 100),
+	}
+
+	resolved, err := convertedPrice(domain.NewMoney(150050, "RUB"), "KZT", rate)
+	if err != nil {
+		t.Fatalf("conversion failed: %s", err)
+	}
+	if resolved.Price != domain.NewMoney(768256, "KZT") || resolved.Source != domain.PriceSourceConverted {
+		t.Errorf("expected 7682.56 KZT converted, but got %s from %s", resolved.Price, resolved.Source)
+	}
+	if resolved.ConvertedFrom.Amount != 150050 || resolved.Rate.Rate != "5.12" {
+		t.Errorf("expected the original price and rate to be kept, but got %+v", resolved)
+	}
+}
@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/response"
+)
+
+const (
+	BatchAtomic     = "atomic"
+	BatchBestEffort = "best_effort"
+)
+
+type BatchRequest struct {
+	Mode       string                  `json:"mode"`
+	Operations []BatchOperationRequest `json:"operations"`
+}
+
+// Atomic reports whether the batch must be all-or-nothing, which is the default.
+func (r *BatchRequest) Atomic() (bool, error) {
+	switch r.Mode {
+	case "", BatchAtomic:
+		return true, nil
+	case BatchBestEffort:
+		return false, nil
+	default:
+		return false, fmt.Errorf("%w: mode must be %q or %q", ers.ErrInvalidInput, BatchAtomic, BatchBestEffort)
+	}
+}
+
+func (r *BatchRequest) ToDomain() []domain.BatchOperation {
+	ops := make([]domain.BatchOperation, len(r.Operations))
+	for i, op := range r.Operations {
+		ops[i] = op.ToDomain()
+	}
+	return ops
+}
+
+// BatchOperationRequest takes the single-item request bodies: product for
+// create, changes for update and the adjust fields for adjust.
+type BatchOperationRequest struct {
+	Op            domain.BatchOp        `json:"op"`
+	ID            uuid.UUID             `json:"id"`
+	Version       int64                 `json:"version"`
+	Product       *CreateProductRequest `json:"product"`
+	Changes       *UpdateProductRequest `json:"changes"`
+	WarehouseID   uuid.UUID             `json:"warehouse_id"`
+	Delta         int                   `json:"delta"`
+	ReasonCode    string                `json:"reason_code"`
+	CorrelationID string                `json:"correlation_id"`
+}
+
+func (r *BatchOperationRequest) ToDomain() domain.BatchOperation {
+	op := domain.BatchOperation{
+		Op:      r.Op,
+		ID:      r.ID,
+		Version: r.Version,
+		Adjust: domain.AdjustStockDTO{
+			WarehouseID:   r.WarehouseID,
+			Delta:         r.Delta,
+			ReasonCode:    r.ReasonCode,
+			CorrelationID: r.CorrelationID,
+		},
+	}
+	if r.Product != nil {
+		op.Product = r.Product.ToDomain()
+	}
+	if r.Changes != nil {
+		op.Changes = r.Changes.ToUpdateDTO()
+	}
+	return op
+}
+
+type BatchResponse struct {
+	Mode      string                `json:"mode"`
+	Succeeded int                   `json:"succeeded"`
+	Failed    int                   `json:"failed"`
+	Results   []BatchResultResponse `json:"results"`
+}
+
+// BatchResultResponse reports each operation with the status code and error
+// text the matching single-item endpoint would have returned.
+type BatchResultResponse struct {
+	Index   int                  `json:"index"`
+	Op      domain.BatchOp       `json:"op"`
+	Status  int                  `json:"status"`
+	Product *domain.Product      `json:"product,omitempty"`
+	Balance *domain.StockBalance `json:"balance,omitempty"`
+	Error   string               `json:"error,omitempty"`
+}
+
+var batchSuccessStatus = map[domain.BatchOp]int{
+	domain.BatchCreate: http.StatusCreated,
+	domain.BatchUpdate: http.StatusOK,
+	domain.BatchDelete: http.StatusNoContent,
+	domain.BatchAdjust: http.StatusOK,
+}
+
+func toBatchResponse(mode string, results []domain.BatchResult) BatchResponse {
+	resp := BatchResponse{Mode: mode, Results: make([]BatchResultResponse, len(results))}
+
+	for i, result := range results {
+		item := BatchResultResponse{
+			Index:   i,
+			Op:      result.Op,
+			Status:  batchSuccessStatus[result.Op],
+			Product: result.Product,
+			Balance: result.Balance,
+		}
+		if result.Err != nil {
+			item.Status = response.StatusCode(result.Err)
+			item.Error = response.ErrorText(result.Err)
+			resp.Failed++
+		} else {
+			resp.Succeeded++
+		}
+		resp.Results[i] = item
+	}
+
+	return resp
+}
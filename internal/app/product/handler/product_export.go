@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/xlsx"
+)
+
+const exportFlushEvery = 500
+
+// exportColumns starts with the columns the import endpoint reads, so an
+// export can be edited and uploaded back.
+var exportColumns = []string{
+	"sku", "name", "description", "price", "currency", "quantity",
+	"id", "reserved", "available", "version", "created_at", "updated_at", "deleted_at",
+}
+
+type exportFormat struct {
+	contentType string
+	extension   string
+	newWriter   func(w io.Writer) (productWriter, error)
+}
+
+var exportFormats = map[string]exportFormat{
+	"csv": {
+		contentType: "text/csv; charset=utf-8",
+		extension:   "csv",
+		newWriter:   newCSVProductWriter,
+	},
+	"jsonl": {
+		contentType: "application/x-ndjson",
+		extension:   "jsonl",
+		newWriter:   newJSONLProductWriter,
+	},
+	"xlsx": {
+		contentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		extension:   "xlsx",
+		newWriter:   newXLSXProductWriter,
+	},
+}
+
+func exportFormatByName(name string) (exportFormat, error) {
+	if name == "" {
+		name = "csv"
+	}
+	format, ok := exportFormats[name]
+	if !ok {
+		return exportFormat{}, fmt.Errorf("%w: unsupported export format %q", ers.ErrInvalidInput, name)
+	}
+	return format, nil
+}
+
+type productWriter interface {
+	Write(p domain.Product) error
+	Flush() error
+	Close() error
+}
+
+type csvProductWriter struct {
+	w *csv.Writer
+}
+
+func newCSVProductWriter(w io.Writer) (productWriter, error) {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(exportColumns); err != nil {
+		return nil, err
+	}
+	return &csvProductWriter{w: cw}, nil
+}
+
+func (c *csvProductWriter) Write(p domain.Product) error {
+	return c.w.Write([]string{
+		p.SKU,
+		p.Name,
+		p.Description,
+		p.Price.Decimal(),
+		p.Price.Currency,
+		strconv.Itoa(p.Quantity),
+		p.ID.String(),
+		strconv.Itoa(p.Reserved),
+		strconv.Itoa(p.Available),
+		strconv.FormatInt(p.Version, 10),
+		p.CreatedAt.UTC().Format(time.RFC3339),
+		p.UpdatedAt.UTC().Format(time.RFC3339),
+		formatDeletedAt(p.DeletedAt),
+	})
+}
+
+func (c *csvProductWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvProductWriter) Close() error {
+	return c.Flush()
+}
+
+type jsonlProductWriter struct {
+	enc *json.Encoder
+}
+
+func newJSONLProductWriter(w io.Writer) (productWriter, error) {
+	return &jsonlProductWriter{enc: json.NewEncoder(w)}, nil
+}
+
+func (j *jsonlProductWriter) Write(p domain.Product) error {
+	return j.enc.Encode(p)
+}
+
+func (j *jsonlProductWriter) Flush() error {
+	return nil
+}
+
+func (j *jsonlProductWriter) Close() error {
+	return nil
+}
+
+type xlsxProductWriter struct {
+	w *xlsx.Writer
+}
+
+func newXLSXProductWriter(w io.Writer) (productWriter, error) {
+	xw, err := xlsx.NewWriter(w, "Products")
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]interface{}, len(exportColumns))
+	for i, column := range exportColumns {
+		header[i] = column
+	}
+	if err := xw.WriteRow(header...); err != nil {
+		return nil, err
+	}
+
+	return &xlsxProductWriter{w: xw}, nil
+}
+
+func (x *xlsxProductWriter) Write(p domain.Product) error {
+	return x.w.WriteRow(
+		p.SKU,
+		p.Name,
+		p.Description,
+		p.Price.Decimal(),
+		p.Price.Currency,
+		p.Quantity,
+		p.ID.String(),
+		p.Reserved,
+		p.Available,
+		p.Version,
+		p.CreatedAt.UTC().Format(time.RFC3339),
+		p.UpdatedAt.UTC().Format(time.RFC3339),
+		formatDeletedAt(p.DeletedAt),
+	)
+}
+
+func (x *xlsxProductWriter) Flush() error {
+	return x.w.Flush()
+}
+
+func (x *xlsxProductWriter) Close() error {
+	return x.w.Close()
+}
+
+func formatDeletedAt(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	inventoryv1 "github.com/jamal23041989/go-marketplace-inventory-service/api/proto/inventory/v1"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/product/service"
+	stock "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/stock/service"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/grpcerr"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/logger"
+)
+
+// InventoryServer is the gRPC transport for the same services ProductHandler uses.
+type InventoryServer struct {
+	inventoryv1.UnimplementedInventoryServiceServer
+
+	service service.ProductService
+	stock   stock.StockService
+	logger  logger.Logger
+}
+
+func NewInventoryServer(
+	service service.ProductService,
+	stock stock.StockService,
+	logger logger.Logger,
+) *InventoryServer {
+	return &InventoryServer{
+		service: service,
+		stock:   stock,
+		logger:  logger,
+	}
+}
+
+func (s *InventoryServer) Get(ctx context.Context, req *inventoryv1.GetRequest) (*inventoryv1.Product, error) {
+	id, err := parseID(req.GetId(), "id")
+	if err != nil {
+		return nil, grpcerr.Status(s.logger, err)
+	}
+
+	product, err := s.service.GetById(ctx, id, req.GetIncludeDeleted())
+	if err != nil {
+		return nil, grpcerr.Status(s.logger, err)
+	}
+
+	return toProto(product), nil
+}
+
+// List walks the keyset pages of GetAll and streams each product as soon as its page arrives.
+func (s *InventoryServer) List(req *inventoryv1.ListRequest, stream inventoryv1.InventoryService_ListServer) error {
+	filter := listFilterFromProto(req)
+
+	for {
+		page, err := s.service.GetAll(stream.Context(), filter)
+		if err != nil {
+			return grpcerr.Status(s.logger, err)
+		}
+
+		for _, product := range page.Items {
+			if err := stream.Send(toProto(product)); err != nil {
+				return err
+			}
+		}
+
+		if page.NextCursor == "" {
+			return nil
+		}
+		filter.Cursor = page.NextCursor
+	}
+}
+
+func (s *InventoryServer) Create(ctx context.Context, req *inventoryv1.CreateRequest) (*inventoryv1.Product, error) {
+	product, err := s.service.Create(ctx, domain.Product{
+		SKU:         req.GetSku(),
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+		Price:       domain.Money{Amount: req.GetPrice(), Currency: strings.ToUpper(req.GetCurrency())},
+		Quantity:    int(req.GetQuantity()),
+	})
+	if err != nil {
+		return nil, grpcerr.Status(s.logger, err)
+	}
+
+	return toProto(product), nil
+}
+
+func (s *InventoryServer) Update(ctx context.Context, req *inventoryv1.UpdateRequest) (*inventoryv1.Product, error) {
+	id, err := parseID(req.GetId(), "id")
+	if err != nil {
+		return nil, grpcerr.Status(s.logger, err)
+	}
+
+	dto := domain.UpdateProductDTO{
+		SKU:         req.Sku,
+		Name:        req.Name,
+		Description: req.Description,
+	}
+	if req.Currency != nil && req.Price == nil {
+		return nil, grpcerr.Status(s.logger, fmt.Errorf("%w: currency can only change together with price", ers.ErrInvalidInput))
+	}
+	if req.Price != nil {
+		// without a currency the price stays in the currency the product already has
+		dto.Price = &domain.Money{Amount: *req.Price, Currency: strings.ToUpper(req.GetCurrency())}
+	}
+	if req.Quantity != nil {
+		quantity := int(*req.Quantity)
+		dto.Quantity = &quantity
+	}
+	if req.WarehouseId != nil {
+		warehouseID, err := parseID(*req.WarehouseId, "warehouse_id")
+		if err != nil {
+			return nil, grpcerr.Status(s.logger, err)
+		}
+		dto.WarehouseID = &warehouseID
+	}
+
+	product, err := s.service.Update(ctx, id, dto, req.GetVersion())
+	if err != nil {
+		return nil, grpcerr.Status(s.logger, err)
+	}
+
+	return toProto(product), nil
+}
+
+func (s *InventoryServer) Delete(ctx context.Context, req *inventoryv1.DeleteRequest) (*inventoryv1.DeleteResponse, error) {
+	id, err := parseID(req.GetId(), "id")
+	if err != nil {
+		return nil, grpcerr.Status(s.logger, err)
+	}
+
+	if err := s.service.Delete(ctx, id, req.GetVersion()); err != nil {
+		return nil, grpcerr.Status(s.logger, err)
+	}
+
+	return &inventoryv1.DeleteResponse{}, nil
+}
+
+func (s *InventoryServer) AdjustStock(
+	ctx context.Context,
+	req *inventoryv1.AdjustStockRequest,
+) (*inventoryv1.StockBalance, error) {
+	productID, err := parseID(req.GetProductId(), "product_id")
+	if err != nil {
+		return nil, grpcerr.Status(s.logger, err)
+	}
+
+	var warehouseID uuid.UUID
+	if req.GetWarehouseId() != "" {
+		if warehouseID, err = parseID(req.GetWarehouseId(), "warehouse_id"); err != nil {
+			return nil, grpcerr.Status(s.logger, err)
+		}
+	}
+
+	balance, err := s.stock.Adjust(ctx, domain.AdjustStockDTO{
+		ProductID:     productID,
+		WarehouseID:   warehouseID,
+		Delta:         int(req.GetDelta()),
+		ReasonCode:    req.GetReasonCode(),
+		CorrelationID: req.GetCorrelationId(),
+	})
+	if err != nil {
+		return nil, grpcerr.Status(s.logger, err)
+	}
+
+	return &inventoryv1.StockBalance{
+		ProductId:         balance.ProductID.String(),
+		Quantity:          int64(balance.Quantity),
+		WarehouseId:       balance.WarehouseID.String(),
+		WarehouseQuantity: int64(balance.WarehouseQuantity),
+		MovementId:        balance.MovementID.String(),
+	}, nil
+}
+
+func parseID(value string, field string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil || id == uuid.Nil {
+		return uuid.Nil, fmt.Errorf("%w: invalid %s", ers.ErrInvalidInput, field)
+	}
+	return id, nil
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"strings"
+
+	inventoryv1 "github.com/jamal23041989/go-marketplace-inventory-service/api/proto/inventory/v1"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toProto(p domain.Product) *inventoryv1.Product {
+	out := &inventoryv1.Product{
+		Id:          p.ID.String(),
+		Sku:         p.SKU,
+		Name:        p.Name,
+		Description: p.Description,
+		Price:       p.Price.Amount,
+		Currency:    p.Price.Currency,
+		Quantity:    int64(p.Quantity),
+		Reserved:    int64(p.Reserved),
+		Available:   int64(p.Available),
+		Version:     p.Version,
+		CreatedAt:   timestamppb.New(p.CreatedAt),
+		UpdatedAt:   timestamppb.New(p.UpdatedAt),
+	}
+	if p.DeletedAt != nil {
+		out.DeletedAt = timestamppb.New(*p.DeletedAt)
+	}
+	return out
+}
+
+func listFilterFromProto(req *inventoryv1.ListRequest) domain.ProductFilter {
+	filter := domain.ProductFilter{
+		Limit:          int(req.GetPageSize()),
+		NamePrefix:     req.GetNamePrefix(),
+		InStock:        req.GetInStock(),
+		IncludeDeleted: req.GetIncludeDeleted(),
+	}
+
+	// gRPC price bounds are minor units of the default currency
+	if req.MinPrice != nil {
+		minPrice := domain.NewMoney(req.GetMinPrice(), domain.DefaultCurrency)
+		filter.MinPrice = &minPrice
+	}
+	if req.MaxPrice != nil {
+		maxPrice := domain.NewMoney(req.GetMaxPrice(), domain.DefaultCurrency)
+		filter.MaxPrice = &maxPrice
+	}
+
+	sort := req.GetSort()
+	if strings.HasPrefix(sort, "-") {
+		filter.SortDesc = true
+		sort = strings.TrimPrefix(sort, "-")
+	}
+	filter.SortField = domain.ProductSortField(sort)
+
+	return filter
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	inventoryv1 "github.com/jamal23041989/go-marketplace-inventory-service/api/proto/inventory/v1"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/product/service"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// recordingProducts keeps the last update and answers reads with a fixed product.
+type recordingProducts struct {
+	service.ProductService
+	product domain.Product
+	updates []domain.UpdateProductDTO
+}
+
+func (r *recordingProducts) GetById(ctx context.Context, id uuid.UUID, includeDeleted bool) (domain.Product, error) {
+	if id != r.product.ID {
+		return domain.Product{}, ers.ErrProductNotFound
+	}
+	return r.product, nil
+}
+
+func (r *recordingProducts) Update(
+	ctx context.Context,
+	id uuid.UUID,
+	dto domain.UpdateProductDTO,
+	version int64,
+) (domain.Product, error) {
+	r.updates = append(r.updates, dto)
+	return r.product, nil
+}
+
+func newTestInventoryServer(products *recordingProducts) *InventoryServer {
+	return NewInventoryServer(products, nil, logger.New(io.Discard))
+}
+
+func TestGet_NotFound(t *testing.T) {
+	s := newTestInventoryServer(&recordingProducts{product: domain.Product{ID: uuid.New()}})
+
+	_, err := s.Get(context.Background(), &inventoryv1.GetRequest{Id: uuid.NewString()})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected code %s, but got %s", codes.NotFound, status.Code(err))
+	}
+}
+
+func TestGet_InvalidID(t *testing.T) {
+	s := newTestInventoryServer(&recordingProducts{})
+
+	_, err := s.Get(context.Background(), &inventoryv1.GetRequest{Id: "keyboard"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected code %s, but got %s", codes.InvalidArgument, status.Code(err))
+	}
+}
+
+func TestUpdate_CurrencyWithoutPrice(t *testing.T) {
+	products := &recordingProducts{}
+	s := newTestInventoryServer(products)
+
+	_, err := s.Update(context.Background(), &inventoryv1.UpdateRequest{
+		Id:       uuid.NewString(),
+		Currency: proto.String("usd"),
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected code %s, but got %s", codes.InvalidArgument, status.Code(err))
+	}
+
+	if len(products.updates) != 0 {
+		t.Errorf("expected the service not to be called, but got %d updates", len(products.updates))
+	}
+}
+
+func TestUpdate_MapsPriceAndWarehouse(t *testing.T) {
+	products := &recordingProducts{}
+	s := newTestInventoryServer(products)
+
+	warehouseID := uuid.New()
+	_, err := s.Update(context.Background(), &inventoryv1.UpdateRequest{
+		Id:          uuid.NewString(),
+		Price:       proto.Int64(99900),
+		Currency:    proto.String("usd"),
+		Quantity:    proto.Int64(5),
+		WarehouseId: proto.String(warehouseID.String()),
+	})
+	if err != nil {
+		t.Fatalf("update failed: %s", err)
+	}
+
+	if len(products.updates) != 1 {
+		t.Fatalf("expected one update, but got %d", len(products.updates))
+	}
+
+	dto := products.updates[0]
+	if dto.Price == nil || *dto.Price != domain.NewMoney(99900, "USD") {
+		t.Errorf("expected price 999.00 USD, but got %v", dto.Price)
+	}
+	if dto.Quantity == nil || *dto.Quantity != 5 {
+		t.Errorf("expected quantity 5, but got %v", dto.Quantity)
+	}
+	if dto.WarehouseID == nil || *dto.WarehouseID != warehouseID {
+		t.Errorf("expected warehouse %s, but got %v", warehouseID, dto.WarehouseID)
+	}
+}
+
+func TestToProto_PriceAndDeletedAt(t *testing.T) {
+	deletedAt := time.Now()
+	product := domain.Product{
+		ID:        uuid.New(),
+		Name:      "Клавиатура",
+		Price:     domain.NewMoney(150000, "RUB"),
+		Quantity:  10,
+		Reserved:  3,
+		Available: 7,
+		DeletedAt: &deletedAt,
+	}
+
+	out := toProto(product)
+
+	if out.GetPrice() != 150000 || out.GetCurrency() != "RUB" {
+		t.Errorf("expected price 150000 RUB, but got %d %s", out.GetPrice(), out.GetCurrency())
+	}
+	if out.GetAvailable() != 7 || out.GetReserved() != 3 {
+		t.Errorf("expected 7 available and 3 reserved, but got %d and %d", out.GetAvailable(), out.GetReserved())
+	}
+	if out.GetDeletedAt() == nil || !out.GetDeletedAt().AsTime().Equal(deletedAt) {
+		t.Errorf("expected deleted_at %s, but got %v", deletedAt, out.GetDeletedAt())
+	}
+}
+
+func TestListFilterFromProto_DescendingSort(t *testing.T) {
+	filter := listFilterFromProto(&inventoryv1.ListRequest{Sort: "-price", MinPrice: proto.Int64(1000)})
+
+	if filter.SortField != domain.ProductSortField("price") || !filter.SortDesc {
+		t.Errorf("expected descending sort by price, but got %q desc=%t", filter.SortField, filter.SortDesc)
+	}
+	if filter.MinPrice == nil || filter.MinPrice.Currency != domain.DefaultCurrency {
+		t.Errorf("expected a min price in %s, but got %v", domain.DefaultCurrency, filter.MinPrice)
+	}
+}
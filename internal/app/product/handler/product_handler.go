@@ -5,169 +5,432 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	ps "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/price/service"
 	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/product/service"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
 	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
 	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/logger"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/response"
 )
 
 type ProductHandler struct {
 	service service.ProductService
+	prices  ps.PriceListService
 	logger  logger.Logger
 }
 
-func NewProductHandler(service service.ProductService, logger logger.Logger) *ProductHandler {
+func NewProductHandler(service service.ProductService, prices ps.PriceListService, logger logger.Logger) *ProductHandler {
 	return &ProductHandler{
 		service: service,
+		prices:  prices,
 		logger:  logger,
 	}
 }
 
 func (h *ProductHandler) Create(w http.ResponseWriter, r *http.Request) {
-	if !h.checkMethod(w, r, http.MethodPost) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodPost) {
 		return
 	}
 
 	var req CreateProductRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondWithError(w, fmt.Errorf("%w: invalid body", ers.ErrInvalidInput))
+		response.Error(w, h.logger, fmt.Errorf("%w: invalid body", ers.ErrInvalidInput))
 		return
 	}
 
 	createProduct, err := h.service.Create(r.Context(), req.ToDomain())
 	if err != nil {
-		h.respondWithError(w, err)
+		response.Error(w, h.logger, err)
 		return
 	}
 
-	h.respondWithJSON(w, http.StatusCreated, createProduct)
+	setETag(w, createProduct.Version)
+	response.JSON(w, h.logger, http.StatusCreated, createProduct)
 }
 
 func (h *ProductHandler) GetById(w http.ResponseWriter, r *http.Request) {
-	if !h.checkMethod(w, r, http.MethodGet) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodGet) {
 		return
 	}
 
-	id, err := h.getID(r, "/products/")
+	id, err := response.PathID(r, h.logger, "id")
 	if err != nil {
-		h.respondWithError(w, err)
+		response.Error(w, h.logger, err)
 		return
 	}
 
-	product, err := h.service.GetById(r.Context(), id)
+	includeDeleted, err := queryBool(r, "include_deleted")
 	if err != nil {
-		h.respondWithError(w, err)
+		response.Error(w, h.logger, err)
 		return
 	}
 
-	h.respondWithJSON(w, http.StatusOK, product)
+	product, err := h.service.GetById(r.Context(), id, includeDeleted)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+	if err := h.resolvePrice(r, &product); err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	setETag(w, product.Version)
+	response.JSON(w, h.logger, http.StatusOK, product)
+}
+
+func (h *ProductHandler) GetBySKU(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodGet) {
+		return
+	}
+
+	product, err := h.service.GetBySKU(r.Context(), r.PathValue("sku"))
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+	if err := h.resolvePrice(r, &product); err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	setETag(w, product.Version)
+	response.JSON(w, h.logger, http.StatusOK, product)
+}
+
+func (h *ProductHandler) GetByBarcode(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodGet) {
+		return
+	}
+
+	product, err := h.service.GetByBarcode(r.Context(), r.PathValue("code"))
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+	if err := h.resolvePrice(r, &product); err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	setETag(w, product.Version)
+	response.JSON(w, h.logger, http.StatusOK, product)
 }
 
 func (h *ProductHandler) GetAll(w http.ResponseWriter, r *http.Request) {
-	if !h.checkMethod(w, r, http.MethodGet) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodGet) {
 		return
 	}
 
-	products, err := h.service.GetAll(r.Context())
+	filter, err := ProductFilterFromQuery(r)
 	if err != nil {
-		h.respondWithError(w, err)
+		response.Error(w, h.logger, err)
 		return
 	}
 
-	h.respondWithJSON(w, http.StatusOK, products)
+	page, err := h.service.GetAll(r.Context(), filter)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+	if err := ResolvePrices(r, h.prices, page.Items); err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusOK, page)
+}
+
+// Batch answers 200 when every operation succeeded or the batch is best-effort.
+// A failed atomic batch answers with the status of the operation that failed.
+func (h *ProductHandler) Batch(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodPost) {
+		return
+	}
+
+	var req BatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, h.logger, fmt.Errorf("%w: invalid body", ers.ErrInvalidInput))
+		return
+	}
+
+	atomic, err := req.Atomic()
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	results, err := h.service.Batch(r.Context(), req.ToDomain(), atomic)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	mode := BatchBestEffort
+	if atomic {
+		mode = BatchAtomic
+	}
+	resp := toBatchResponse(mode, results)
+
+	status := http.StatusOK
+	for _, result := range results {
+		if atomic && result.Err != nil && !errors.Is(result.Err, ers.ErrBatchAborted) {
+			status = response.StatusCode(result.Err)
+			h.logger.Warn("batch rolled back: %v", result.Err)
+			break
+		}
+	}
+
+	response.JSON(w, h.logger, status, resp)
+}
+
+// Export streams the products matching the list filters. The response starts
+// with the first row, so errors found before it still get a proper status;
+// a failure mid-stream can only be logged and leaves a truncated file.
+func (h *ProductHandler) Export(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodGet) {
+		return
+	}
+
+	format, err := exportFormatByName(r.URL.Query().Get("format"))
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	filter, err := ProductFilterFromQuery(r)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	var (
+		out     productWriter
+		started bool
+		written int
+	)
+	start := func() error {
+		started = true
+		filename := fmt.Sprintf("products-%s.%s", time.Now().UTC().Format("20060102-150405"), format.extension)
+		w.Header().Set("Content-Type", format.contentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		w.WriteHeader(http.StatusOK)
+
+		var err error
+		out, err = format.newWriter(w)
+		return err
+	}
+
+	rc := http.NewResponseController(w)
+	err = h.service.Export(r.Context(), filter, func(product domain.Product) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		if err := out.Write(product); err != nil {
+			return err
+		}
+
+		written++
+		if written%exportFlushEvery == 0 {
+			if err := out.Flush(); err != nil {
+				return err
+			}
+			// not every ResponseWriter can flush; the data still goes out as the buffer fills
+			_ = rc.Flush()
+		}
+		return nil
+	})
+	if err == nil && !started {
+		err = start()
+	}
+	if err != nil {
+		if !started {
+			response.Error(w, h.logger, err)
+			return
+		}
+		h.logger.Error("export aborted after %d products: %v", written, err)
+		return
+	}
+
+	if err := out.Close(); err != nil {
+		h.logger.Error("error finishing export: %v", err)
+	}
+}
+
+func (h *ProductHandler) Search(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodGet) {
+		return
+	}
+
+	var limit int
+	if value := r.URL.Query().Get("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil {
+			response.Error(w, h.logger, fmt.Errorf("%w: limit must be an integer", ers.ErrInvalidInput))
+			return
+		}
+	}
+
+	results, err := h.service.Search(r.Context(), r.URL.Query().Get("q"), limit)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusOK, results)
 }
 
 func (h *ProductHandler) Update(w http.ResponseWriter, r *http.Request) {
-	if !h.checkMethod(w, r, http.MethodPatch) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodPatch) {
+		return
+	}
+
+	id, err := response.PathID(r, h.logger, "id")
+	if err != nil {
+		response.Error(w, h.logger, err)
 		return
 	}
 
-	id, err := h.getID(r, "/products/")
+	version, err := h.matchVersion(r, id)
 	if err != nil {
-		h.respondWithError(w, err)
+		response.Error(w, h.logger, err)
 		return
 	}
 
 	var req UpdateProductRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondWithError(w, err)
+		response.Error(w, h.logger, fmt.Errorf("%w: invalid body", ers.ErrInvalidInput))
 		return
 	}
 
 	product := req.ToUpdateDTO()
-	updateProduct, err := h.service.Update(r.Context(), id, product)
+	updateProduct, err := h.service.Update(r.Context(), id, product, version)
 	if err != nil {
-		h.respondWithError(w, err)
+		response.Error(w, h.logger, err)
 		return
 	}
 
-	h.respondWithJSON(w, http.StatusOK, updateProduct)
+	setETag(w, updateProduct.Version)
+	response.JSON(w, h.logger, http.StatusOK, updateProduct)
 }
 
-func (h *ProductHandler) Delete(w http.ResponseWriter, r *http.Request) {
-	if !h.checkMethod(w, r, http.MethodDelete) {
+func (h *ProductHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodPost) {
 		return
 	}
 
-	id, err := h.getID(r, "/products/")
+	id, err := response.PathID(r, h.logger, "id")
 	if err != nil {
-		h.respondWithError(w, err)
+		response.Error(w, h.logger, err)
 		return
 	}
 
-	if err := h.service.Delete(r.Context(), id); err != nil {
-		h.respondWithError(w, err)
+	product, err := h.service.Restore(r.Context(), id)
+	if err != nil {
+		response.Error(w, h.logger, err)
 		return
 	}
 
-	h.respondWithJSON(w, http.StatusNoContent, nil)
+	setETag(w, product.Version)
+	response.JSON(w, h.logger, http.StatusOK, product)
 }
 
-func (h *ProductHandler) respondWithJSON(w http.ResponseWriter, status int, payload interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	if payload != nil {
-		if err := json.NewEncoder(w).Encode(payload); err != nil {
-			h.logger.Error("error encoding response: %v", err)
-		}
+func (h *ProductHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodDelete) {
+		return
 	}
-}
 
-func (h *ProductHandler) respondWithError(w http.ResponseWriter, err error) {
-	var valErr *ers.ValidationError
+	id, err := response.PathID(r, h.logger, "id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
 
-	if errors.As(err, &valErr) {
-		h.logger.Warn("validation error: %v", valErr.Error())
-		http.Error(w, valErr.Error(), http.StatusBadRequest)
+	version, err := h.matchVersion(r, id)
+	if err != nil {
+		response.Error(w, h.logger, err)
 		return
-	} else if errors.Is(err, ers.ErrProductNotFound) {
-		h.logger.Warn("product not found: %v", ers.ErrProductNotFound)
-		http.Error(w, fmt.Errorf("%w: not found error", ers.ErrProductNotFound).Error(), http.StatusNotFound)
+	}
+
+	if err := h.service.Delete(r.Context(), id, version); err != nil {
+		response.Error(w, h.logger, err)
 		return
 	}
 
-	h.logger.Error("internal error: %v", err)
-	http.Error(w, fmt.Errorf("%w: internal error", ers.ErrInternalServerError).Error(), http.StatusInternalServerError)
-	return
+	response.JSON(w, h.logger, http.StatusNoContent, nil)
 }
 
-func (h *ProductHandler) getID(r *http.Request, path string) (uuid.UUID, error) {
-	idStr := strings.TrimPrefix(r.URL.Path, path)
-	id, err := uuid.Parse(idStr)
-	if err != nil {
-		h.logger.Warn("invalid id: %v", idStr)
-		return uuid.Nil, fmt.Errorf("%w: invalid uuid format", ers.ErrInvalidInput)
+func setETag(w http.ResponseWriter, version int64) {
+	w.Header().Set("ETag", strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
+// ifMatch returns the product versions listed in the If-Match header, or nil
+// when the write is unconditional. If-Match uses strong comparison, so weak
+// tags never match; a header with nothing but weak tags fails the precondition.
+func ifMatch(r *http.Request) ([]int64, error) {
+	var (
+		versions []int64
+		weak     bool
+	)
+	for _, header := range r.Header.Values("If-Match") {
+		for _, tag := range strings.Split(header, ",") {
+			tag = strings.TrimSpace(tag)
+			switch {
+			case tag == "":
+				continue
+			case tag == "*":
+				return nil, nil
+			case strings.HasPrefix(tag, "W/"):
+				weak = true
+				continue
+			}
+
+			unquoted, err := strconv.Unquote(tag)
+			if err != nil {
+				unquoted = tag
+			}
+
+			version, err := strconv.ParseInt(unquoted, 10, 64)
+			if err != nil || version <= 0 {
+				return nil, fmt.Errorf("%w: malformed If-Match header", ers.ErrInvalidInput)
+			}
+			versions = append(versions, version)
+		}
 	}
-	return id, nil
+
+	if len(versions) == 0 && weak {
+		return nil, fmt.Errorf("%w: If-Match needs a strong ETag", ers.ErrPreconditionFailed)
+	}
+	return versions, nil
 }
 
-func (h *ProductHandler) checkMethod(w http.ResponseWriter, r *http.Request, expectedMethod string) bool {
-	if r.Method != expectedMethod {
-		h.logger.Warn("method not allowed: %v", expectedMethod)
-		http.Error(w, fmt.Errorf("%w: method not allowed error", ers.ErrMethodNotAllowed).Error(), http.StatusMethodNotAllowed)
-		return false
+// matchVersion picks the If-Match version the write is conditioned on, or 0
+// when it is unconditional. With several ETags it returns the one equal to the
+// current version; the service still checks it inside the write.
+func (h *ProductHandler) matchVersion(r *http.Request, id uuid.UUID) (int64, error) {
+	versions, err := ifMatch(r)
+	if err != nil || len(versions) == 0 {
+		return 0, err
+	}
+	if len(versions) == 1 {
+		return versions[0], nil
+	}
+
+	current, err := h.service.GetById(r.Context(), id, false)
+	if err != nil {
+		return 0, err
+	}
+	for _, version := range versions {
+		if version == current.Version {
+			return version, nil
+		}
 	}
-	return true
+	return 0, fmt.Errorf("%w: product version %d is not in If-Match", ers.ErrPreconditionFailed, current.Version)
 }
@@ -1,19 +1,34 @@
 package handler
 
 import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
 	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/response"
 )
 
 type CreateProductRequest struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Price       int64  `json:"price"`
-	Quantity    int    `json:"quantity"`
+	SKU         string       `json:"sku"`
+	Barcodes    []string     `json:"barcodes"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Price       domain.Money `json:"price"`
+	Quantity    int          `json:"quantity"`
 }
 
 func (r *CreateProductRequest) ToDomain() domain.Product {
 	var product domain.Product
 
+	product.SKU = r.SKU
+	for _, code := range r.Barcodes {
+		product.Barcodes = append(product.Barcodes, domain.Barcode{Code: code})
+	}
 	product.Name = r.Name
 	product.Description = r.Description
 	product.Price = r.Price
@@ -23,17 +38,150 @@ func (r *CreateProductRequest) ToDomain() domain.Product {
 }
 
 type UpdateProductRequest struct {
-	Name        *string `json:"name"`
-	Description *string `json:"description"`
-	Price       *int64  `json:"price"`
-	Quantity    *int    `json:"quantity"`
+	SKU          *string       `json:"sku"`
+	Barcodes     *[]string     `json:"barcodes"`
+	Name         *string       `json:"name"`
+	Description  *string       `json:"description"`
+	Price        *domain.Money `json:"price"`
+	InheritPrice *bool         `json:"inherit_price"`
+	Quantity     *int          `json:"quantity"`
+	WarehouseID  *uuid.UUID    `json:"warehouse_id"`
 }
 
 func (r *UpdateProductRequest) ToUpdateDTO() domain.UpdateProductDTO {
 	return domain.UpdateProductDTO{
-		Name:        r.Name,
-		Description: r.Description,
-		Price:       r.Price,
-		Quantity:    r.Quantity,
+		SKU:          r.SKU,
+		Barcodes:     r.Barcodes,
+		Name:         r.Name,
+		Description:  r.Description,
+		Price:        r.Price,
+		InheritPrice: r.InheritPrice,
+		Quantity:     r.Quantity,
+		WarehouseID:  r.WarehouseID,
+	}
+}
+
+// ProductFilterFromQuery reads the list filters shared by GET /products, the
+// export endpoint and the category product listing.
+func ProductFilterFromQuery(r *http.Request) (domain.ProductFilter, error) {
+	query := r.URL.Query()
+	filter := domain.ProductFilter{
+		Cursor:     query.Get("cursor"),
+		NamePrefix: query.Get("name_prefix"),
+	}
+
+	var err error
+	if value := query.Get("limit"); value != "" {
+		if filter.Limit, err = strconv.Atoi(value); err != nil {
+			return domain.ProductFilter{}, fmt.Errorf("%w: limit must be an integer", ers.ErrInvalidInput)
+		}
+	}
+	currency := strings.ToUpper(query.Get("price_currency"))
+	if currency == "" {
+		currency = domain.DefaultCurrency
+	}
+	if filter.MinPrice, err = queryMoney(r, "min_price", currency); err != nil {
+		return domain.ProductFilter{}, err
+	}
+	if filter.MaxPrice, err = queryMoney(r, "max_price", currency); err != nil {
+		return domain.ProductFilter{}, err
+	}
+	if filter.InStock, err = queryBool(r, "in_stock"); err != nil {
+		return domain.ProductFilter{}, err
+	}
+	if filter.IncludeDeleted, err = queryBool(r, "include_deleted"); err != nil {
+		return domain.ProductFilter{}, err
+	}
+	if filter.CreatedSince, err = response.QueryTime(r, "created_since"); err != nil {
+		return domain.ProductFilter{}, err
+	}
+	if filter.UpdatedSince, err = response.QueryTime(r, "updated_since"); err != nil {
+		return domain.ProductFilter{}, err
+	}
+	if filter.CategoryID, err = response.QueryID(r, "category_id"); err != nil {
+		return domain.ProductFilter{}, err
+	}
+	if filter.Attributes, err = attributeFiltersFromQuery(r); err != nil {
+		return domain.ProductFilter{}, err
+	}
+	if sort := query.Get("sort"); sort != "" {
+		filter.SortDesc = strings.HasPrefix(sort, "-")
+		filter.SortField = domain.ProductSortField(strings.TrimPrefix(sort, "-"))
+	}
+
+	return filter, nil
+}
+
+// attributeFiltersFromQuery reads attr.<code>=a,b for any of the values and
+// attr.<code>.min / attr.<code>.max for numeric ranges.
+func attributeFiltersFromQuery(r *http.Request) ([]domain.AttributeFilter, error) {
+	byCode := map[string]*domain.AttributeFilter{}
+	var codes []string
+
+	for key, values := range r.URL.Query() {
+		if !strings.HasPrefix(key, "attr.") || len(values) == 0 {
+			continue
+		}
+		code, bound, _ := strings.Cut(strings.TrimPrefix(key, "attr."), ".")
+
+		filter, ok := byCode[code]
+		if !ok {
+			filter = &domain.AttributeFilter{Code: code}
+			byCode[code] = filter
+			codes = append(codes, code)
+		}
+
+		switch bound {
+		case "":
+			for _, value := range values {
+				filter.Values = append(filter.Values, strings.Split(value, ",")...)
+			}
+		case "min", "max":
+			n, err := strconv.ParseFloat(values[0], 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s must be a number", ers.ErrInvalidInput, key)
+			}
+			if bound == "min" {
+				filter.Min = &n
+			} else {
+				filter.Max = &n
+			}
+		default:
+			return nil, fmt.Errorf("%w: unknown attribute filter %s", ers.ErrInvalidInput, key)
+		}
+	}
+
+	sort.Strings(codes)
+	filters := make([]domain.AttributeFilter, 0, len(codes))
+	for _, code := range codes {
+		filters = append(filters, *byCode[code])
+	}
+	return filters, nil
+}
+
+func queryBool(r *http.Request, name string) (bool, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return false, nil
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%w: %s must be a boolean", ers.ErrInvalidInput, name)
+	}
+	return b, nil
+}
+
+// queryMoney reads a decimal amount in major units, e.g. min_price=1500.50.
+func queryMoney(r *http.Request, name string, currency string) (*domain.Money, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	m, err := domain.ParseMoney(value, currency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s must be an amount in %s", ers.ErrInvalidInput, name, currency)
 	}
+	return &m, nil
 }
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+)
+
+func requestWithIfMatch(values ...string) *http.Request {
+	r := httptest.NewRequest(http.MethodPatch, "/product/1", nil)
+	for _, value := range values {
+		r.Header.Add("If-Match", value)
+	}
+	return r
+}
+
+func TestIfMatch_StrongTag(t *testing.T) {
+	versions, err := ifMatch(requestWithIfMatch(`"3"`))
+	if err != nil {
+		t.Fatalf("If-Match parsing failed: %s", err)
+	}
+
+	if len(versions) != 1 || versions[0] != 3 {
+		t.Errorf("expected versions [3], but got %v", versions)
+	}
+}
+
+func TestIfMatch_WeakTag(t *testing.T) {
+	_, err := ifMatch(requestWithIfMatch(`W/"3"`))
+	if !errors.Is(err, ers.ErrPreconditionFailed) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrPreconditionFailed, err)
+	}
+}
+
+func TestIfMatch_List(t *testing.T) {
+	versions, err := ifMatch(requestWithIfMatch(`"3", W/"4"`, `"5"`))
+	if err != nil {
+		t.Fatalf("If-Match parsing failed: %s", err)
+	}
+
+	if len(versions) != 2 || versions[0] != 3 || versions[1] != 5 {
+		t.Errorf("expected versions [3 5], but got %v", versions)
+	}
+}
+
+func TestIfMatch_Any(t *testing.T) {
+	versions, err := ifMatch(requestWithIfMatch("*"))
+	if err != nil || versions != nil {
+		t.Errorf("expected an unconditional write, but got %v and %v", versions, err)
+	}
+}
+
+func TestIfMatch_Malformed(t *testing.T) {
+	_, err := ifMatch(requestWithIfMatch(`"three"`))
+	if !errors.Is(err, ers.ErrInvalidInput) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrInvalidInput, err)
+	}
+}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/product/service"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/logger"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/response"
+)
+
+type ImportHandler struct {
+	products    service.ProductService
+	jobs        service.ImportJobService
+	maxFileSize int64
+	syncRows    int
+	logger      logger.Logger
+}
+
+// NewImportHandler runs imports of up to syncRows rows inside the request and
+// queues larger ones as jobs.
+func NewImportHandler(
+	products service.ProductService,
+	jobs service.ImportJobService,
+	maxFileSize int64,
+	syncRows int,
+	logger logger.Logger,
+) *ImportHandler {
+	return &ImportHandler{
+		products:    products,
+		jobs:        jobs,
+		maxFileSize: maxFileSize,
+		syncRows:    syncRows,
+		logger:      logger,
+	}
+}
+
+// Import accepts the file as the "file" field of a multipart form or as the
+// raw request body. ?async=true queues the import regardless of its size.
+func (h *ImportHandler) Import(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodPost) {
+		return
+	}
+
+	dryRun, err := queryBool(r, "dry_run")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+	async, err := queryBool(r, "async")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	data, err := h.readFile(w, r)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	rows, err := parseImportFile(data)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	if async || len(rows) > h.syncRows {
+		job, err := h.jobs.Submit(r.Context(), rows, dryRun)
+		if err != nil {
+			response.Error(w, h.logger, err)
+			return
+		}
+
+		w.Header().Set("Location", "/products/import/"+job.ID.String())
+		response.JSON(w, h.logger, http.StatusAccepted, job)
+		return
+	}
+
+	report, err := h.products.Import(r.Context(), rows, dryRun)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusOK, report)
+}
+
+func (h *ImportHandler) GetJob(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodGet) {
+		return
+	}
+
+	id, err := response.PathID(r, h.logger, "id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	job, err := h.jobs.GetById(r.Context(), id)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusOK, job)
+}
+
+func (h *ImportHandler) readFile(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxFileSize)
+
+	body := io.Reader(r.Body)
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		reader, err := r.MultipartReader()
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid multipart body", ers.ErrInvalidInput)
+		}
+		for {
+			part, err := reader.NextPart()
+			if err != nil {
+				return nil, h.readError(err, "multipart body has no file field")
+			}
+			if part.FormName() == "file" {
+				body = part
+				break
+			}
+		}
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, h.readError(err, "invalid body")
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w: import file is empty", ers.ErrInvalidInput)
+	}
+
+	return data, nil
+}
+
+func (h *ImportHandler) readError(err error, msg string) error {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return fmt.Errorf("%w: import file exceeds %d bytes", ers.ErrInvalidInput, h.maxFileSize)
+	}
+	return fmt.Errorf("%w: %s", ers.ErrInvalidInput, msg)
+}
@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/xlsx"
+)
+
+// importColumns maps accepted header names to the ImportRow field they fill.
+var importColumns = map[string]string{
+	"sku":         "sku",
+	"name":        "name",
+	"description": "description",
+	"price":       "price",
+	"currency":    "currency",
+	"quantity":    "quantity",
+	"артикул":     "sku",
+	"название":    "name",
+	"описание":    "description",
+	"цена":        "price",
+	"валюта":      "currency",
+	"количество":  "quantity",
+}
+
+// parseImportFile reads a CSV or XLSX upload; XLSX is recognised by its zip
+// signature. The first non-empty row must be the header.
+func parseImportFile(data []byte) ([]domain.ImportRow, error) {
+	var (
+		records [][]string
+		err     error
+	)
+	if bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		records, err = xlsx.ReadRows(bytes.NewReader(data), int64(len(data)))
+	} else {
+		records, err = readCSV(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ers.ErrInvalidInput, err)
+	}
+
+	return importRows(records)
+}
+
+// readCSV accepts comma or semicolon separated files (Excel writes the latter
+// in Russian locales) with an optional UTF-8 BOM.
+func readCSV(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	header, _, _ := bytes.Cut(data, []byte("\n"))
+	reader := csv.NewReader(bytes.NewReader(data))
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		reader.Comma = ';'
+	}
+	reader.FieldsPerRecord = -1
+
+	var records [][]string
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// keep record i on source line i+1 so the report points at the right line
+		line, _ := reader.FieldPos(0)
+		for len(records) < line-1 {
+			records = append(records, nil)
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func importRows(records [][]string) ([]domain.ImportRow, error) {
+	headerAt := -1
+	for i, record := range records {
+		if !blankRecord(record) {
+			headerAt = i
+			break
+		}
+	}
+	if headerAt < 0 {
+		return nil, fmt.Errorf("%w: import file is empty", ers.ErrInvalidInput)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[headerAt] {
+		field, ok := importColumns[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			continue
+		}
+		if _, dup := columns[field]; dup {
+			return nil, fmt.Errorf("%w: column %q appears twice", ers.ErrInvalidInput, field)
+		}
+		columns[field] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, fmt.Errorf("%w: import file has no name column", ers.ErrInvalidInput)
+	}
+
+	rows := []domain.ImportRow{}
+	for i := headerAt + 1; i < len(records); i++ {
+		if blankRecord(records[i]) {
+			continue
+		}
+		rows = append(rows, importRow(i+1, records[i], columns))
+	}
+
+	return rows, nil
+}
+
+func importRow(line int, record []string, columns map[string]int) domain.ImportRow {
+	cell := func(field string) string {
+		i, ok := columns[field]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	row := domain.ImportRow{
+		Line: line,
+		SKU:  cell("sku"),
+		Name: cell("name"),
+	}
+	if v := cell("description"); v != "" {
+		row.Description = &v
+	}
+
+	currency := strings.ToUpper(cell("currency"))
+	if currency != "" && !domain.ValidCurrency(currency) {
+		row.Errors = append(row.Errors, fmt.Sprintf("currency %q is not supported", currency))
+	}
+	if v := cell("price"); v != "" && len(row.Errors) == 0 {
+		price, err := parsePrice(v, currency)
+		if err != nil {
+			row.Errors = append(row.Errors, fmt.Sprintf("price %q is not an amount in major units", v))
+		}
+		price.Currency = currency
+		row.Price = &price
+	}
+	if v := cell("quantity"); v != "" {
+		quantity, err := parseWhole(v)
+		if err != nil || quantity > math.MaxInt32 {
+			row.Errors = append(row.Errors, fmt.Sprintf("quantity %q is not a whole number", v))
+		}
+		n := int(quantity)
+		row.Quantity = &n
+	}
+
+	return row
+}
+
+// parsePrice reads a price in major units such as "1500" or "1500,50". Without
+// a currency column the amount is read as the default currency; the currency
+// itself is left empty so that updates keep the product's own.
+func parsePrice(v string, currency string) (domain.Money, error) {
+	parseCurrency := currency
+	if parseCurrency == "" {
+		parseCurrency = domain.DefaultCurrency
+	}
+	return domain.ParseMoney(strings.Replace(v, ",", ".", 1), parseCurrency)
+}
+
+// parseWhole accepts integers and integral decimals such as "150.0", which is
+// how spreadsheets often store whole numbers.
+func parseWhole(v string) (int64, error) {
+	if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return n, nil
+	}
+
+	f, err := strconv.ParseFloat(strings.Replace(v, ",", ".", 1), 64)
+	if err != nil || f != math.Trunc(f) || math.Abs(f) > math.MaxInt64 {
+		return 0, errors.New("not a whole number")
+	}
+
+	return int64(f), nil
+}
+
+func blankRecord(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
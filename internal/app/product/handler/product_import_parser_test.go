@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"errors"
+	"testing"
+
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+)
+
+func TestParseImportFile_CSV(t *testing.T) {
+	data := "\xef\xbb\xbfАртикул;Name;Price;Quantity;Description\n" +
+		"KB-1;Клавиатура;1500,0;10;Механическая клавиатура\n" +
+		"\n" +
+		"MS-2;Mouse;abc;5;Wireless mouse\n"
+
+	rows, err := parseImportFile([]byte(data))
+	if err != nil {
+		t.Fatalf("parsing failed: %s", err)
+	}
+
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, but got %d", len(rows))
+	}
+	if rows[0].SKU != "KB-1" || rows[0].Name != "Клавиатура" || rows[0].Price.Amount != 150000 || *rows[0].Quantity != 10 {
+		t.Errorf("unexpected first row: %+v", rows[0])
+	}
+	if rows[1].Line != 4 || len(rows[1].Errors) != 1 {
+		t.Errorf("expected a price error on line 4, but got line %d with %v", rows[1].Line, rows[1].Errors)
+	}
+}
+
+func TestParseImportFile_NoNameColumn(t *testing.T) {
+	_, err := parseImportFile([]byte("sku,price\nKB-1,100\n"))
+	if !errors.Is(err, ers.ErrInvalidInput) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrInvalidInput, err)
+	}
+}
+
+func TestParseImportFile_PartialColumns(t *testing.T) {
+	rows, err := parseImportFile([]byte("sku,name,quantity\nKB-1,Keyboard,\n"))
+	if err != nil {
+		t.Fatalf("parsing failed: %s", err)
+	}
+
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, but got %d", len(rows))
+	}
+	if rows[0].Description != nil || rows[0].Price != nil || rows[0].Quantity != nil {
+		t.Errorf("expected missing columns and empty cells to stay unset, but got %+v", rows[0])
+	}
+}
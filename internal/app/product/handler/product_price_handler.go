@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	ps "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/price/service"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+)
+
+// ResolvePrices fills ResolvedPrice of products and their variants when the
+// request asks for a currency, optionally narrowed to a region's price list.
+func ResolvePrices(r *http.Request, prices ps.PriceListService, products []domain.Product) error {
+	currency := r.URL.Query().Get("currency")
+	region := r.URL.Query().Get("region")
+	if currency == "" {
+		if region != "" {
+			return fmt.Errorf("%w: region needs a currency", ers.ErrInvalidInput)
+		}
+		return nil
+	}
+
+	return prices.Resolve(r.Context(), products, currency, region)
+}
+
+func (h *ProductHandler) resolvePrice(r *http.Request, product *domain.Product) error {
+	products := []domain.Product{*product}
+	if err := ResolvePrices(r, h.prices, products); err != nil {
+		return err
+	}
+	*product = products[0]
+	return nil
+}
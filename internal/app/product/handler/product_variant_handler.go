@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/response"
+)
+
+func (h *ProductHandler) SetVariantAttributes(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodPut) {
+		return
+	}
+
+	id, err := response.PathID(r, h.logger, "id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	var req VariantAttributesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, h.logger, fmt.Errorf("%w: invalid body", ers.ErrInvalidInput))
+		return
+	}
+
+	product, err := h.service.SetVariantAttributes(r.Context(), id, req.Attributes)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	setETag(w, product.Version)
+	response.JSON(w, h.logger, http.StatusOK, product)
+}
+
+func (h *ProductHandler) CreateVariant(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodPost) {
+		return
+	}
+
+	id, err := response.PathID(r, h.logger, "id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	var req CreateVariantRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, h.logger, fmt.Errorf("%w: invalid body", ers.ErrInvalidInput))
+		return
+	}
+
+	variant, err := h.service.CreateVariant(r.Context(), id, req.ToDomain())
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	setETag(w, variant.Version)
+	response.JSON(w, h.logger, http.StatusCreated, variant)
+}
+
+func (h *ProductHandler) GenerateVariants(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodPost) {
+		return
+	}
+
+	id, err := response.PathID(r, h.logger, "id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	variants, err := h.service.GenerateVariants(r.Context(), id)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusCreated, VariantsResponse{Items: variants})
+}
@@ -0,0 +1,42 @@
+package handler
+
+import "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+
+type VariantAttributesRequest struct {
+	Attributes []domain.VariantAttribute `json:"attributes"`
+}
+
+// CreateVariantRequest describes one variant. Without a price the variant
+// inherits the parent's; name and description default to the parent's.
+type CreateVariantRequest struct {
+	Values      map[string]string `json:"values"`
+	SKU         string            `json:"sku"`
+	Barcodes    []string          `json:"barcodes"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Price       *domain.Money     `json:"price"`
+	Quantity    int               `json:"quantity"`
+}
+
+func (r *CreateVariantRequest) ToDomain() domain.Product {
+	variant := domain.Product{
+		VariantValues: r.Values,
+		SKU:           r.SKU,
+		Name:          r.Name,
+		Description:   r.Description,
+		Quantity:      r.Quantity,
+		InheritsPrice: r.Price == nil,
+	}
+	if r.Price != nil {
+		variant.Price = *r.Price
+	}
+	for _, code := range r.Barcodes {
+		variant.Barcodes = append(variant.Barcodes, domain.Barcode{Code: code})
+	}
+
+	return variant
+}
+
+type VariantsResponse struct {
+	Items []domain.Product `json:"items"`
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	core "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/repository"
+)
+
+type PostgresImportJobRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresImportJobRepository(db *sql.DB) *PostgresImportJobRepository {
+	return &PostgresImportJobRepository{
+		db: db,
+	}
+}
+
+func (i *PostgresImportJobRepository) Create(ctx context.Context, job *domain.ImportJob) error {
+	rows, err := json.Marshal(job.Rows)
+	if err != nil {
+		return fmt.Errorf("error encoding import rows: %w", err)
+	}
+
+	query := `
+		INSERT INTO import_jobs (id, status, dry_run, total_rows, actor, rows, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	if _, err := core.Conn(ctx, i.db).ExecContext(
+		ctx,
+		query,
+		job.ID,
+		job.Status,
+		job.DryRun,
+		job.TotalRows,
+		job.Actor,
+		rows,
+		job.CreatedAt,
+	); err != nil {
+		return fmt.Errorf("error inserting import job: %w", err)
+	}
+
+	return nil
+}
+
+func (i *PostgresImportJobRepository) GetById(ctx context.Context, id uuid.UUID) (domain.ImportJob, error) {
+	var (
+		job    domain.ImportJob
+		report []byte
+	)
+
+	query := `
+		SELECT id, status, dry_run, total_rows, actor, report, error, created_at, started_at, finished_at
+		FROM import_jobs
+		WHERE id = $1
+	`
+
+	if err := core.Conn(ctx, i.db).QueryRowContext(ctx, query, id).Scan(
+		&job.ID,
+		&job.Status,
+		&job.DryRun,
+		&job.TotalRows,
+		&job.Actor,
+		&report,
+		&job.Error,
+		&job.CreatedAt,
+		&job.StartedAt,
+		&job.FinishedAt,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ImportJob{}, fmt.Errorf("%w: id %s", ers.ErrImportJobNotFound, id)
+		}
+		return domain.ImportJob{}, fmt.Errorf("error getting import job: %w", err)
+	}
+
+	if len(report) > 0 {
+		job.Report = &domain.ImportReport{}
+		if err := json.Unmarshal(report, job.Report); err != nil {
+			return domain.ImportJob{}, fmt.Errorf("error decoding import report: %w", err)
+		}
+	}
+
+	return job, nil
+}
+
+// ClaimNext marks the oldest queued job as running and returns it with its
+// rows, or nil when the queue is empty. SKIP LOCKED lets several workers poll.
+func (i *PostgresImportJobRepository) ClaimNext(ctx context.Context) (*domain.ImportJob, error) {
+	var (
+		job  domain.ImportJob
+		rows []byte
+	)
+
+	query := `
+		UPDATE import_jobs
+		SET status = 'running', started_at = NOW()
+		WHERE id = (
+			SELECT id FROM import_jobs
+			WHERE status = 'queued'
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, status, dry_run, total_rows, actor, rows, created_at, started_at
+	`
+
+	if err := core.Conn(ctx, i.db).QueryRowContext(ctx, query).Scan(
+		&job.ID,
+		&job.Status,
+		&job.DryRun,
+		&job.TotalRows,
+		&job.Actor,
+		&rows,
+		&job.CreatedAt,
+		&job.StartedAt,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error claiming import job: %w", err)
+	}
+
+	if err := json.Unmarshal(rows, &job.Rows); err != nil {
+		return nil, fmt.Errorf("error decoding import rows: %w", err)
+	}
+
+	return &job, nil
+}
+
+// Finish stores the outcome and drops the rows, which are no longer needed.
+func (i *PostgresImportJobRepository) Finish(ctx context.Context, job *domain.ImportJob) error {
+	var report []byte
+	if job.Report != nil {
+		var err error
+		if report, err = json.Marshal(job.Report); err != nil {
+			return fmt.Errorf("error encoding import report: %w", err)
+		}
+	}
+
+	query := `
+		UPDATE import_jobs
+		SET status = $2, report = $3, error = $4, finished_at = $5, rows = '[]'
+		WHERE id = $1
+	`
+
+	if _, err := core.Conn(ctx, i.db).ExecContext(
+		ctx,
+		query,
+		job.ID,
+		job.Status,
+		report,
+		job.Error,
+		job.FinishedAt,
+	); err != nil {
+		return fmt.Errorf("error finishing import job: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+type ImportJobRepository interface {
+	Create(ctx context.Context, job *domain.ImportJob) error
+	GetById(ctx context.Context, id uuid.UUID) (domain.ImportJob, error)
+	ClaimNext(ctx context.Context) (*domain.ImportJob, error)
+	Finish(ctx context.Context, job *domain.ImportJob) error
+}
@@ -3,14 +3,67 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
 	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	core "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/repository"
+	"github.com/lib/pq"
 )
 
+const uniqueViolation = "23505"
+
+// reservedSQL sums active, not yet expired holds on a product row aliased as p.
+const reservedSQL = `
+	COALESCE((
+		SELECT SUM(r.quantity)
+		FROM reservations r
+		WHERE r.product_id = p.id AND r.status = 'active' AND r.expires_at > NOW()
+	), 0)
+`
+
+// barcodesSQL collects the barcodes of a product row aliased as p.
+const barcodesSQL = `
+	ARRAY(SELECT b.code FROM product_barcodes b WHERE b.product_id = p.id ORDER BY b.code)
+`
+
+// priceSQL resolves the price and currency of a product row aliased as p;
+// variants without a price of their own inherit both from the parent.
+const priceSQL = `
+	COALESCE(p.price_minor, (SELECT pp.price_minor FROM products pp WHERE pp.id = p.parent_id), 0),
+	CASE WHEN p.price_minor IS NULL AND p.parent_id IS NOT NULL
+		THEN (SELECT pp.currency FROM products pp WHERE pp.id = p.parent_id)
+		ELSE p.currency
+	END,
+	p.price_minor IS NULL
+`
+
+const productColumns = `
+	p.id, p.parent_id, p.variant_values, COALESCE(p.sku, ''), ` + barcodesSQL + `, p.name, p.description, ` + priceSQL + `, p.quantity, ` + reservedSQL + `, p.version, p.created_at, p.updated_at, p.deleted_at
+`
+
+// sortColumns maps the public sort keys to the keyset column and the cast applied to the cursor value.
+var sortColumns = map[domain.ProductSortField]struct {
+	column string
+	cast   string
+}{
+	domain.SortByName:      {"p.name", "text"},
+	domain.SortByPrice:     {"p.price_minor", "bigint"},
+	domain.SortByQuantity:  {"p.quantity", "integer"},
+	domain.SortByUpdatedAt: {"p.updated_at", "timestamptz"},
+}
+
+const headlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2"
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
 type PostgresProductRepository struct {
 	db *sql.DB
 }
@@ -26,23 +79,40 @@ func (i *PostgresProductRepository) Create(
 	p *domain.Product,
 ) (domain.Product, error) {
 	query := `
-		INSERT INTO products (name, description, price, quantity, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING id
+		INSERT INTO products (name, description, price_minor, currency, quantity, created_at, updated_at, sku, parent_id, variant_values)
+		VALUES ($1, $2, CASE WHEN $10 THEN NULL ELSE $3::bigint END, $11, $4, $5, $6, NULLIF($7, ''), $8, $9)
+		RETURNING id, version
 	`
 
-	if err := i.db.QueryRowContext(
+	variantValues, err := marshalVariantValues(p.VariantValues)
+	if err != nil {
+		return domain.Product{}, err
+	}
+
+	if err := core.Conn(ctx, i.db).QueryRowContext(
 		ctx,
 		query,
 		p.Name,
 		p.Description,
-		p.Price,
+		p.Price.Amount,
 		p.Quantity,
 		p.CreatedAt,
 		p.UpdatedAt,
-	).Scan(&p.ID); err != nil {
+		p.SKU,
+		p.ParentID,
+		variantValues,
+		p.InheritsPrice,
+		p.Price.Currency,
+	).Scan(&p.ID, &p.Version); err != nil {
+		if isVariantViolation(err) {
+			return domain.Product{}, fmt.Errorf("%w: the parent already has a variant with these values", ers.ErrVariantExists)
+		}
+		if isUniqueViolation(err) {
+			return domain.Product{}, fmt.Errorf("%w: sku %q is taken", ers.ErrSKUExists, p.SKU)
+		}
 		return domain.Product{}, fmt.Errorf("error inserting product: %w", err)
 	}
+	p.Available = p.Quantity
 
 	return *p, nil
 }
@@ -50,24 +120,16 @@ func (i *PostgresProductRepository) Create(
 func (i *PostgresProductRepository) GetById(
 	ctx context.Context,
 	id uuid.UUID,
+	includeDeleted bool,
 ) (domain.Product, error) {
-	var product domain.Product
-
 	query := `
-		SELECT id, name, description, price, quantity, created_at, updated_at
-		FROM products
-		WHERE id = $1
+		SELECT ` + productColumns + `
+		FROM products p
+		WHERE p.id = $1 AND ($2 OR p.deleted_at IS NULL)
 	`
 
-	if err := i.db.QueryRowContext(ctx, query, id).Scan(
-		&product.ID,
-		&product.Name,
-		&product.Description,
-		&product.Price,
-		&product.Quantity,
-		&product.CreatedAt,
-		&product.UpdatedAt,
-	); err != nil {
+	product, err := scanProduct(core.Conn(ctx, i.db).QueryRowContext(ctx, query, id, includeDeleted))
+	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return domain.Product{}, fmt.Errorf("%w: product not found", ers.ErrProductNotFound)
 		}
@@ -77,19 +139,209 @@ func (i *PostgresProductRepository) GetById(
 	return product, nil
 }
 
+func (i *PostgresProductRepository) GetBySKU(ctx context.Context, sku string) (domain.Product, error) {
+	query := `
+		SELECT ` + productColumns + `
+		FROM products p
+		WHERE p.sku = $1 AND p.deleted_at IS NULL
+	`
+
+	product, err := scanProduct(core.Conn(ctx, i.db).QueryRowContext(ctx, query, sku))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Product{}, fmt.Errorf("%w: no product with sku %q", ers.ErrProductNotFound, sku)
+		}
+		return domain.Product{}, err
+	}
+
+	return product, nil
+}
+
+// GetByBarcode finds the live product carrying the barcode, given in its GTIN-14 form.
+func (i *PostgresProductRepository) GetByBarcode(ctx context.Context, gtin string) (domain.Product, error) {
+	query := `
+		SELECT ` + productColumns + `
+		FROM product_barcodes b
+		JOIN products p ON p.id = b.product_id
+		WHERE b.gtin = $1 AND p.deleted_at IS NULL
+	`
+
+	product, err := scanProduct(core.Conn(ctx, i.db).QueryRowContext(ctx, query, gtin))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Product{}, fmt.Errorf("%w: no product with barcode %s", ers.ErrProductNotFound, gtin)
+		}
+		return domain.Product{}, err
+	}
+
+	return product, nil
+}
+
+// SetBarcodes replaces the barcodes of a product.
+func (i *PostgresProductRepository) SetBarcodes(
+	ctx context.Context,
+	productID uuid.UUID,
+	barcodes []domain.Barcode,
+) error {
+	conn := core.Conn(ctx, i.db)
+
+	if _, err := conn.ExecContext(ctx, `DELETE FROM product_barcodes WHERE product_id = $1`, productID); err != nil {
+		return fmt.Errorf("error clearing barcodes: %w", err)
+	}
+
+	for _, barcode := range barcodes {
+		query := `
+			INSERT INTO product_barcodes (gtin, code, product_id)
+			VALUES ($1, $2, $3)
+		`
+
+		if _, err := conn.ExecContext(ctx, query, barcode.GTIN14(), barcode.Code, productID); err != nil {
+			if isUniqueViolation(err) {
+				return fmt.Errorf("%w: barcode %s belongs to another product", ers.ErrBarcodeExists, barcode.Code)
+			}
+			return fmt.Errorf("error inserting barcode: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetAll returns one keyset page. The caller asks for Limit rows; the service requests one extra to detect the next page.
 func (i *PostgresProductRepository) GetAll(
 	ctx context.Context,
+	filter domain.ProductFilter,
 ) ([]domain.Product, error) {
-	var products []domain.Product
+	products := []domain.Product{}
+
+	err := i.Iterate(ctx, filter, func(product domain.Product) error {
+		products = append(products, product)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+// Iterate calls fn for every product matching the filter in sort order, reading
+// rows off the result cursor one at a time. A zero Limit means no limit.
+func (i *PostgresProductRepository) Iterate(
+	ctx context.Context,
+	filter domain.ProductFilter,
+	fn func(domain.Product) error,
+) error {
+	where, args := productConditions(filter)
+
+	sort, ok := sortColumns[filter.SortField]
+	if !ok {
+		sort = sortColumns[domain.SortByName]
+	}
+	direction, comparison := "ASC", ">"
+	if filter.SortDesc {
+		direction, comparison = "DESC", "<"
+	}
+
+	if filter.After != nil {
+		args = append(args, filter.After.Value, filter.After.ID)
+		where = append(where, fmt.Sprintf(
+			"(%s, p.id) %s ($%d::%s, $%d)",
+			sort.column, comparison, len(args)-1, sort.cast, len(args),
+		))
+	}
 
 	query := `
-		SELECT id, name, description, price, quantity, created_at, updated_at
-		FROM products
+		SELECT ` + productColumns + `
+		FROM products p
+		WHERE ` + strings.Join(where, " AND ")
+
+	query += fmt.Sprintf(" ORDER BY %s %s, p.id %s", sort.column, direction, direction)
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	rows, err := core.Conn(ctx, i.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer func(rows *sql.Rows) {
+		if err := rows.Close(); err != nil {
+		}
+	}(rows)
+
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(product); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return nil
+}
+
+// Search ranks products by full-text match over both the Russian and the English vectors.
+func (i *PostgresProductRepository) Search(
+	ctx context.Context,
+	q string,
+	limit int,
+) ([]domain.ProductSearchResult, error) {
+	query := `
+		WITH q AS (
+			SELECT websearch_to_tsquery('russian', $1) || websearch_to_tsquery('english', $1) AS query
+		)
+		SELECT ` + productColumns + `,
+		       ts_rank_cd(p.search_vector, q.query) AS rank,
+		       ts_headline('russian', p.name, q.query, '` + headlineOptions + `'),
+		       ts_headline('russian', coalesce(p.description, ''), q.query, '` + headlineOptions + `')
+		FROM products p, q
+		WHERE p.search_vector @@ q.query AND p.deleted_at IS NULL AND p.parent_id IS NULL
+		ORDER BY rank DESC, p.id
+		LIMIT $2
 	`
 
-	rows, err := i.db.QueryContext(ctx, query)
+	return i.search(ctx, query, domain.MatchFullText, q, limit)
+}
+
+// SearchFuzzy is the typo-tolerant fallback: trigram similarity against the name.
+func (i *PostgresProductRepository) SearchFuzzy(
+	ctx context.Context,
+	q string,
+	limit int,
+) ([]domain.ProductSearchResult, error) {
+	query := `
+		SELECT ` + productColumns + `,
+		       similarity(p.name, $1) AS rank,
+		       p.name,
+		       left(coalesce(p.description, ''), 200)
+		FROM products p
+		WHERE p.name % $1 AND p.deleted_at IS NULL AND p.parent_id IS NULL
+		ORDER BY rank DESC, p.id
+		LIMIT $2
+	`
+
+	return i.search(ctx, query, domain.MatchTrigram, q, limit)
+}
+
+func (i *PostgresProductRepository) search(
+	ctx context.Context,
+	query string,
+	match domain.SearchMatch,
+	args ...interface{},
+) ([]domain.ProductSearchResult, error) {
+	results := []domain.ProductSearchResult{}
+
+	rows, err := core.Conn(ctx, i.db).QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("error searching products: %w", err)
 	}
 	defer func(rows *sql.Rows) {
 		if err := rows.Close(); err != nil {
@@ -97,28 +349,22 @@ func (i *PostgresProductRepository) GetAll(
 	}(rows)
 
 	for rows.Next() {
-		var product domain.Product
+		result := domain.ProductSearchResult{Match: match}
 
-		if err := rows.Scan(
-			&product.ID,
-			&product.Name,
-			&product.Description,
-			&product.Price,
-			&product.Quantity,
-			&product.CreatedAt,
-			&product.UpdatedAt,
-		); err != nil {
-			return products, err
+		product, err := scanProduct(rows, &result.Rank, &result.NameHighlight, &result.DescriptionHighlight)
+		if err != nil {
+			return results, err
 		}
+		result.Product = product
 
-		products = append(products, product)
+		results = append(results, result)
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("error during rows iteration: %w", err)
 	}
 
-	return products, nil
+	return results, nil
 }
 
 func (i *PostgresProductRepository) Update(
@@ -127,30 +373,25 @@ func (i *PostgresProductRepository) Update(
 	p domain.Product,
 ) (domain.Product, error) {
 	query := `
-       UPDATE products
-       SET name = $1, description = $2, price = $3, quantity = $4, updated_at = $5
-       WHERE id = $6
-       RETURNING id, name, description, price, quantity, created_at, updated_at
+       UPDATE products p
+       SET name = $1, description = $2, price_minor = CASE WHEN $8 THEN NULL ELSE $3::bigint END,
+           currency = $9, updated_at = $4, sku = NULLIF($7, ''), version = version + 1
+       WHERE p.id = $5 AND p.version = $6 AND p.deleted_at IS NULL
+       RETURNING ` + productColumns + `
     `
 
-	var updatedProduct domain.Product
-	err := i.db.QueryRowContext(
+	updatedProduct, err := scanProduct(core.Conn(ctx, i.db).QueryRowContext(
 		ctx,
 		query,
-		p.Name, p.Description, p.Price, p.Quantity, p.UpdatedAt, id,
-	).Scan(
-		&updatedProduct.ID,
-		&updatedProduct.Name,
-		&updatedProduct.Description,
-		&updatedProduct.Price,
-		&updatedProduct.Quantity,
-		&updatedProduct.CreatedAt,
-		&updatedProduct.UpdatedAt,
-	)
+		p.Name, p.Description, p.Price.Amount, p.UpdatedAt, id, p.Version, p.SKU, p.InheritsPrice, p.Price.Currency,
+	))
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return domain.Product{}, fmt.Errorf("%w: not found error", ers.ErrProductNotFound)
+			return domain.Product{}, i.missingOrConflict(ctx, id)
+		}
+		if isUniqueViolation(err) {
+			return domain.Product{}, fmt.Errorf("%w: sku %q is taken", ers.ErrSKUExists, p.SKU)
 		}
 		return domain.Product{}, fmt.Errorf("error updating product: %w", err)
 	}
@@ -158,23 +399,256 @@ func (i *PostgresProductRepository) Update(
 	return updatedProduct, nil
 }
 
+// InTransitForUpdate locks the product row and sums its quantity on shipped,
+// not yet received transfers. The lock makes a concurrent shipment either
+// finish first and be counted or wait until the product is deleted and fail.
+func (i *PostgresProductRepository) InTransitForUpdate(ctx context.Context, id uuid.UUID) (int, error) {
+	conn := core.Conn(ctx, i.db)
+
+	var locked uuid.UUID
+	if err := conn.QueryRowContext(
+		ctx,
+		`SELECT id FROM products WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`,
+		id,
+	).Scan(&locked); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("%w: not found error", ers.ErrProductNotFound)
+		}
+		return 0, err
+	}
+
+	query := `
+		SELECT COALESCE(SUM(l.quantity), 0)
+		FROM transfer_lines l
+		JOIN transfers t ON t.id = l.transfer_id
+		WHERE l.product_id = $1 AND t.status = 'shipped'
+	`
+
+	var quantity int
+	if err := conn.QueryRowContext(ctx, query, id).Scan(&quantity); err != nil {
+		return 0, fmt.Errorf("error summing in-transit stock: %w", err)
+	}
+
+	return quantity, nil
+}
+
+// Delete marks the product as deleted; the row is removed later by Purge.
 func (i *PostgresProductRepository) Delete(
 	ctx context.Context,
 	id uuid.UUID,
+	version int64,
+	deletedAt time.Time,
 ) error {
 	query := `
-		DELETE FROM products
-		WHERE id = $1
+		UPDATE products
+		SET deleted_at = $3, updated_at = $3, version = version + 1
+		WHERE id = $1 AND ($2 = 0 OR version = $2) AND deleted_at IS NULL
 	`
 
-	result, err := i.db.ExecContext(ctx, query, id)
+	result, err := core.Conn(ctx, i.db).ExecContext(ctx, query, id, version, deletedAt)
 	if err != nil {
 		return err
 	}
-	_, err = result.RowsAffected()
+	rows, err := result.RowsAffected()
 	if err != nil {
 		return err
 	}
+	if rows == 0 {
+		return i.missingOrConflict(ctx, id)
+	}
 
 	return nil
 }
+
+func (i *PostgresProductRepository) Restore(
+	ctx context.Context,
+	id uuid.UUID,
+	restoredAt time.Time,
+) (domain.Product, error) {
+	query := `
+		UPDATE products p
+		SET deleted_at = NULL, updated_at = $2, version = version + 1
+		WHERE p.id = $1 AND p.deleted_at IS NOT NULL
+		RETURNING ` + productColumns + `
+	`
+
+	product, err := scanProduct(core.Conn(ctx, i.db).QueryRowContext(ctx, query, id, restoredAt))
+	if err != nil {
+		if isVariantViolation(err) {
+			return domain.Product{}, fmt.Errorf("%w: another variant took its values in the meantime", ers.ErrVariantExists)
+		}
+		if isUniqueViolation(err) {
+			return domain.Product{}, fmt.Errorf("%w: another product took its sku in the meantime", ers.ErrSKUExists)
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return domain.Product{}, fmt.Errorf("error restoring product: %w", err)
+		}
+		if _, err := i.GetById(ctx, id, false); err != nil {
+			return domain.Product{}, err
+		}
+		return domain.Product{}, fmt.Errorf("%w: product is not deleted", ers.ErrProductNotDeleted)
+	}
+
+	return product, nil
+}
+
+// Purge hard-deletes products soft-deleted before the given time. Products
+// referenced by transfer documents or the stock ledger are kept so those stay
+// complete; a parent goes once all of its variants are gone.
+func (i *PostgresProductRepository) Purge(
+	ctx context.Context,
+	deletedBefore time.Time,
+) (int64, error) {
+	query := `
+		DELETE FROM products p
+		WHERE p.deleted_at < $1
+		  AND NOT EXISTS (SELECT 1 FROM transfer_lines l WHERE l.product_id = p.id)
+		  AND NOT EXISTS (SELECT 1 FROM stock_movements m WHERE m.product_id = p.id)
+		  AND NOT EXISTS (SELECT 1 FROM products v WHERE v.parent_id = p.id)
+	`
+
+	result, err := core.Conn(ctx, i.db).ExecContext(ctx, query, deletedBefore)
+	if err != nil {
+		return 0, fmt.Errorf("error purging products: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// missingOrConflict explains why a version-guarded write matched no rows.
+func (i *PostgresProductRepository) missingOrConflict(ctx context.Context, id uuid.UUID) error {
+	var exists bool
+	if err := core.Conn(ctx, i.db).QueryRowContext(
+		ctx,
+		`SELECT EXISTS (SELECT 1 FROM products WHERE id = $1 AND deleted_at IS NULL)`,
+		id,
+	).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%w: not found error", ers.ErrProductNotFound)
+	}
+	return fmt.Errorf("%w: product was modified concurrently", ers.ErrVersionConflict)
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolation
+}
+
+// scanProduct reads productColumns followed by any extra columns of the query.
+func scanProduct(row rowScanner, extra ...interface{}) (domain.Product, error) {
+	var (
+		product       domain.Product
+		parentID      uuid.NullUUID
+		variantValues []byte
+		barcodes      pq.StringArray
+	)
+
+	dest := []interface{}{
+		&product.ID,
+		&parentID,
+		&variantValues,
+		&product.SKU,
+		&barcodes,
+		&product.Name,
+		&product.Description,
+		&product.Price.Amount,
+		&product.Price.Currency,
+		&product.InheritsPrice,
+		&product.Quantity,
+		&product.Reserved,
+		&product.Version,
+		&product.CreatedAt,
+		&product.UpdatedAt,
+		&product.DeletedAt,
+	}
+
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return domain.Product{}, err
+	}
+	product.Available = product.Quantity - product.Reserved
+	if parentID.Valid {
+		product.ParentID = &parentID.UUID
+	}
+	if variantValues != nil {
+		if err := json.Unmarshal(variantValues, &product.VariantValues); err != nil {
+			return domain.Product{}, fmt.Errorf("error decoding variant values: %w", err)
+		}
+	}
+	for _, code := range barcodes {
+		product.Barcodes = append(product.Barcodes, domain.Barcode{Code: code, Type: domain.BarcodeTypeOf(code)})
+	}
+
+	return product, nil
+}
+
+// productConditions turns the list filters into WHERE clauses with positional arguments.
+func productConditions(filter domain.ProductFilter) ([]string, []interface{}) {
+	// variants are listed under their parent, not on their own
+	where := []string{"p.parent_id IS NULL"}
+	var args []interface{}
+
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		where = append(where, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.NamePrefix != "" {
+		escaped := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(filter.NamePrefix)
+		add("lower(p.name) LIKE lower($%d)", escaped+"%")
+	}
+	if filter.MinPrice != nil {
+		add("p.price_minor >= $%d", filter.MinPrice.Amount)
+		add("p.currency = $%d", filter.MinPrice.Currency)
+	}
+	if filter.MaxPrice != nil {
+		add("p.price_minor <= $%d", filter.MaxPrice.Amount)
+		add("p.currency = $%d", filter.MaxPrice.Currency)
+	}
+	if filter.CreatedSince != nil {
+		add("p.created_at >= $%d", *filter.CreatedSince)
+	}
+	if filter.UpdatedSince != nil {
+		add("p.updated_at >= $%d", *filter.UpdatedSince)
+	}
+	if filter.CategoryID != nil {
+		add(`EXISTS (
+			SELECT 1
+			FROM product_categories pc
+			JOIN categories c ON c.id = pc.category_id
+			JOIN categories root ON c.path LIKE root.path || '%%'
+			WHERE pc.product_id = p.id AND root.id = $%d
+		)`, *filter.CategoryID)
+	}
+	for _, attribute := range filter.Attributes {
+		args = append(args, attribute.Code)
+		conditions := []string{fmt.Sprintf("a.code = $%d", len(args))}
+		if len(attribute.Values) > 0 {
+			args = append(args, pq.StringArray(attribute.Values))
+			conditions = append(conditions, fmt.Sprintf("v.value_text = ANY($%d)", len(args)))
+		}
+		if attribute.Min != nil {
+			args = append(args, *attribute.Min)
+			conditions = append(conditions, fmt.Sprintf("v.value_number >= $%d", len(args)))
+		}
+		if attribute.Max != nil {
+			args = append(args, *attribute.Max)
+			conditions = append(conditions, fmt.Sprintf("v.value_number <= $%d", len(args)))
+		}
+		where = append(where, `EXISTS (
+			SELECT 1
+			FROM product_attribute_values v
+			JOIN category_attributes a ON a.id = v.attribute_id
+			WHERE v.product_id = p.id AND `+strings.Join(conditions, " AND ")+`
+		)`)
+	}
+	if !filter.IncludeDeleted {
+		where = append(where, "p.deleted_at IS NULL")
+	}
+	if filter.InStock {
+		where = append(where, "p.quantity - "+reservedSQL+" > 0")
+	}
+
+	return where, args
+}
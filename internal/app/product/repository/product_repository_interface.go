@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
@@ -9,8 +10,20 @@ import (
 
 type ProductRepository interface {
 	Create(ctx context.Context, p *domain.Product) (domain.Product, error)
-	GetById(ctx context.Context, id uuid.UUID) (domain.Product, error)
-	GetAll(ctx context.Context) ([]domain.Product, error)
+	GetById(ctx context.Context, id uuid.UUID, includeDeleted bool) (domain.Product, error)
+	GetBySKU(ctx context.Context, sku string) (domain.Product, error)
+	GetByBarcode(ctx context.Context, gtin string) (domain.Product, error)
+	SetBarcodes(ctx context.Context, productID uuid.UUID, barcodes []domain.Barcode) error
+	GetVariants(ctx context.Context, parentID uuid.UUID) ([]domain.Product, error)
+	GetVariantAttributes(ctx context.Context, productID uuid.UUID) ([]domain.VariantAttribute, error)
+	SetVariantAttributes(ctx context.Context, productID uuid.UUID, attributes []domain.VariantAttribute) error
+	GetAll(ctx context.Context, filter domain.ProductFilter) ([]domain.Product, error)
+	Iterate(ctx context.Context, filter domain.ProductFilter, fn func(domain.Product) error) error
+	Search(ctx context.Context, q string, limit int) ([]domain.ProductSearchResult, error)
+	SearchFuzzy(ctx context.Context, q string, limit int) ([]domain.ProductSearchResult, error)
 	Update(ctx context.Context, id uuid.UUID, p domain.Product) (domain.Product, error)
-	Delete(ctx context.Context, id uuid.UUID) error
+	InTransitForUpdate(ctx context.Context, id uuid.UUID) (int, error)
+	Delete(ctx context.Context, id uuid.UUID, version int64, deletedAt time.Time) error
+	Restore(ctx context.Context, id uuid.UUID, restoredAt time.Time) (domain.Product, error)
+	Purge(ctx context.Context, deletedBefore time.Time) (int64, error)
 }
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	core "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/repository"
+	"github.com/lib/pq"
+)
+
+const variantValuesIndex = "idx_products_variant_values"
+
+// GetVariants returns the live variants of a parent in creation order.
+func (i *PostgresProductRepository) GetVariants(ctx context.Context, parentID uuid.UUID) ([]domain.Product, error) {
+	query := `
+		SELECT ` + productColumns + `
+		FROM products p
+		WHERE p.parent_id = $1 AND p.deleted_at IS NULL
+		ORDER BY p.created_at, p.id
+	`
+
+	rows, err := core.Conn(ctx, i.db).QueryContext(ctx, query, parentID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting variants: %w", err)
+	}
+	defer func(rows *sql.Rows) {
+		if err := rows.Close(); err != nil {
+		}
+	}(rows)
+
+	variants := []domain.Product{}
+	for rows.Next() {
+		variant, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+		variants = append(variants, variant)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return variants, nil
+}
+
+func (i *PostgresProductRepository) GetVariantAttributes(
+	ctx context.Context,
+	productID uuid.UUID,
+) ([]domain.VariantAttribute, error) {
+	query := `
+		SELECT name, attribute_values
+		FROM product_variant_attributes
+		WHERE product_id = $1
+		ORDER BY position
+	`
+
+	rows, err := core.Conn(ctx, i.db).QueryContext(ctx, query, productID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting variant attributes: %w", err)
+	}
+	defer func(rows *sql.Rows) {
+		if err := rows.Close(); err != nil {
+		}
+	}(rows)
+
+	attributes := []domain.VariantAttribute{}
+	for rows.Next() {
+		var attribute domain.VariantAttribute
+		if err := rows.Scan(&attribute.Name, (*pq.StringArray)(&attribute.Values)); err != nil {
+			return nil, err
+		}
+		attributes = append(attributes, attribute)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return attributes, nil
+}
+
+// SetVariantAttributes replaces the variant attributes of a product, keeping their order.
+func (i *PostgresProductRepository) SetVariantAttributes(
+	ctx context.Context,
+	productID uuid.UUID,
+	attributes []domain.VariantAttribute,
+) error {
+	conn := core.Conn(ctx, i.db)
+
+	if _, err := conn.ExecContext(ctx, `DELETE FROM product_variant_attributes WHERE product_id = $1`, productID); err != nil {
+		return fmt.Errorf("error clearing variant attributes: %w", err)
+	}
+
+	for position, attribute := range attributes {
+		query := `
+			INSERT INTO product_variant_attributes (product_id, name, position, attribute_values)
+			VALUES ($1, $2, $3, $4)
+		`
+
+		if _, err := conn.ExecContext(
+			ctx,
+			query,
+			productID,
+			attribute.Name,
+			position,
+			pq.StringArray(attribute.Values),
+		); err != nil {
+			return fmt.Errorf("error inserting variant attribute: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// marshalVariantValues encodes the JSONB argument, an untyped nil for products
+// that are not variants so the column stays NULL.
+func marshalVariantValues(values map[string]string) (interface{}, error) {
+	if values == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding variant values: %w", err)
+	}
+	return data, nil
+}
+
+func isVariantViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolation && pqErr.Constraint == variantValuesIndex
+}
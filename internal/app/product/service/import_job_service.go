@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/product/repository"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/auth"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+)
+
+type importJobService struct {
+	repo     repository.ImportJobRepository
+	products ProductService
+}
+
+func NewImportJobService(repo repository.ImportJobRepository, products ProductService) ImportJobService {
+	return &importJobService{
+		repo:     repo,
+		products: products,
+	}
+}
+
+// Submit queues the rows for the import worker. The caller becomes the actor
+// recorded in the audit trail for every product the job touches.
+func (i *importJobService) Submit(ctx context.Context, rows []domain.ImportRow, dryRun bool) (domain.ImportJob, error) {
+	if len(rows) == 0 {
+		return domain.ImportJob{}, fmt.Errorf("%w: import file has no rows", ers.ErrInvalidInput)
+	}
+	if len(rows) > MaxImportRows {
+		return domain.ImportJob{}, fmt.Errorf("%w: import is limited to %d rows", ers.ErrInvalidInput, MaxImportRows)
+	}
+
+	job := domain.ImportJob{
+		ID:        uuid.New(),
+		Status:    domain.ImportQueued,
+		DryRun:    dryRun,
+		TotalRows: len(rows),
+		Actor:     auth.ActorFrom(ctx).ID,
+		Rows:      rows,
+		CreatedAt: time.Now(),
+	}
+
+	if err := i.repo.Create(ctx, &job); err != nil {
+		return domain.ImportJob{}, err
+	}
+
+	job.Rows = nil
+	return job, nil
+}
+
+func (i *importJobService) GetById(ctx context.Context, id uuid.UUID) (domain.ImportJob, error) {
+	if id == uuid.Nil {
+		return domain.ImportJob{}, errors.New("invalid import job id")
+	}
+
+	return i.repo.GetById(ctx, id)
+}
+
+// RunNext processes the oldest queued job and reports whether there was one.
+func (i *importJobService) RunNext(ctx context.Context) (bool, error) {
+	job, err := i.repo.ClaimNext(ctx)
+	if err != nil || job == nil {
+		return false, err
+	}
+
+	report, err := i.products.Import(auth.WithActor(ctx, auth.Actor{ID: job.Actor}), job.Rows, job.DryRun)
+	if err != nil {
+		job.Status = domain.ImportFailed
+		job.Error = err.Error()
+	} else {
+		job.Status = domain.ImportDone
+		job.Report = &report
+	}
+	finishedAt := time.Now()
+	job.FinishedAt = &finishedAt
+
+	// the job must not stay "running" just because the worker is shutting down
+	if err := i.repo.Finish(context.WithoutCancel(ctx), job); err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+type ImportJobService interface {
+	Submit(ctx context.Context, rows []domain.ImportRow, dryRun bool) (domain.ImportJob, error)
+	GetById(ctx context.Context, id uuid.UUID) (domain.ImportJob, error)
+	RunNext(ctx context.Context) (bool, error)
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/logger"
+)
+
+type ImportWorker struct {
+	service  ImportJobService
+	interval time.Duration
+	logger   logger.Logger
+}
+
+func NewImportWorker(service ImportJobService, interval time.Duration, logger logger.Logger) *ImportWorker {
+	return &ImportWorker{
+		service:  service,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+// Run drains the import queue every interval until ctx is cancelled.
+func (w *ImportWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for ctx.Err() == nil {
+				ran, err := w.service.RunNext(ctx)
+				if err != nil {
+					w.logger.Error("failed to run import job: %v", err)
+					break
+				}
+				if !ran {
+					break
+				}
+			}
+		}
+	}
+}
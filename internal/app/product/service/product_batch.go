@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+)
+
+const MaxBatchOperations = 500
+
+// Batch runs the operations in order. In atomic mode they share one
+// transaction and the first failure rolls back the whole batch; otherwise each
+// operation commits on its own and failures do not stop the rest.
+func (p *productService) Batch(
+	ctx context.Context,
+	ops []domain.BatchOperation,
+	atomic bool,
+) ([]domain.BatchResult, error) {
+	if len(ops) == 0 {
+		return nil, fmt.Errorf("%w: batch has no operations", ers.ErrInvalidInput)
+	}
+	if len(ops) > MaxBatchOperations {
+		return nil, fmt.Errorf("%w: batch is limited to %d operations", ers.ErrInvalidInput, MaxBatchOperations)
+	}
+
+	results := make([]domain.BatchResult, len(ops))
+	if !atomic {
+		for i, op := range ops {
+			results[i] = p.apply(ctx, op)
+		}
+		return results, nil
+	}
+
+	failed := -1
+	err := p.tx.WithinTx(ctx, func(ctx context.Context) error {
+		for i, op := range ops {
+			results[i] = p.apply(ctx, op)
+			if results[i].Err != nil {
+				failed = i
+				return results[i].Err
+			}
+		}
+		return nil
+	})
+	if err == nil {
+		return results, nil
+	}
+
+	for i, op := range ops {
+		switch {
+		case i == failed:
+		case failed < 0:
+			// every operation succeeded but the commit did not
+			results[i] = domain.BatchResult{Op: op.Op, Err: err}
+		case i < failed:
+			results[i] = domain.BatchResult{
+				Op:  op.Op,
+				Err: fmt.Errorf("%w: rolled back because operation %d failed", ers.ErrBatchAborted, failed),
+			}
+		default:
+			results[i] = domain.BatchResult{
+				Op:  op.Op,
+				Err: fmt.Errorf("%w: not run because operation %d failed", ers.ErrBatchAborted, failed),
+			}
+		}
+	}
+
+	return results, nil
+}
+
+func (p *productService) apply(ctx context.Context, op domain.BatchOperation) domain.BatchResult {
+	result := domain.BatchResult{Op: op.Op}
+
+	if op.Op != domain.BatchCreate && op.ID == uuid.Nil {
+		result.Err = fmt.Errorf("%w: %s needs a product id", ers.ErrInvalidInput, op.Op)
+		return result
+	}
+
+	switch op.Op {
+	case domain.BatchCreate:
+		product, err := p.Create(ctx, op.Product)
+		result.Product, result.Err = &product, err
+	case domain.BatchUpdate:
+		product, err := p.Update(ctx, op.ID, op.Changes, op.Version)
+		result.Product, result.Err = &product, err
+	case domain.BatchDelete:
+		result.Err = p.Delete(ctx, op.ID, op.Version)
+	case domain.BatchAdjust:
+		op.Adjust.ProductID = op.ID
+		balance, err := p.stock.Adjust(ctx, op.Adjust)
+		result.Balance, result.Err = &balance, err
+	default:
+		result.Err = fmt.Errorf("%w: unknown operation %q", ers.ErrInvalidInput, op.Op)
+	}
+
+	if result.Err != nil {
+		result.Product, result.Balance = nil, nil
+	}
+	return result
+}
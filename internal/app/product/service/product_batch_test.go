@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+)
+
+type inlineTx struct{}
+
+func (inlineTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestBatch_AtomicStopsAtFirstFailure(t *testing.T) {
+	p := &productService{tx: inlineTx{}}
+
+	results, err := p.Batch(context.Background(), []domain.BatchOperation{
+		{Op: domain.BatchCreate, Product: domain.Product{Description: "Товар без названия"}},
+		{Op: domain.BatchDelete, ID: uuid.New()},
+	}, true)
+	if err != nil {
+		t.Fatalf("batch failed: %s", err)
+	}
+
+	if !errors.Is(results[0].Err, ers.ErrInvalidInput) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrInvalidInput, results[0].Err)
+	}
+	if !errors.Is(results[1].Err, ers.ErrBatchAborted) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrBatchAborted, results[1].Err)
+	}
+}
+
+func TestBatch_BestEffortRunsEveryOperation(t *testing.T) {
+	p := &productService{tx: inlineTx{}}
+
+	results, err := p.Batch(context.Background(), []domain.BatchOperation{
+		{Op: "rename", ID: uuid.New()},
+		{Op: domain.BatchUpdate},
+	}, false)
+	if err != nil {
+		t.Fatalf("batch failed: %s", err)
+	}
+
+	for i, result := range results {
+		if !errors.Is(result.Err, ers.ErrInvalidInput) {
+			t.Errorf("operation %d: expected error to be %v, but got %v", i, ers.ErrInvalidInput, result.Err)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+)
+
+func sortKey(filter domain.ProductFilter) string {
+	if filter.SortDesc {
+		return "-" + string(filter.SortField)
+	}
+	return string(filter.SortField)
+}
+
+func encodeCursor(filter domain.ProductFilter, last domain.Product) string {
+	cursor := domain.ProductCursor{
+		Sort: sortKey(filter),
+		ID:   last.ID,
+	}
+
+	switch filter.SortField {
+	case domain.SortByPrice:
+		cursor.Value = strconv.FormatInt(last.Price.Amount, 10)
+	case domain.SortByQuantity:
+		cursor.Value = strconv.Itoa(last.Quantity)
+	case domain.SortByUpdatedAt:
+		cursor.Value = last.UpdatedAt.UTC().Format(time.RFC3339Nano)
+	default:
+		cursor.Value = last.Name
+	}
+
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodeCursor rejects cursors issued for a different sort order, since their keyset position would be meaningless.
+func decodeCursor(filter domain.ProductFilter) (*domain.ProductCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(filter.Cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ers.ErrInvalidInput)
+	}
+
+	var cursor domain.ProductCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ers.ErrInvalidInput)
+	}
+	if cursor.Sort != sortKey(filter) {
+		return nil, fmt.Errorf("%w: cursor was issued for sort %q", ers.ErrInvalidInput, cursor.Sort)
+	}
+	if cursor.ID == uuid.Nil || !validCursorValue(filter.SortField, cursor.Value) {
+		return nil, fmt.Errorf("%w: malformed cursor", ers.ErrInvalidInput)
+	}
+
+	return &cursor, nil
+}
+
+// validCursorValue checks the value parses as the type of the sort column, so a
+// tampered cursor fails here rather than in the cast of the keyset query.
+func validCursorValue(field domain.ProductSortField, value string) bool {
+	var err error
+	switch field {
+	case domain.SortByPrice:
+		_, err = strconv.ParseInt(value, 10, 64)
+	case domain.SortByQuantity:
+		_, err = strconv.ParseInt(value, 10, 32)
+	case domain.SortByUpdatedAt:
+		_, err = time.Parse(time.RFC3339Nano, value)
+	}
+	return err == nil
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+)
+
+func TestCursor_RoundTrip(t *testing.T) {
+	filter := domain.ProductFilter{SortField: domain.SortByPrice, SortDesc: true}
+	last := domain.Product{ID: uuid.New(), Name: "Монитор", Price: domain.NewMoney(2599000, "RUB")}
+
+	filter.Cursor = encodeCursor(filter, last)
+
+	cursor, err := decodeCursor(filter)
+	if err != nil {
+		t.Fatalf("cursor decoding failed: %s", err)
+	}
+
+	if cursor.ID != last.ID || cursor.Value != "2599000" {
+		t.Errorf("expected cursor at %s/2599000, but got %s/%s", last.ID, cursor.ID, cursor.Value)
+	}
+}
+
+func TestCursor_SortMismatch(t *testing.T) {
+	last := domain.Product{ID: uuid.New(), Name: "Keyboard"}
+	cursor := encodeCursor(domain.ProductFilter{SortField: domain.SortByName}, last)
+
+	_, err := decodeCursor(domain.ProductFilter{SortField: domain.SortByName, SortDesc: true, Cursor: cursor})
+	if !errors.Is(err, ers.ErrInvalidInput) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrInvalidInput, err)
+	}
+}
+
+func TestCursor_TamperedValue(t *testing.T) {
+	filter := domain.ProductFilter{SortField: domain.SortByUpdatedAt}
+
+	for _, raw := range []string{
+		`{"s":"updated_at","v":"yesterday","id":"` + uuid.NewString() + `"}`,
+		`{"s":"updated_at","v":"2024-03-01T10:00:00Z","id":"00000000-0000-0000-0000-000000000000"}`,
+	} {
+		filter.Cursor = base64.RawURLEncoding.EncodeToString([]byte(raw))
+
+		if _, err := decodeCursor(filter); !errors.Is(err, ers.ErrInvalidInput) {
+			t.Errorf("cursor %s: expected error to be %v, but got %v", raw, ers.ErrInvalidInput, err)
+		}
+	}
+}
+
+func TestCursor_TamperedPrice(t *testing.T) {
+	filter := domain.ProductFilter{SortField: domain.SortByPrice}
+	filter.Cursor = base64.RawURLEncoding.EncodeToString(
+		[]byte(`{"s":"price","v":"1e9; DROP TABLE products","id":"` + uuid.NewString() + `"}`),
+	)
+
+	if _, err := decodeCursor(filter); !errors.Is(err, ers.ErrInvalidInput) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrInvalidInput, err)
+	}
+}
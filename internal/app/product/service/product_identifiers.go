@@ -0,0 +1,96 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+)
+
+const (
+	MaxSKULength       = 64
+	MaxProductBarcodes = 20
+)
+
+// SKUs appear in URLs (/products/by-sku/{sku}) and spreadsheets, so they are
+// restricted to letters, digits, dots, dashes and underscores.
+var skuPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+func validateSKU(sku string) error {
+	if len(sku) > MaxSKULength {
+		return fmt.Errorf("%w: sku is longer than %d characters", ers.ErrInvalidInput, MaxSKULength)
+	}
+	if !skuPattern.MatchString(sku) {
+		return fmt.Errorf("%w: sku %q may only contain latin letters, digits, '.', '-' and '_'", ers.ErrInvalidInput, sku)
+	}
+	return nil
+}
+
+// newBarcodes turns client input into barcodes, trimming the codes and
+// deriving their type. The result still needs validateBarcodes.
+func newBarcodes(codes []string) []domain.Barcode {
+	barcodes := make([]domain.Barcode, len(codes))
+	for i, code := range codes {
+		code = strings.TrimSpace(code)
+		barcodes[i] = domain.Barcode{Code: code, Type: domain.BarcodeTypeOf(code)}
+	}
+	return barcodes
+}
+
+func barcodeCodes(barcodes []domain.Barcode) []string {
+	codes := make([]string, len(barcodes))
+	for i, barcode := range barcodes {
+		codes[i] = barcode.Code
+	}
+	return codes
+}
+
+func validateBarcodes(barcodes []domain.Barcode) error {
+	if len(barcodes) > MaxProductBarcodes {
+		return fmt.Errorf("%w: a product can have at most %d barcodes", ers.ErrInvalidInput, MaxProductBarcodes)
+	}
+
+	seen := make(map[string]bool, len(barcodes))
+	for _, barcode := range barcodes {
+		if err := validateBarcode(barcode.Code); err != nil {
+			return err
+		}
+		if seen[barcode.GTIN14()] {
+			return fmt.Errorf("%w: barcode %s is listed twice", ers.ErrInvalidInput, barcode.Code)
+		}
+		seen[barcode.GTIN14()] = true
+	}
+	return nil
+}
+
+// validateBarcode accepts UPC-A, EAN-13 and GTIN-14 codes with a correct check digit.
+func validateBarcode(code string) error {
+	if domain.BarcodeTypeOf(code) == "" {
+		return fmt.Errorf("%w: barcode %q must have 12, 13 or 14 digits", ers.ErrInvalidInput, code)
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return fmt.Errorf("%w: barcode %q must contain digits only", ers.ErrInvalidInput, code)
+		}
+	}
+	if !validCheckDigit(code) {
+		return fmt.Errorf("%w: barcode %s has a wrong check digit", ers.ErrInvalidInput, code)
+	}
+	return nil
+}
+
+// validCheckDigit implements the GS1 mod-10 check: counting from the right,
+// digits before the check digit are weighted 3, 1, 3, ...
+func validCheckDigit(code string) bool {
+	sum := 0
+	for i := len(code) - 2; i >= 0; i-- {
+		digit := int(code[i] - '0')
+		if (len(code)-2-i)%2 == 0 {
+			digit *= 3
+		}
+		sum += digit
+	}
+	return (10-sum%10)%10 == int(code[len(code)-1]-'0')
+}
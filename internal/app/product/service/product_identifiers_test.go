@@ -0,0 +1,39 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+)
+
+func TestValidateBarcode_CheckDigit(t *testing.T) {
+	for _, code := range []string{"4601546021298", "036000291452", "10036000291459"} {
+		if err := validateBarcode(code); err != nil {
+			t.Errorf("expected %s to be valid, but got %v", code, err)
+		}
+	}
+
+	if err := validateBarcode("4601546021297"); !errors.Is(err, ers.ErrInvalidInput) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrInvalidInput, err)
+	}
+}
+
+func TestValidateBarcodes_SameGTIN(t *testing.T) {
+	// UPC-A and its EAN-13 form are the same GTIN
+	barcodes := newBarcodes([]string{"036000291452", " 0036000291452 "})
+
+	if err := validateBarcodes(barcodes); !errors.Is(err, ers.ErrInvalidInput) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrInvalidInput, err)
+	}
+	if barcodes[1].Type != domain.BarcodeEAN13 {
+		t.Errorf("expected type %s, but got %s", domain.BarcodeEAN13, barcodes[1].Type)
+	}
+}
+
+func TestValidateSKU_Slash(t *testing.T) {
+	if err := validateSKU("SHOE/42"); !errors.Is(err, ers.ErrInvalidInput) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrInvalidInput, err)
+	}
+}
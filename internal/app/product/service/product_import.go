@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+)
+
+const MaxImportRows = 50000
+
+// warnNoSKU is reported for rows without a SKU: imports find products only by
+// SKU, so such a row is inserted again every time the file is imported.
+const warnNoSKU = "row has no sku and was inserted as a new product; importing it again creates a duplicate"
+
+// errDryRun rolls back a row that was applied only to check that it would succeed.
+var errDryRun = errors.New("dry run")
+
+// Import upserts one product per row: rows whose SKU matches an existing
+// product update it, the rest create new products. The SKU stays optional, so
+// a row without one always creates a product and is reported with a warning.
+// Every row commits in its own transaction, so one bad row does not discard
+// the others.
+func (p *productService) Import(ctx context.Context, rows []domain.ImportRow, dryRun bool) (domain.ImportReport, error) {
+	if len(rows) == 0 {
+		return domain.ImportReport{}, fmt.Errorf("%w: import file has no rows", ers.ErrInvalidInput)
+	}
+	if len(rows) > MaxImportRows {
+		return domain.ImportReport{}, fmt.Errorf("%w: import is limited to %d rows", ers.ErrInvalidInput, MaxImportRows)
+	}
+
+	report := domain.ImportReport{
+		DryRun: dryRun,
+		Total:  len(rows),
+		Rows:   make([]domain.ImportRowResult, 0, len(rows)),
+	}
+
+	for _, row := range rows {
+		if err := ctx.Err(); err != nil {
+			return domain.ImportReport{}, err
+		}
+
+		result := p.importRow(ctx, row, dryRun)
+		switch {
+		case len(result.Errors) > 0:
+			report.Failed++
+		case result.Action == domain.ImportCreate:
+			report.Created++
+		case result.Action == domain.ImportUpdate:
+			report.Updated++
+		}
+		report.Rows = append(report.Rows, result)
+	}
+
+	return report, nil
+}
+
+func (p *productService) importRow(ctx context.Context, row domain.ImportRow, dryRun bool) domain.ImportRowResult {
+	row.SKU = strings.TrimSpace(row.SKU)
+	result := domain.ImportRowResult{Line: row.Line, SKU: row.SKU, Errors: row.Errors}
+	if len(row.Errors) > 0 {
+		return result
+	}
+
+	err := p.tx.WithinTx(ctx, func(ctx context.Context) error {
+		existing, err := p.findBySKU(ctx, row.SKU)
+		if err != nil {
+			return err
+		}
+
+		var saved domain.Product
+		if existing == nil {
+			result.Action = domain.ImportCreate
+			if saved, err = p.importCreate(ctx, row); err != nil {
+				return err
+			}
+			if row.SKU == "" {
+				result.Warnings = []string{warnNoSKU}
+			}
+		} else {
+			result.Action = domain.ImportUpdate
+			if saved, err = p.update(ctx, existing.ID, importUpdate(row), 0); err != nil {
+				return err
+			}
+		}
+		result.ProductID = &saved.ID
+
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		result.Action = ""
+		result.ProductID = nil
+		result.Warnings = nil
+		result.Errors = []string{err.Error()}
+	}
+
+	return result
+}
+
+// importCreate creates a product from a row; missing cells fall back to an
+// empty description, a zero price in the default currency and no stock.
+func (p *productService) importCreate(ctx context.Context, row domain.ImportRow) (domain.Product, error) {
+	product := domain.Product{SKU: row.SKU, Name: row.Name}
+	if row.Description != nil {
+		product.Description = *row.Description
+	}
+	if row.Price != nil {
+		product.Price = *row.Price
+	}
+	if row.Quantity != nil {
+		product.Quantity = *row.Quantity
+	}
+	product.Price = priceIn(product.Price, domain.DefaultCurrency)
+
+	if err := p.validateProduct(product); err != nil {
+		return domain.Product{}, err
+	}
+	return p.create(ctx, product)
+}
+
+// importUpdate changes only what the row carries: a column missing from the
+// file or an empty cell keeps the product's current value.
+func importUpdate(row domain.ImportRow) domain.UpdateProductDTO {
+	dto := domain.UpdateProductDTO{
+		Description: row.Description,
+		Price:       row.Price,
+		Quantity:    row.Quantity,
+	}
+	if row.Name != "" {
+		dto.Name = &row.Name
+	}
+	return dto
+}
+
+func (p *productService) findBySKU(ctx context.Context, sku string) (*domain.Product, error) {
+	if sku == "" {
+		return nil, nil
+	}
+
+	product, err := p.repo.GetBySKU(ctx, sku)
+	if errors.Is(err, ers.ErrProductNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &product, nil
+}
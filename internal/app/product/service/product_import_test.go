@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+func TestImport_PartialRowKeepsMissingFields(t *testing.T) {
+	product := testProduct()
+	product.SKU = "KB-1"
+	p, repo := newTestProductService(product)
+
+	// a file with only sku and name columns
+	rows := []domain.ImportRow{{Line: 2, SKU: "KB-1", Name: "Клавиатура беспроводная"}}
+
+	report, err := p.Import(context.Background(), rows, false)
+	if err != nil {
+		t.Fatalf("import failed: %s", err)
+	}
+	if report.Updated != 1 || report.Failed != 0 {
+		t.Fatalf("expected one updated row, but got %+v", report)
+	}
+
+	got := repo.products[product.ID]
+	if got.Name != "Клавиатура беспроводная" {
+		t.Errorf("expected name %q, but got %q", "Клавиатура беспроводная", got.Name)
+	}
+	if got.Description != product.Description || got.Price != product.Price || got.Quantity != product.Quantity {
+		t.Errorf("expected description, price and quantity to stay unchanged, but got %+v", got)
+	}
+	if movements := p.stock.(*memoryStock).movements; len(movements) != 0 {
+		t.Errorf("expected no stock movement, but got %+v", movements)
+	}
+}
+
+func TestImport_UpdatesPresentFields(t *testing.T) {
+	product := testProduct()
+	product.SKU = "KB-1"
+	p, repo := newTestProductService(product)
+
+	quantity := 4
+	rows := []domain.ImportRow{{Line: 2, SKU: "KB-1", Name: product.Name, Quantity: &quantity}}
+
+	if _, err := p.Import(context.Background(), rows, false); err != nil {
+		t.Fatalf("import failed: %s", err)
+	}
+
+	got := repo.products[product.ID]
+	if got.Quantity != quantity || got.Price != product.Price {
+		t.Errorf("expected quantity %d and price %v, but got %d and %v", quantity, product.Price, got.Quantity, got.Price)
+	}
+}
+
+func TestImport_RowWithoutSKUWarns(t *testing.T) {
+	p, repo := newTestProductService()
+
+	description := "Беспроводная мышь"
+	rows := []domain.ImportRow{{Line: 2, Name: "Мышь", Description: &description}}
+
+	report, err := p.Import(context.Background(), rows, false)
+	if err != nil {
+		t.Fatalf("import failed: %s", err)
+	}
+
+	if report.Created != 1 || len(repo.products) != 1 {
+		t.Fatalf("expected one created product, but got %+v", report)
+	}
+	if len(report.Rows[0].Warnings) != 1 {
+		t.Errorf("expected a warning for the row without a sku, but got %v", report.Rows[0].Warnings)
+	}
+}
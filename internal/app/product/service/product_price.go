@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+)
+
+// priceIn fills in the currency of a price that came without one.
+func priceIn(price domain.Money, currency string) domain.Money {
+	if price.Currency == "" {
+		price.Currency = currency
+	}
+	return price
+}
+
+func validatePrice(price domain.Money) error {
+	if !domain.ValidCurrency(price.Currency) {
+		return fmt.Errorf("%w: unsupported currency %q", ers.ErrInvalidInput, price.Currency)
+	}
+	if price.IsNegative() {
+		return fmt.Errorf("%w: product price cannot be negative", ers.ErrInvalidInput)
+	}
+	return nil
+}
+
+// checkVariantCurrency keeps a parent and the variants with prices of their own
+// in one currency, so that the prices within a product stay comparable.
+func (p *productService) checkVariantCurrency(ctx context.Context, product domain.Product) error {
+	if product.IsVariant() {
+		if product.InheritsPrice {
+			return nil
+		}
+		parent, err := p.repo.GetById(ctx, *product.ParentID, true)
+		if err != nil {
+			return err
+		}
+		_, err = parent.Price.Cmp(product.Price)
+		return err
+	}
+
+	variants, err := p.repo.GetVariants(ctx, product.ID)
+	if err != nil {
+		return err
+	}
+	for _, variant := range variants {
+		if variant.InheritsPrice {
+			continue
+		}
+		if _, err := product.Price.Cmp(variant.Price); err != nil {
+			return fmt.Errorf("%w: variant %s is priced in %s", ers.ErrCurrencyMismatch, variant.ID, variant.Price.Currency)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/logger"
+)
+
+type Purger struct {
+	service   ProductService
+	retention time.Duration
+	interval  time.Duration
+	logger    logger.Logger
+}
+
+func NewPurger(service ProductService, retention, interval time.Duration, logger logger.Logger) *Purger {
+	return &Purger{
+		service:   service,
+		retention: retention,
+		interval:  interval,
+		logger:    logger,
+	}
+}
+
+// Run hard-deletes expired soft-deleted products every interval until ctx is cancelled.
+func (p *Purger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := p.service.Purge(ctx, p.retention)
+			if err != nil {
+				p.logger.Error("failed to purge deleted products: %v", err)
+				continue
+			}
+			if purged > 0 {
+				p.logger.Info("purged %d deleted products", purged)
+			}
+		}
+	}
+}
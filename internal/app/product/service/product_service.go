@@ -4,62 +4,296 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 	"unicode/utf8"
 
 	"github.com/google/uuid"
+	audit "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/audit/service"
+	outbox "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/outbox/service"
 	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/product/repository"
+	stock "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/stock/service"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/auth"
 	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
 	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	core "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/repository"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 500
+
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+
+	MaxAttributeFilters = 10
 )
 
 type productService struct {
-	repo repository.ProductRepository
+	repo   repository.ProductRepository
+	stock  stock.StockService
+	audit  audit.AuditService
+	events outbox.OutboxService
+	tx     core.Transactor
 }
 
-func NewProductService(repo repository.ProductRepository) ProductService {
+func NewProductService(
+	repo repository.ProductRepository,
+	stock stock.StockService,
+	audit audit.AuditService,
+	events outbox.OutboxService,
+	tx core.Transactor,
+) ProductService {
 	return &productService{
-		repo: repo,
+		repo:   repo,
+		stock:  stock,
+		audit:  audit,
+		events: events,
+		tx:     tx,
 	}
 }
 
 func (p *productService) Create(ctx context.Context, product domain.Product) (domain.Product, error) {
+	product.SKU = strings.TrimSpace(product.SKU)
+	product.Barcodes = newBarcodes(barcodeCodes(product.Barcodes))
+	product.Price = priceIn(product.Price, domain.DefaultCurrency)
 	if err := p.validateProduct(product); err != nil {
 		return domain.Product{}, err
 	}
 
+	var created domain.Product
+	err := p.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		created, err = p.create(ctx, product)
+		return err
+	})
+	if err != nil {
+		return domain.Product{}, err
+	}
+
+	return created, nil
+}
+
+// create inserts a validated product; it must run inside a transaction.
+func (p *productService) create(ctx context.Context, product domain.Product) (domain.Product, error) {
 	product.ID = uuid.New()
 	product.CreatedAt = time.Now()
 	product.UpdatedAt = time.Now()
 
-	return p.repo.Create(ctx, &product)
+	// the opening quantity goes through the ledger like any other receipt
+	initialQuantity := product.Quantity
+	product.Quantity = 0
+
+	created, err := p.repo.Create(ctx, &product)
+	if err != nil {
+		return domain.Product{}, err
+	}
+
+	if len(product.Barcodes) > 0 {
+		if err := p.repo.SetBarcodes(ctx, created.ID, product.Barcodes); err != nil {
+			return domain.Product{}, err
+		}
+	}
+
+	if initialQuantity != 0 {
+		if _, err := p.stock.Record(ctx, domain.RecordMovementDTO{
+			ProductID:   created.ID,
+			Type:        domain.MovementReceipt,
+			Quantity:    initialQuantity,
+			ReasonCode:  "initial_stock",
+			KeepVersion: true,
+		}); err != nil {
+			return domain.Product{}, err
+		}
+	}
+
+	if initialQuantity != 0 || len(product.Barcodes) > 0 {
+		if created, err = p.repo.GetById(ctx, created.ID, false); err != nil {
+			return domain.Product{}, err
+		}
+	}
+
+	if err := p.audit.Record(ctx, domain.AuditEntityProduct, created.ID, domain.AuditCreate, nil, created); err != nil {
+		return domain.Product{}, err
+	}
+	if err := p.events.Add(ctx, domain.EventProductCreated, domain.AggregateProduct, created.ID, created); err != nil {
+		return domain.Product{}, err
+	}
+
+	return created, nil
 }
 
-func (p *productService) GetById(ctx context.Context, id uuid.UUID) (domain.Product, error) {
+func (p *productService) GetById(ctx context.Context, id uuid.UUID, includeDeleted bool) (domain.Product, error) {
 	if id == uuid.Nil {
 		return domain.Product{}, errors.New("invalid product id")
 	}
-	return p.repo.GetById(ctx, id)
+	if includeDeleted && !isAdmin(ctx) {
+		return domain.Product{}, fmt.Errorf("%w: only admins can read deleted products", ers.ErrForbidden)
+	}
+
+	product, err := p.repo.GetById(ctx, id, includeDeleted)
+	if err != nil {
+		return domain.Product{}, err
+	}
+
+	if product, err = p.withStock(ctx, product); err != nil {
+		return domain.Product{}, err
+	}
+
+	return p.withVariants(ctx, product)
+}
+
+func (p *productService) GetBySKU(ctx context.Context, sku string) (domain.Product, error) {
+	if err := validateSKU(sku); err != nil {
+		return domain.Product{}, err
+	}
+
+	product, err := p.repo.GetBySKU(ctx, sku)
+	if err != nil {
+		return domain.Product{}, err
+	}
+
+	return p.withStock(ctx, product)
+}
+
+func (p *productService) GetByBarcode(ctx context.Context, code string) (domain.Product, error) {
+	if err := validateBarcode(code); err != nil {
+		return domain.Product{}, err
+	}
+
+	product, err := p.repo.GetByBarcode(ctx, domain.Barcode{Code: code}.GTIN14())
+	if err != nil {
+		return domain.Product{}, err
+	}
+
+	return p.withStock(ctx, product)
+}
+
+// withStock adds the per-warehouse breakdown shown on single-product reads.
+func (p *productService) withStock(ctx context.Context, product domain.Product) (domain.Product, error) {
+	var err error
+	if product.Stock, err = p.stock.Levels(ctx, product.ID); err != nil {
+		return domain.Product{}, err
+	}
+	return product, nil
+}
+
+func (p *productService) GetAll(ctx context.Context, filter domain.ProductFilter) (domain.ProductPage, error) {
+	if err := p.validateFilter(&filter); err != nil {
+		return domain.ProductPage{}, err
+	}
+	if filter.IncludeDeleted && !isAdmin(ctx) {
+		return domain.ProductPage{}, fmt.Errorf("%w: only admins can list deleted products", ers.ErrForbidden)
+	}
+
+	limit := filter.Limit
+	filter.Limit++
+
+	products, err := p.repo.GetAll(ctx, filter)
+	if err != nil {
+		return domain.ProductPage{}, err
+	}
+
+	page := domain.ProductPage{Items: products}
+	if len(products) > limit {
+		page.Items = products[:limit]
+		page.NextCursor = encodeCursor(filter, page.Items[limit-1])
+	}
+
+	return page, nil
 }
 
-func (p *productService) GetAll(ctx context.Context) ([]domain.Product, error) {
-	return p.repo.GetAll(ctx)
+// Export streams every product matching the filter to fn. The page size is
+// ignored; a cursor from the list endpoint resumes the export after that product.
+func (p *productService) Export(ctx context.Context, filter domain.ProductFilter, fn func(domain.Product) error) error {
+	filter.Limit = 0
+	if err := p.validateFilter(&filter); err != nil {
+		return err
+	}
+	if filter.IncludeDeleted && !isAdmin(ctx) {
+		return fmt.Errorf("%w: only admins can export deleted products", ers.ErrForbidden)
+	}
+	filter.Limit = 0 // validateFilter applied the default page size
+
+	return p.repo.Iterate(ctx, filter, fn)
+}
+
+// Search falls back to trigram matching when the full-text query finds nothing, which covers typos.
+func (p *productService) Search(ctx context.Context, q string, limit int) ([]domain.ProductSearchResult, error) {
+	q = strings.TrimSpace(q)
+	if q == "" {
+		return nil, fmt.Errorf("%w: search query is required", ers.ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(q) > 200 {
+		return nil, fmt.Errorf("%w: search query is too long", ers.ErrInvalidInput)
+	}
+
+	switch {
+	case limit == 0:
+		limit = DefaultSearchLimit
+	case limit < 0 || limit > MaxSearchLimit:
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", ers.ErrInvalidInput, MaxSearchLimit)
+	}
+
+	results, err := p.repo.Search(ctx, q, limit)
+	if err != nil || len(results) > 0 {
+		return results, err
+	}
+
+	return p.repo.SearchFuzzy(ctx, q, limit)
 }
 
 func (p *productService) Update(
 	ctx context.Context,
 	id uuid.UUID,
 	dto domain.UpdateProductDTO,
+	version int64,
 ) (domain.Product, error) {
 	if id == uuid.Nil {
 		return domain.Product{}, errors.New("invalid product id")
 	}
 
-	currentProduct, err := p.repo.GetById(ctx, id)
+	var updated domain.Product
+	err := p.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		updated, err = p.update(ctx, id, dto, version)
+		return err
+	})
+	if err != nil {
+		return domain.Product{}, err
+	}
+
+	return updated, nil
+}
+
+// update applies the DTO on top of the stored product; it must run inside a transaction.
+func (p *productService) update(
+	ctx context.Context,
+	id uuid.UUID,
+	dto domain.UpdateProductDTO,
+	version int64,
+) (domain.Product, error) {
+	currentProduct, err := p.repo.GetById(ctx, id, false)
 	if err != nil {
 		return domain.Product{}, err
 	}
+	before := currentProduct
+	if version != 0 && currentProduct.Version != version {
+		return domain.Product{}, fmt.Errorf(
+			"%w: product version is %d, not %d",
+			ers.ErrPreconditionFailed,
+			currentProduct.Version,
+			version,
+		)
+	}
+	previousQuantity := currentProduct.Quantity
 
+	if dto.SKU != nil {
+		currentProduct.SKU = strings.TrimSpace(*dto.SKU)
+	}
+	if dto.Barcodes != nil {
+		currentProduct.Barcodes = newBarcodes(*dto.Barcodes)
+	}
 	if dto.Name != nil {
 		currentProduct.Name = *dto.Name
 	}
@@ -67,33 +301,238 @@ func (p *productService) Update(
 		currentProduct.Description = *dto.Description
 	}
 	if dto.Price != nil {
-		currentProduct.Price = *dto.Price
+		currentProduct.Price = priceIn(*dto.Price, currentProduct.Price.Currency)
+		currentProduct.InheritsPrice = false
+	}
+	if dto.InheritPrice != nil {
+		if *dto.InheritPrice && (dto.Price != nil || !currentProduct.IsVariant()) {
+			return domain.Product{}, fmt.Errorf("%w: only a variant without a new price can inherit the price", ers.ErrInvalidInput)
+		}
+		currentProduct.InheritsPrice = *dto.InheritPrice
 	}
 	if dto.Quantity != nil {
 		currentProduct.Quantity = *dto.Quantity
 	}
+	if dto.WarehouseID != nil && dto.Quantity == nil {
+		return domain.Product{}, fmt.Errorf("%w: warehouse_id only applies to a quantity change", ers.ErrInvalidInput)
+	}
 	currentProduct.UpdatedAt = time.Now()
 
 	if err := p.validateProduct(currentProduct); err != nil {
 		return domain.Product{}, err
 	}
+	if dto.Price != nil {
+		if err := p.checkVariantCurrency(ctx, currentProduct); err != nil {
+			return domain.Product{}, err
+		}
+	}
+
+	// the version read above guards the write against concurrent edits
+	updated, err := p.repo.Update(ctx, id, currentProduct)
+	if err != nil {
+		return domain.Product{}, err
+	}
 
-	return p.repo.Update(ctx, id, currentProduct)
+	if dto.Barcodes != nil {
+		if err := p.repo.SetBarcodes(ctx, id, currentProduct.Barcodes); err != nil {
+			return domain.Product{}, err
+		}
+	}
+
+	// an absolute quantity from the client is recorded as an adjustment by the
+	// difference; the update above already bumped the version for both
+	delta := currentProduct.Quantity - previousQuantity
+	if delta != 0 {
+		var warehouseID uuid.UUID
+		if dto.WarehouseID != nil {
+			warehouseID = *dto.WarehouseID
+		}
+		if _, err := p.stock.Record(ctx, domain.RecordMovementDTO{
+			ProductID:   id,
+			WarehouseID: warehouseID,
+			Type:        domain.MovementAdjustment,
+			Quantity:    delta,
+			ReasonCode:  "manual_update",
+			KeepVersion: true,
+		}); err != nil {
+			return domain.Product{}, err
+		}
+	}
+
+	if delta != 0 || dto.Barcodes != nil {
+		if updated, err = p.repo.GetById(ctx, id, false); err != nil {
+			return domain.Product{}, err
+		}
+	}
+
+	if err := p.audit.Record(ctx, domain.AuditEntityProduct, id, domain.AuditUpdate, before, updated); err != nil {
+		return domain.Product{}, err
+	}
+	if err := p.events.Add(ctx, domain.EventProductUpdated, domain.AggregateProduct, id, updated); err != nil {
+		return domain.Product{}, err
+	}
+
+	return updated, nil
 }
 
-func (p *productService) Delete(ctx context.Context, id uuid.UUID) error {
+func (p *productService) Delete(ctx context.Context, id uuid.UUID, version int64) error {
 	if id == uuid.Nil {
 		return errors.New("invalid product id")
 	}
-	return p.repo.Delete(ctx, id)
+
+	return p.tx.WithinTx(ctx, func(ctx context.Context) error {
+		currentProduct, err := p.repo.GetById(ctx, id, false)
+		if err != nil {
+			return err
+		}
+		if version != 0 && currentProduct.Version != version {
+			return fmt.Errorf(
+				"%w: product version is %d, not %d",
+				ers.ErrPreconditionFailed,
+				currentProduct.Version,
+				version,
+			)
+		}
+		if !currentProduct.IsVariant() {
+			variants, err := p.repo.GetVariants(ctx, id)
+			if err != nil {
+				return err
+			}
+			if len(variants) > 0 {
+				return fmt.Errorf("%w: delete its %d variants first", ers.ErrProductHasVariants, len(variants))
+			}
+		}
+		// a shipped transfer could never be received into a deleted product
+		inTransit, err := p.repo.InTransitForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if inTransit > 0 {
+			return fmt.Errorf("%w: %d units are on shipped transfers", ers.ErrProductInTransit, inTransit)
+		}
+		deletedAt := time.Now()
+		if err := p.repo.Delete(ctx, id, currentProduct.Version, deletedAt); err != nil {
+			return err
+		}
+		if err := p.audit.Record(ctx, domain.AuditEntityProduct, id, domain.AuditDelete, currentProduct, nil); err != nil {
+			return err
+		}
+		return p.events.Add(ctx, domain.EventProductDeleted, domain.AggregateProduct, id, domain.ProductDeleted{
+			ID:        id,
+			DeletedAt: deletedAt,
+		})
+	})
+}
+
+func (p *productService) Restore(ctx context.Context, id uuid.UUID) (domain.Product, error) {
+	if id == uuid.Nil {
+		return domain.Product{}, errors.New("invalid product id")
+	}
+
+	var restored domain.Product
+	err := p.tx.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := p.repo.GetById(ctx, id, true)
+		if err != nil {
+			return err
+		}
+		if before.IsVariant() {
+			_, err := p.repo.GetById(ctx, *before.ParentID, false)
+			if errors.Is(err, ers.ErrProductNotFound) {
+				return fmt.Errorf("%w: the parent product is deleted, restore it first", ers.ErrInvalidInput)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		if restored, err = p.repo.Restore(ctx, id, time.Now()); err != nil {
+			return err
+		}
+		if err := p.audit.Record(ctx, domain.AuditEntityProduct, id, domain.AuditRestore, before, restored); err != nil {
+			return err
+		}
+		// consumers see a restore as the product coming back with its current state
+		return p.events.Add(ctx, domain.EventProductUpdated, domain.AggregateProduct, id, restored)
+	})
+	if err != nil {
+		return domain.Product{}, err
+	}
+
+	return restored, nil
+}
+
+// Purge removes products that have stayed soft-deleted for longer than retention.
+func (p *productService) Purge(ctx context.Context, retention time.Duration) (int64, error) {
+	return p.repo.Purge(ctx, time.Now().Add(-retention))
+}
+
+func (p *productService) validateFilter(filter *domain.ProductFilter) error {
+	switch {
+	case filter.Limit == 0:
+		filter.Limit = DefaultPageSize
+	case filter.Limit < 0 || filter.Limit > MaxPageSize:
+		return fmt.Errorf("%w: limit must be between 1 and %d", ers.ErrInvalidInput, MaxPageSize)
+	}
+
+	switch filter.SortField {
+	case "":
+		filter.SortField = domain.SortByName
+	case domain.SortByName, domain.SortByPrice, domain.SortByQuantity, domain.SortByUpdatedAt:
+	default:
+		return fmt.Errorf("%w: unsupported sort field %q", ers.ErrInvalidInput, filter.SortField)
+	}
+
+	if filter.MinPrice != nil && filter.MaxPrice != nil {
+		cmp, err := filter.MinPrice.Cmp(*filter.MaxPrice)
+		if err != nil {
+			return err
+		}
+		if cmp > 0 {
+			return fmt.Errorf("%w: min_price cannot exceed max_price", ers.ErrInvalidInput)
+		}
+	}
+
+	if len(filter.Attributes) > MaxAttributeFilters {
+		return fmt.Errorf("%w: at most %d attribute filters are allowed", ers.ErrInvalidInput, MaxAttributeFilters)
+	}
+	for _, attribute := range filter.Attributes {
+		if !domain.ValidAttributeCode(attribute.Code) {
+			return fmt.Errorf("%w: invalid attribute code %q", ers.ErrInvalidInput, attribute.Code)
+		}
+		if attribute.Min != nil && attribute.Max != nil && *attribute.Min > *attribute.Max {
+			return fmt.Errorf("%w: attr.%s.min cannot exceed attr.%s.max", ers.ErrInvalidInput, attribute.Code, attribute.Code)
+		}
+	}
+
+	filter.After = nil
+	if filter.Cursor != "" {
+		cursor, err := decodeCursor(*filter)
+		if err != nil {
+			return err
+		}
+		filter.After = cursor
+	}
+
+	return nil
+}
+
+func isAdmin(ctx context.Context) bool {
+	return auth.ActorFrom(ctx).Role == auth.RoleAdmin
 }
 
 func (p *productService) validateProduct(product domain.Product) error {
+	if product.SKU != "" {
+		if err := validateSKU(product.SKU); err != nil {
+			return err
+		}
+	}
+	if err := validateBarcodes(product.Barcodes); err != nil {
+		return err
+	}
 	if product.Name == "" {
 		return fmt.Errorf("%w: product name is required", ers.ErrInvalidInput)
 	}
-	if product.Price < 0 {
-		return fmt.Errorf("%w: product price cannot be negative", ers.ErrInvalidInput)
+	if err := validatePrice(product.Price); err != nil {
+		return err
 	}
 	if product.Quantity < 0 {
 		return fmt.Errorf("%w: product quantity cannot be negative", ers.ErrInvalidInput)
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
@@ -9,8 +10,19 @@ import (
 
 type ProductService interface {
 	Create(ctx context.Context, p domain.Product) (domain.Product, error)
-	GetById(ctx context.Context, id uuid.UUID) (domain.Product, error)
-	GetAll(ctx context.Context) ([]domain.Product, error)
-	Update(ctx context.Context, id uuid.UUID, dto domain.UpdateProductDTO) (domain.Product, error)
-	Delete(ctx context.Context, id uuid.UUID) error
+	GetById(ctx context.Context, id uuid.UUID, includeDeleted bool) (domain.Product, error)
+	GetBySKU(ctx context.Context, sku string) (domain.Product, error)
+	GetByBarcode(ctx context.Context, code string) (domain.Product, error)
+	GetAll(ctx context.Context, filter domain.ProductFilter) (domain.ProductPage, error)
+	Export(ctx context.Context, filter domain.ProductFilter, fn func(domain.Product) error) error
+	Search(ctx context.Context, q string, limit int) ([]domain.ProductSearchResult, error)
+	Update(ctx context.Context, id uuid.UUID, dto domain.UpdateProductDTO, version int64) (domain.Product, error)
+	Delete(ctx context.Context, id uuid.UUID, version int64) error
+	Restore(ctx context.Context, id uuid.UUID) (domain.Product, error)
+	Purge(ctx context.Context, retention time.Duration) (int64, error)
+	SetVariantAttributes(ctx context.Context, productID uuid.UUID, attributes []domain.VariantAttribute) (domain.Product, error)
+	CreateVariant(ctx context.Context, parentID uuid.UUID, variant domain.Product) (domain.Product, error)
+	GenerateVariants(ctx context.Context, parentID uuid.UUID) ([]domain.Product, error)
+	Batch(ctx context.Context, ops []domain.BatchOperation, atomic bool) ([]domain.BatchResult, error)
+	Import(ctx context.Context, rows []domain.ImportRow, dryRun bool) (domain.ImportReport, error)
 }
@@ -1,19 +1,203 @@
 package service
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
+	audit "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/audit/service"
+	outbox "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/outbox/service"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/product/repository"
+	stock "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/stock/service"
 	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
 	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
 )
 
+// memoryProducts keeps products in a map and checks versions the way the
+// Postgres repository does.
+type memoryProducts struct {
+	repository.ProductRepository
+	products  map[uuid.UUID]domain.Product
+	inTransit map[uuid.UUID]int
+}
+
+func (m *memoryProducts) Create(ctx context.Context, p *domain.Product) (domain.Product, error) {
+	m.products[p.ID] = *p
+	return *p, nil
+}
+
+func (m *memoryProducts) GetById(ctx context.Context, id uuid.UUID, includeDeleted bool) (domain.Product, error) {
+	product, ok := m.products[id]
+	if !ok || (product.DeletedAt != nil && !includeDeleted) {
+		return domain.Product{}, fmt.Errorf("%w: not found error", ers.ErrProductNotFound)
+	}
+	return product, nil
+}
+
+func (m *memoryProducts) GetBySKU(ctx context.Context, sku string) (domain.Product, error) {
+	for _, product := range m.products {
+		if product.SKU == sku && product.DeletedAt == nil {
+			return product, nil
+		}
+	}
+	return domain.Product{}, fmt.Errorf("%w: not found error", ers.ErrProductNotFound)
+}
+
+func (m *memoryProducts) GetVariants(ctx context.Context, parentID uuid.UUID) ([]domain.Product, error) {
+	return nil, nil
+}
+
+func (m *memoryProducts) Update(ctx context.Context, id uuid.UUID, p domain.Product) (domain.Product, error) {
+	current, err := m.GetById(ctx, id, false)
+	if err != nil {
+		return domain.Product{}, err
+	}
+	if current.Version != p.Version {
+		return domain.Product{}, fmt.Errorf("%w: product was modified concurrently", ers.ErrVersionConflict)
+	}
+	// the quantity only changes through the ledger
+	p.Quantity = current.Quantity
+	p.Version++
+	m.products[id] = p
+	return p, nil
+}
+
+func (m *memoryProducts) Delete(ctx context.Context, id uuid.UUID, version int64, deletedAt time.Time) error {
+	product, err := m.GetById(ctx, id, false)
+	if err != nil {
+		return err
+	}
+	if product.Version != version {
+		return fmt.Errorf("%w: product was modified concurrently", ers.ErrVersionConflict)
+	}
+	product.DeletedAt = &deletedAt
+	product.Version++
+	m.products[id] = product
+	return nil
+}
+
+func (m *memoryProducts) InTransitForUpdate(ctx context.Context, id uuid.UUID) (int, error) {
+	if _, err := m.GetById(ctx, id, false); err != nil {
+		return 0, err
+	}
+	return m.inTransit[id], nil
+}
+
+func (m *memoryProducts) Restore(ctx context.Context, id uuid.UUID, restoredAt time.Time) (domain.Product, error) {
+	product, ok := m.products[id]
+	if !ok || product.DeletedAt == nil {
+		return domain.Product{}, fmt.Errorf("%w: not found error", ers.ErrProductNotFound)
+	}
+	product.DeletedAt = nil
+	product.UpdatedAt = restoredAt
+	product.Version++
+	m.products[id] = product
+	return product, nil
+}
+
+// memoryStock applies movements to the products of a memoryProducts.
+type memoryStock struct {
+	stock.StockService
+	products  *memoryProducts
+	movements []domain.RecordMovementDTO
+}
+
+func (m *memoryStock) Record(ctx context.Context, dto domain.RecordMovementDTO) (domain.StockMovement, error) {
+	product := m.products.products[dto.ProductID]
+	product.Quantity += dto.Quantity
+	if !dto.KeepVersion {
+		product.Version++
+	}
+	m.products.products[dto.ProductID] = product
+	m.movements = append(m.movements, dto)
+	return domain.StockMovement{ProductID: dto.ProductID, Quantity: dto.Quantity, BalanceAfter: product.Quantity}, nil
+}
+
+type memoryAudit struct {
+	audit.AuditService
+	actions []domain.AuditAction
+}
+
+func (m *memoryAudit) Record(
+	ctx context.Context,
+	entityType string,
+	entityID uuid.UUID,
+	action domain.AuditAction,
+	before interface{},
+	after interface{},
+) error {
+	m.actions = append(m.actions, action)
+	return nil
+}
+
+type memoryEvents struct {
+	outbox.OutboxService
+	events []domain.EventType
+}
+
+func (m *memoryEvents) Add(
+	ctx context.Context,
+	eventType domain.EventType,
+	aggregateType string,
+	aggregateID uuid.UUID,
+	payload interface{},
+) error {
+	m.events = append(m.events, eventType)
+	return nil
+}
+
+// searchProducts answers full-text and trigram searches from fixed results.
+type searchProducts struct {
+	repository.ProductRepository
+	fullText []domain.ProductSearchResult
+	trigram  []domain.ProductSearchResult
+	limits   []int
+}
+
+func (m *searchProducts) Search(ctx context.Context, q string, limit int) ([]domain.ProductSearchResult, error) {
+	m.limits = append(m.limits, limit)
+	return m.fullText, nil
+}
+
+func (m *searchProducts) SearchFuzzy(ctx context.Context, q string, limit int) ([]domain.ProductSearchResult, error) {
+	m.limits = append(m.limits, limit)
+	return m.trigram, nil
+}
+
+func newTestProductService(products ...domain.Product) (*productService, *memoryProducts) {
+	repo := &memoryProducts{products: map[uuid.UUID]domain.Product{}, inTransit: map[uuid.UUID]int{}}
+	for _, product := range products {
+		repo.products[product.ID] = product
+	}
+	return &productService{
+		repo:   repo,
+		stock:  &memoryStock{products: repo},
+		audit:  &memoryAudit{},
+		events: &memoryEvents{},
+		tx:     inlineTx{},
+	}, repo
+}
+
+func testProduct() domain.Product {
+	return domain.Product{
+		ID:          uuid.New(),
+		Name:        "Клавиатура",
+		Price:       domain.NewMoney(150000, "RUB"),
+		Quantity:    10,
+		Description: "Механическая клавиатура с подсветкой",
+		Version:     3,
+	}
+}
+
 func TestValidateProduct_Success(t *testing.T) {
 	p := &productService{}
 
 	validProduct := domain.Product{
 		Name:        "Клавиатура",
-		Price:       1500,
+		Price:       domain.NewMoney(150000, "RUB"),
 		Quantity:    10,
 		Description: "Механическая клавиатура с подсветкой",
 	}
@@ -29,7 +213,7 @@ func TestValidateProduct_NegativePrice(t *testing.T) {
 
 	invalidProduct := domain.Product{
 		Name:     "Мышка",
-		Price:    -100,
+		Price:    domain.NewMoney(-10000, "RUB"),
 		Quantity: 5,
 	}
 
@@ -42,3 +226,223 @@ func TestValidateProduct_NegativePrice(t *testing.T) {
 		t.Errorf("expected error to be %v, but got %v", ers.ErrInvalidInput, err)
 	}
 }
+
+func TestUpdate_StaleVersion(t *testing.T) {
+	product := testProduct()
+	p, repo := newTestProductService(product)
+
+	name := "Клавиатура беспроводная"
+	_, err := p.Update(context.Background(), product.ID, domain.UpdateProductDTO{Name: &name}, product.Version-1)
+	if !errors.Is(err, ers.ErrPreconditionFailed) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrPreconditionFailed, err)
+	}
+
+	if repo.products[product.ID].Name != product.Name {
+		t.Errorf("expected the product to stay unchanged, but got name %q", repo.products[product.ID].Name)
+	}
+}
+
+func TestUpdate_QuantityBumpsVersionOnce(t *testing.T) {
+	product := testProduct()
+	p, _ := newTestProductService(product)
+
+	quantity := 12
+	updated, err := p.Update(context.Background(), product.ID, domain.UpdateProductDTO{Quantity: &quantity}, product.Version)
+	if err != nil {
+		t.Fatalf("product update failed: %s", err)
+	}
+
+	if updated.Quantity != quantity {
+		t.Errorf("expected quantity %d, but got %d", quantity, updated.Quantity)
+	}
+	if updated.Version != product.Version+1 {
+		t.Errorf("expected version %d, but got %d", product.Version+1, updated.Version)
+	}
+}
+
+func TestDelete_StaleVersion(t *testing.T) {
+	product := testProduct()
+	p, repo := newTestProductService(product)
+
+	err := p.Delete(context.Background(), product.ID, product.Version+1)
+	if !errors.Is(err, ers.ErrPreconditionFailed) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrPreconditionFailed, err)
+	}
+
+	if repo.products[product.ID].DeletedAt != nil {
+		t.Errorf("expected the product not to be deleted")
+	}
+}
+
+func TestUpdate_WarehouseWithoutQuantity(t *testing.T) {
+	product := testProduct()
+	p, _ := newTestProductService(product)
+
+	warehouseID := uuid.New()
+	_, err := p.Update(context.Background(), product.ID, domain.UpdateProductDTO{WarehouseID: &warehouseID}, 0)
+	if !errors.Is(err, ers.ErrInvalidInput) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrInvalidInput, err)
+	}
+}
+
+func TestUpdate_QuantityInWarehouse(t *testing.T) {
+	product := testProduct()
+	p, _ := newTestProductService(product)
+
+	quantity := 7
+	warehouseID := uuid.New()
+	if _, err := p.Update(context.Background(), product.ID, domain.UpdateProductDTO{
+		Quantity:    &quantity,
+		WarehouseID: &warehouseID,
+	}, 0); err != nil {
+		t.Fatalf("product update failed: %s", err)
+	}
+
+	movements := p.stock.(*memoryStock).movements
+	if len(movements) != 1 || movements[0].WarehouseID != warehouseID || movements[0].Quantity != -3 {
+		t.Errorf("expected an adjustment of -3 in warehouse %s, but got %+v", warehouseID, movements)
+	}
+}
+
+func TestSearch_InvalidLimit(t *testing.T) {
+	p := &productService{repo: &searchProducts{}}
+
+	_, err := p.Search(context.Background(), "клавиатура", MaxSearchLimit+1)
+	if !errors.Is(err, ers.ErrInvalidInput) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrInvalidInput, err)
+	}
+}
+
+func TestSearch_BlankQuery(t *testing.T) {
+	p := &productService{repo: &searchProducts{}}
+
+	_, err := p.Search(context.Background(), "   ", 0)
+	if !errors.Is(err, ers.ErrInvalidInput) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrInvalidInput, err)
+	}
+}
+
+func TestSearch_FullTextHit(t *testing.T) {
+	repo := &searchProducts{
+		fullText: []domain.ProductSearchResult{{Product: testProduct(), Match: domain.MatchFullText}},
+		trigram:  []domain.ProductSearchResult{{Product: testProduct(), Match: domain.MatchTrigram}},
+	}
+	p := &productService{repo: repo}
+
+	results, err := p.Search(context.Background(), "клавиатура", 0)
+	if err != nil {
+		t.Fatalf("product search failed: %s", err)
+	}
+
+	if len(results) != 1 || results[0].Match != domain.MatchFullText {
+		t.Errorf("expected one full-text match, but got %+v", results)
+	}
+	if len(repo.limits) != 1 || repo.limits[0] != DefaultSearchLimit {
+		t.Errorf("expected one search with limit %d, but got %v", DefaultSearchLimit, repo.limits)
+	}
+}
+
+func TestSearch_FallsBackToTrigram(t *testing.T) {
+	repo := &searchProducts{
+		trigram: []domain.ProductSearchResult{{Product: testProduct(), Match: domain.MatchTrigram}},
+	}
+	p := &productService{repo: repo}
+
+	results, err := p.Search(context.Background(), "клавиатрура", 5)
+	if err != nil {
+		t.Fatalf("product search failed: %s", err)
+	}
+
+	if len(results) != 1 || results[0].Match != domain.MatchTrigram {
+		t.Errorf("expected one trigram match, but got %+v", results)
+	}
+	if len(repo.limits) != 2 || repo.limits[1] != 5 {
+		t.Errorf("expected a trigram search with limit 5, but got %v", repo.limits)
+	}
+}
+
+func TestDelete_SoftDeletes(t *testing.T) {
+	product := testProduct()
+	p, repo := newTestProductService(product)
+
+	if err := p.Delete(context.Background(), product.ID, product.Version); err != nil {
+		t.Fatalf("product delete failed: %s", err)
+	}
+
+	if repo.products[product.ID].DeletedAt == nil {
+		t.Fatalf("expected the product to be kept with deleted_at set")
+	}
+	if _, err := p.GetById(context.Background(), product.ID, false); !errors.Is(err, ers.ErrProductNotFound) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrProductNotFound, err)
+	}
+
+	events := p.events.(*memoryEvents).events
+	if len(events) != 1 || events[0] != domain.EventProductDeleted {
+		t.Errorf("expected a %s event, but got %v", domain.EventProductDeleted, events)
+	}
+}
+
+func TestDelete_StockInTransit(t *testing.T) {
+	product := testProduct()
+	p, repo := newTestProductService(product)
+	repo.inTransit[product.ID] = 4
+
+	err := p.Delete(context.Background(), product.ID, 0)
+	if !errors.Is(err, ers.ErrProductInTransit) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrProductInTransit, err)
+	}
+
+	if repo.products[product.ID].DeletedAt != nil {
+		t.Errorf("expected the product not to be deleted")
+	}
+}
+
+func TestRestore_Success(t *testing.T) {
+	product := testProduct()
+	deletedAt := time.Now().Add(-time.Hour)
+	product.DeletedAt = &deletedAt
+	p, _ := newTestProductService(product)
+
+	restored, err := p.Restore(context.Background(), product.ID)
+	if err != nil {
+		t.Fatalf("product restore failed: %s", err)
+	}
+
+	if restored.DeletedAt != nil {
+		t.Errorf("expected deleted_at to be cleared, but got %v", restored.DeletedAt)
+	}
+}
+
+func TestRestore_VariantOfDeletedParent(t *testing.T) {
+	parent := testProduct()
+	variant := testProduct()
+	deletedAt := time.Now().Add(-time.Hour)
+	parent.DeletedAt = &deletedAt
+	variant.DeletedAt = &deletedAt
+	variant.ParentID = &parent.ID
+	p, repo := newTestProductService(parent, variant)
+
+	_, err := p.Restore(context.Background(), variant.ID)
+	if !errors.Is(err, ers.ErrInvalidInput) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrInvalidInput, err)
+	}
+
+	if repo.products[variant.ID].DeletedAt == nil {
+		t.Errorf("expected the variant to stay deleted")
+	}
+}
+
+func TestUpdate_RecordsAudit(t *testing.T) {
+	product := testProduct()
+	p, _ := newTestProductService(product)
+
+	name := "Клавиатура беспроводная"
+	if _, err := p.Update(context.Background(), product.ID, domain.UpdateProductDTO{Name: &name}, 0); err != nil {
+		t.Fatalf("product update failed: %s", err)
+	}
+
+	actions := p.audit.(*memoryAudit).actions
+	if len(actions) != 1 || actions[0] != domain.AuditUpdate {
+		t.Errorf("expected one %s audit entry, but got %v", domain.AuditUpdate, actions)
+	}
+}
@@ -0,0 +1,346 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+)
+
+const (
+	MaxVariantAttributes = 5
+	MaxAttributeValues   = 100
+	MaxVariants          = 500
+
+	maxAttributeLength = 64
+)
+
+// SetVariantAttributes replaces the axes of the product's variant matrix.
+// Values already used by live variants cannot be dropped.
+func (p *productService) SetVariantAttributes(
+	ctx context.Context,
+	productID uuid.UUID,
+	attributes []domain.VariantAttribute,
+) (domain.Product, error) {
+	if productID == uuid.Nil {
+		return domain.Product{}, fmt.Errorf("%w: invalid product id", ers.ErrInvalidInput)
+	}
+
+	attributes, err := normalizeVariantAttributes(attributes)
+	if err != nil {
+		return domain.Product{}, err
+	}
+
+	err = p.tx.WithinTx(ctx, func(ctx context.Context) error {
+		product, err := p.repo.GetById(ctx, productID, false)
+		if err != nil {
+			return err
+		}
+		if product.IsVariant() {
+			return fmt.Errorf("%w: a variant cannot have variants of its own", ers.ErrInvalidInput)
+		}
+
+		before, err := p.withVariants(ctx, product)
+		if err != nil {
+			return err
+		}
+		for _, variant := range before.Variants {
+			if err := validateVariantValues(attributes, variant.VariantValues); err != nil {
+				return fmt.Errorf("%w: variant %s does not fit the new attributes: %v", ers.ErrProductHasVariants, variant.ID, err)
+			}
+		}
+
+		if err := p.repo.SetVariantAttributes(ctx, productID, attributes); err != nil {
+			return err
+		}
+
+		after := before
+		after.VariantAttributes = attributes
+		if err := p.audit.Record(ctx, domain.AuditEntityProduct, productID, domain.AuditUpdate, before, after); err != nil {
+			return err
+		}
+		return p.events.Add(ctx, domain.EventProductUpdated, domain.AggregateProduct, productID, after)
+	})
+	if err != nil {
+		return domain.Product{}, err
+	}
+
+	return p.GetById(ctx, productID, false)
+}
+
+// CreateVariant adds one variant under the parent. Name and description
+// default to the parent's; a variant created without InheritsPrice keeps its own price.
+func (p *productService) CreateVariant(
+	ctx context.Context,
+	parentID uuid.UUID,
+	variant domain.Product,
+) (domain.Product, error) {
+	if parentID == uuid.Nil {
+		return domain.Product{}, fmt.Errorf("%w: invalid product id", ers.ErrInvalidInput)
+	}
+
+	var created domain.Product
+	err := p.tx.WithinTx(ctx, func(ctx context.Context) error {
+		parent, attributes, err := p.variantParent(ctx, parentID)
+		if err != nil {
+			return err
+		}
+
+		values := make(map[string]string, len(variant.VariantValues))
+		for name, value := range variant.VariantValues {
+			values[strings.TrimSpace(name)] = strings.TrimSpace(value)
+		}
+		if err := validateVariantValues(attributes, values); err != nil {
+			return err
+		}
+
+		variant.ParentID = &parent.ID
+		variant.VariantValues = values
+		variant.SKU = strings.TrimSpace(variant.SKU)
+		variant.Barcodes = newBarcodes(barcodeCodes(variant.Barcodes))
+		if variant.Name == "" {
+			variant.Name = variantName(parent, attributes, values)
+		}
+		if variant.Description == "" {
+			variant.Description = parent.Description
+		}
+		if variant.InheritsPrice {
+			variant.Price = parent.Price
+		}
+		variant.Price = priceIn(variant.Price, parent.Price.Currency)
+		if err := p.validateProduct(variant); err != nil {
+			return err
+		}
+		if err := p.checkVariantCurrency(ctx, variant); err != nil {
+			return err
+		}
+
+		created, err = p.create(ctx, variant)
+		return err
+	})
+	if err != nil {
+		return domain.Product{}, err
+	}
+
+	return created, nil
+}
+
+// GenerateVariants creates a variant for every combination of attribute values
+// the parent does not have yet. Generated variants inherit the parent's price,
+// start with no stock and get a SKU derived from the parent's when it has one.
+func (p *productService) GenerateVariants(ctx context.Context, parentID uuid.UUID) ([]domain.Product, error) {
+	if parentID == uuid.Nil {
+		return nil, fmt.Errorf("%w: invalid product id", ers.ErrInvalidInput)
+	}
+
+	created := []domain.Product{}
+	err := p.tx.WithinTx(ctx, func(ctx context.Context) error {
+		parent, attributes, err := p.variantParent(ctx, parentID)
+		if err != nil {
+			return err
+		}
+
+		matrix := variantMatrix(attributes)
+		if len(matrix) > MaxVariants {
+			return fmt.Errorf("%w: the matrix has %d combinations, at most %d are allowed", ers.ErrInvalidInput, len(matrix), MaxVariants)
+		}
+
+		existing, err := p.repo.GetVariants(ctx, parentID)
+		if err != nil {
+			return err
+		}
+		taken := make(map[string]bool, len(existing))
+		for _, variant := range existing {
+			taken[variantKey(attributes, variant.VariantValues)] = true
+		}
+
+		for _, values := range matrix {
+			if taken[variantKey(attributes, values)] {
+				continue
+			}
+
+			variant, err := p.create(ctx, domain.Product{
+				ParentID:      &parent.ID,
+				VariantValues: values,
+				SKU:           variantSKU(parent, attributes, values),
+				Name:          variantName(parent, attributes, values),
+				Description:   parent.Description,
+				Price:         parent.Price,
+				InheritsPrice: true,
+			})
+			if err != nil {
+				return err
+			}
+			created = append(created, variant)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// variantParent loads a product that is about to get variants along with its attributes.
+func (p *productService) variantParent(
+	ctx context.Context,
+	parentID uuid.UUID,
+) (domain.Product, []domain.VariantAttribute, error) {
+	parent, err := p.repo.GetById(ctx, parentID, false)
+	if err != nil {
+		return domain.Product{}, nil, err
+	}
+	if parent.IsVariant() {
+		return domain.Product{}, nil, fmt.Errorf("%w: a variant cannot have variants of its own", ers.ErrInvalidInput)
+	}
+
+	attributes, err := p.repo.GetVariantAttributes(ctx, parentID)
+	if err != nil {
+		return domain.Product{}, nil, err
+	}
+	if len(attributes) == 0 {
+		return domain.Product{}, nil, fmt.Errorf("%w: the product has no variant attributes", ers.ErrInvalidInput)
+	}
+
+	return parent, attributes, nil
+}
+
+// withVariants nests the variant attributes and live variants into a parent product.
+func (p *productService) withVariants(ctx context.Context, product domain.Product) (domain.Product, error) {
+	if product.IsVariant() {
+		return product, nil
+	}
+
+	var err error
+	if product.VariantAttributes, err = p.repo.GetVariantAttributes(ctx, product.ID); err != nil {
+		return domain.Product{}, err
+	}
+	if product.Variants, err = p.repo.GetVariants(ctx, product.ID); err != nil {
+		return domain.Product{}, err
+	}
+	return product, nil
+}
+
+func normalizeVariantAttributes(attributes []domain.VariantAttribute) ([]domain.VariantAttribute, error) {
+	if len(attributes) > MaxVariantAttributes {
+		return nil, fmt.Errorf("%w: a product can have at most %d variant attributes", ers.ErrInvalidInput, MaxVariantAttributes)
+	}
+
+	normalized := make([]domain.VariantAttribute, 0, len(attributes))
+	names := make(map[string]bool, len(attributes))
+	for _, attribute := range attributes {
+		name := strings.TrimSpace(attribute.Name)
+		if name == "" || utf8.RuneCountInString(name) > maxAttributeLength {
+			return nil, fmt.Errorf("%w: attribute name must be 1 to %d characters", ers.ErrInvalidInput, maxAttributeLength)
+		}
+		if names[strings.ToLower(name)] {
+			return nil, fmt.Errorf("%w: attribute %q is listed twice", ers.ErrInvalidInput, name)
+		}
+		names[strings.ToLower(name)] = true
+
+		if len(attribute.Values) == 0 || len(attribute.Values) > MaxAttributeValues {
+			return nil, fmt.Errorf("%w: attribute %q needs 1 to %d values", ers.ErrInvalidInput, name, MaxAttributeValues)
+		}
+		values := make([]string, 0, len(attribute.Values))
+		seen := make(map[string]bool, len(attribute.Values))
+		for _, value := range attribute.Values {
+			value = strings.TrimSpace(value)
+			if value == "" || utf8.RuneCountInString(value) > maxAttributeLength {
+				return nil, fmt.Errorf("%w: values of %q must be 1 to %d characters", ers.ErrInvalidInput, name, maxAttributeLength)
+			}
+			if seen[value] {
+				return nil, fmt.Errorf("%w: value %q of %q is listed twice", ers.ErrInvalidInput, value, name)
+			}
+			seen[value] = true
+			values = append(values, value)
+		}
+
+		normalized = append(normalized, domain.VariantAttribute{Name: name, Values: values})
+	}
+
+	return normalized, nil
+}
+
+// validateVariantValues checks that values pick exactly one allowed value for every attribute.
+func validateVariantValues(attributes []domain.VariantAttribute, values map[string]string) error {
+	if len(values) != len(attributes) {
+		return fmt.Errorf("%w: a variant needs a value for each of the %d attributes", ers.ErrInvalidInput, len(attributes))
+	}
+
+	for _, attribute := range attributes {
+		value, ok := values[attribute.Name]
+		if !ok {
+			return fmt.Errorf("%w: missing value for attribute %q", ers.ErrInvalidInput, attribute.Name)
+		}
+		allowed := false
+		for _, v := range attribute.Values {
+			allowed = allowed || v == value
+		}
+		if !allowed {
+			return fmt.Errorf("%w: %q is not a value of attribute %q", ers.ErrInvalidInput, value, attribute.Name)
+		}
+	}
+
+	return nil
+}
+
+// variantMatrix lists every combination of attribute values, varying the last attribute fastest.
+func variantMatrix(attributes []domain.VariantAttribute) []map[string]string {
+	matrix := []map[string]string{{}}
+	for _, attribute := range attributes {
+		next := make([]map[string]string, 0, len(matrix)*len(attribute.Values))
+		for _, combination := range matrix {
+			for _, value := range attribute.Values {
+				values := make(map[string]string, len(combination)+1)
+				for name, v := range combination {
+					values[name] = v
+				}
+				values[attribute.Name] = value
+				next = append(next, values)
+			}
+		}
+		matrix = next
+	}
+	return matrix
+}
+
+// orderedValues returns the variant's values in attribute order.
+func orderedValues(attributes []domain.VariantAttribute, values map[string]string) []string {
+	ordered := make([]string, len(attributes))
+	for i, attribute := range attributes {
+		ordered[i] = values[attribute.Name]
+	}
+	return ordered
+}
+
+func variantKey(attributes []domain.VariantAttribute, values map[string]string) string {
+	return strings.Join(orderedValues(attributes, values), "\x00")
+}
+
+// variantName renders e.g. "Кроссовки (42, красный)".
+func variantName(parent domain.Product, attributes []domain.VariantAttribute, values map[string]string) string {
+	return parent.Name + " (" + strings.Join(orderedValues(attributes, values), ", ") + ")"
+}
+
+// variantSKU derives e.g. SHOE-42-BLACK from the parent's SKU, or returns ""
+// when the parent has no SKU or the values do not fit the SKU alphabet.
+func variantSKU(parent domain.Product, attributes []domain.VariantAttribute, values map[string]string) string {
+	if parent.SKU == "" {
+		return ""
+	}
+
+	parts := []string{parent.SKU}
+	for _, value := range orderedValues(attributes, values) {
+		parts = append(parts, strings.ToUpper(strings.ReplaceAll(value, " ", "_")))
+	}
+	sku := strings.Join(parts, "-")
+	if validateSKU(sku) != nil {
+		return ""
+	}
+	return sku
+}
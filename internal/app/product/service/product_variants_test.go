@@ -0,0 +1,55 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+)
+
+var shoeAttributes = []domain.VariantAttribute{
+	{Name: "size", Values: []string{"41", "42", "43"}},
+	{Name: "colour", Values: []string{"black", "red"}},
+}
+
+func TestVariantMatrix_AllCombinations(t *testing.T) {
+	matrix := variantMatrix(shoeAttributes)
+
+	if len(matrix) != 6 {
+		t.Fatalf("expected 6 combinations, but got %d", len(matrix))
+	}
+	if matrix[1]["size"] != "41" || matrix[1]["colour"] != "red" {
+		t.Errorf("expected the second combination to be 41/red, but got %v", matrix[1])
+	}
+
+	seen := map[string]bool{}
+	for _, values := range matrix {
+		if err := validateVariantValues(shoeAttributes, values); err != nil {
+			t.Errorf("expected %v to be valid, but got %v", values, err)
+		}
+		seen[variantKey(shoeAttributes, values)] = true
+	}
+	if len(seen) != 6 {
+		t.Errorf("expected 6 distinct combinations, but got %d", len(seen))
+	}
+}
+
+func TestValidateVariantValues_UnknownValue(t *testing.T) {
+	err := validateVariantValues(shoeAttributes, map[string]string{"size": "44", "colour": "black"})
+	if !errors.Is(err, ers.ErrInvalidInput) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrInvalidInput, err)
+	}
+}
+
+func TestVariantSKU_FromParent(t *testing.T) {
+	parent := domain.Product{SKU: "SHOE-1", Name: "Кроссовки"}
+	values := map[string]string{"size": "42", "colour": "black"}
+
+	if sku := variantSKU(parent, shoeAttributes, values); sku != "SHOE-1-42-BLACK" {
+		t.Errorf("expected sku SHOE-1-42-BLACK, but got %q", sku)
+	}
+	if name := variantName(parent, shoeAttributes, values); name != "Кроссовки (42, black)" {
+		t.Errorf("expected name \"Кроссовки (42, black)\", but got %q", name)
+	}
+}
@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+type CreateReservationRequest struct {
+	ProductID   uuid.UUID `json:"product_id"`
+	WarehouseID uuid.UUID `json:"warehouse_id"`
+	Quantity    int       `json:"quantity"`
+	TTLSeconds  int       `json:"ttl_seconds"`
+}
+
+func (r *CreateReservationRequest) ToDomain() domain.CreateReservationDTO {
+	return domain.CreateReservationDTO{
+		ProductID:   r.ProductID,
+		WarehouseID: r.WarehouseID,
+		Quantity:    r.Quantity,
+		TTL:         time.Duration(r.TTLSeconds) * time.Second,
+	}
+}
@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	core "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/repository"
+)
+
+type PostgresReservationRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresReservationRepository(db *sql.DB) *PostgresReservationRepository {
+	return &PostgresReservationRepository{
+		db: db,
+	}
+}
+
+func (i *PostgresReservationRepository) Create(
+	ctx context.Context,
+	r *domain.Reservation,
+) (domain.Reservation, error) {
+	query := `
+		INSERT INTO reservations (id, product_id, warehouse_id, quantity, status, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	if _, err := core.Conn(ctx, i.db).ExecContext(
+		ctx,
+		query,
+		r.ID,
+		r.ProductID,
+		r.WarehouseID,
+		r.Quantity,
+		r.Status,
+		r.ExpiresAt,
+		r.CreatedAt,
+		r.UpdatedAt,
+	); err != nil {
+		return domain.Reservation{}, fmt.Errorf("error inserting reservation: %w", err)
+	}
+
+	return *r, nil
+}
+
+func (i *PostgresReservationRepository) GetById(
+	ctx context.Context,
+	id uuid.UUID,
+) (domain.Reservation, error) {
+	query := `
+		SELECT id, product_id, warehouse_id, quantity, status, expires_at, created_at, updated_at
+		FROM reservations
+		WHERE id = $1
+	`
+
+	return i.get(ctx, query, id)
+}
+
+func (i *PostgresReservationRepository) GetByIdForUpdate(
+	ctx context.Context,
+	id uuid.UUID,
+) (domain.Reservation, error) {
+	query := `
+		SELECT id, product_id, warehouse_id, quantity, status, expires_at, created_at, updated_at
+		FROM reservations
+		WHERE id = $1
+		FOR UPDATE
+	`
+
+	return i.get(ctx, query, id)
+}
+
+// ResolveWarehouse returns the default warehouse for uuid.Nil and checks that an explicit one exists.
+func (i *PostgresReservationRepository) ResolveWarehouse(
+	ctx context.Context,
+	id uuid.UUID,
+) (uuid.UUID, error) {
+	conn := core.Conn(ctx, i.db)
+
+	if id == uuid.Nil {
+		if err := conn.QueryRowContext(ctx, `SELECT id FROM warehouses WHERE is_default`).Scan(&id); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return uuid.Nil, fmt.Errorf("%w: no default warehouse configured", ers.ErrWarehouseNotFound)
+			}
+			return uuid.Nil, err
+		}
+		return id, nil
+	}
+
+	var exists bool
+	if err := conn.QueryRowContext(
+		ctx,
+		`SELECT EXISTS (SELECT 1 FROM warehouses WHERE id = $1)`,
+		id,
+	).Scan(&exists); err != nil {
+		return uuid.Nil, err
+	}
+	if !exists {
+		return uuid.Nil, fmt.Errorf("%w: warehouse not found", ers.ErrWarehouseNotFound)
+	}
+
+	return id, nil
+}
+
+// AvailableForUpdate returns what the warehouse holds of the product minus its
+// active reservations there. It locks the product row so concurrent holds on
+// the same product are serialised.
+func (i *PostgresReservationRepository) AvailableForUpdate(
+	ctx context.Context,
+	productID uuid.UUID,
+	warehouseID uuid.UUID,
+) (int, error) {
+	conn := core.Conn(ctx, i.db)
+
+	var locked uuid.UUID
+	if err := conn.QueryRowContext(
+		ctx,
+		`SELECT id FROM products WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`,
+		productID,
+	).Scan(&locked); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("%w: product not found", ers.ErrProductNotFound)
+		}
+		return 0, err
+	}
+
+	var quantity int
+	if err := conn.QueryRowContext(
+		ctx,
+		`SELECT quantity FROM warehouse_stock WHERE warehouse_id = $1 AND product_id = $2`,
+		warehouseID,
+		productID,
+	).Scan(&quantity); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("error reading warehouse stock: %w", err)
+	}
+
+	var reserved int
+	if err := conn.QueryRowContext(
+		ctx,
+		`
+		SELECT COALESCE(SUM(quantity), 0)
+		FROM reservations
+		WHERE product_id = $1 AND warehouse_id = $2 AND status = 'active' AND expires_at > NOW()
+		`,
+		productID,
+		warehouseID,
+	).Scan(&reserved); err != nil {
+		return 0, fmt.Errorf("error summing reservations: %w", err)
+	}
+
+	return quantity - reserved, nil
+}
+
+func (i *PostgresReservationRepository) UpdateStatus(
+	ctx context.Context,
+	id uuid.UUID,
+	status domain.ReservationStatus,
+	updatedAt time.Time,
+) (domain.Reservation, error) {
+	query := `
+		UPDATE reservations
+		SET status = $1, updated_at = $2
+		WHERE id = $3
+		RETURNING id, product_id, warehouse_id, quantity, status, expires_at, created_at, updated_at
+	`
+
+	return i.get(ctx, query, status, updatedAt, id)
+}
+
+func (i *PostgresReservationRepository) ExpireOverdue(
+	ctx context.Context,
+	now time.Time,
+) (int64, error) {
+	query := `
+		UPDATE reservations
+		SET status = 'expired', updated_at = $1
+		WHERE status = 'active' AND expires_at <= $1
+	`
+
+	result, err := core.Conn(ctx, i.db).ExecContext(ctx, query, now)
+	if err != nil {
+		return 0, fmt.Errorf("error expiring reservations: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+func (i *PostgresReservationRepository) get(
+	ctx context.Context,
+	query string,
+	args ...interface{},
+) (domain.Reservation, error) {
+	var reservation domain.Reservation
+
+	if err := core.Conn(ctx, i.db).QueryRowContext(ctx, query, args...).Scan(
+		&reservation.ID,
+		&reservation.ProductID,
+		&reservation.WarehouseID,
+		&reservation.Quantity,
+		&reservation.Status,
+		&reservation.ExpiresAt,
+		&reservation.CreatedAt,
+		&reservation.UpdatedAt,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Reservation{}, fmt.Errorf("%w: reservation not found", ers.ErrReservationNotFound)
+		}
+		return domain.Reservation{}, err
+	}
+
+	return reservation, nil
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+type ReservationRepository interface {
+	Create(ctx context.Context, r *domain.Reservation) (domain.Reservation, error)
+	GetById(ctx context.Context, id uuid.UUID) (domain.Reservation, error)
+	GetByIdForUpdate(ctx context.Context, id uuid.UUID) (domain.Reservation, error)
+	ResolveWarehouse(ctx context.Context, id uuid.UUID) (uuid.UUID, error)
+	AvailableForUpdate(ctx context.Context, productID uuid.UUID, warehouseID uuid.UUID) (int, error)
+	UpdateStatus(ctx context.Context, id uuid.UUID, status domain.ReservationStatus, updatedAt time.Time) (domain.Reservation, error)
+	ExpireOverdue(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/reservation/repository"
+	stock "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/stock/service"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	core "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/repository"
+)
+
+type reservationService struct {
+	repo       repository.ReservationRepository
+	stock      stock.StockService
+	tx         core.Transactor
+	defaultTTL time.Duration
+	maxTTL     time.Duration
+}
+
+func NewReservationService(
+	repo repository.ReservationRepository,
+	stock stock.StockService,
+	tx core.Transactor,
+	defaultTTL time.Duration,
+	maxTTL time.Duration,
+) ReservationService {
+	return &reservationService{
+		repo:       repo,
+		stock:      stock,
+		tx:         tx,
+		defaultTTL: defaultTTL,
+		maxTTL:     maxTTL,
+	}
+}
+
+func (s *reservationService) Create(
+	ctx context.Context,
+	dto domain.CreateReservationDTO,
+) (domain.Reservation, error) {
+	if err := s.validateReservation(dto); err != nil {
+		return domain.Reservation{}, err
+	}
+
+	ttl := dto.TTL
+	if ttl == 0 {
+		ttl = s.defaultTTL
+	}
+
+	now := time.Now()
+	reservation := domain.Reservation{
+		ID:        uuid.New(),
+		ProductID: dto.ProductID,
+		Quantity:  dto.Quantity,
+		Status:    domain.ReservationActive,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	var created domain.Reservation
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if reservation.WarehouseID, err = s.repo.ResolveWarehouse(ctx, dto.WarehouseID); err != nil {
+			return err
+		}
+
+		available, err := s.repo.AvailableForUpdate(ctx, dto.ProductID, reservation.WarehouseID)
+		if err != nil {
+			return err
+		}
+		if available < dto.Quantity {
+			return fmt.Errorf("%w: requested %d, available %d", ers.ErrInsufficientStock, dto.Quantity, available)
+		}
+
+		created, err = s.repo.Create(ctx, &reservation)
+		return err
+	})
+	if err != nil {
+		return domain.Reservation{}, err
+	}
+
+	return created, nil
+}
+
+func (s *reservationService) GetById(ctx context.Context, id uuid.UUID) (domain.Reservation, error) {
+	if id == uuid.Nil {
+		return domain.Reservation{}, fmt.Errorf("%w: invalid reservation id", ers.ErrInvalidInput)
+	}
+	return s.repo.GetById(ctx, id)
+}
+
+// Confirm turns the hold into a sale: the reserved quantity leaves the on-hand
+// stock of the warehouse the hold was made in.
+func (s *reservationService) Confirm(ctx context.Context, id uuid.UUID) (domain.Reservation, error) {
+	return s.finish(ctx, id, domain.ReservationConfirmed)
+}
+
+func (s *reservationService) Cancel(ctx context.Context, id uuid.UUID) (domain.Reservation, error) {
+	return s.finish(ctx, id, domain.ReservationCancelled)
+}
+
+func (s *reservationService) ReleaseExpired(ctx context.Context) (int64, error) {
+	return s.repo.ExpireOverdue(ctx, time.Now())
+}
+
+func (s *reservationService) finish(
+	ctx context.Context,
+	id uuid.UUID,
+	status domain.ReservationStatus,
+) (domain.Reservation, error) {
+	if id == uuid.Nil {
+		return domain.Reservation{}, fmt.Errorf("%w: invalid reservation id", ers.ErrInvalidInput)
+	}
+
+	var updated domain.Reservation
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := s.repo.GetByIdForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if current.Status != domain.ReservationActive || !current.ExpiresAt.After(now) {
+			return fmt.Errorf("%w: reservation is %s", ers.ErrReservationNotActive, current.Status)
+		}
+
+		// the hold is released first so the sale can take the stock it protected
+		if updated, err = s.repo.UpdateStatus(ctx, id, status, now); err != nil {
+			return err
+		}
+
+		if status == domain.ReservationConfirmed {
+			if _, err := s.stock.Record(ctx, domain.RecordMovementDTO{
+				ProductID:     current.ProductID,
+				WarehouseID:   current.WarehouseID,
+				Type:          domain.MovementSale,
+				Quantity:      current.Quantity,
+				ReasonCode:    "reservation_confirmed",
+				CorrelationID: current.ID.String(),
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.Reservation{}, err
+	}
+
+	return updated, nil
+}
+
+func (s *reservationService) validateReservation(dto domain.CreateReservationDTO) error {
+	if dto.ProductID == uuid.Nil {
+		return fmt.Errorf("%w: product id is required", ers.ErrInvalidInput)
+	}
+	if dto.Quantity <= 0 {
+		return fmt.Errorf("%w: reservation quantity must be positive", ers.ErrInvalidInput)
+	}
+	if dto.TTL < 0 {
+		return fmt.Errorf("%w: reservation ttl cannot be negative", ers.ErrInvalidInput)
+	}
+	if dto.TTL > s.maxTTL {
+		return fmt.Errorf("%w: reservation ttl cannot exceed %s", ers.ErrInvalidInput, s.maxTTL)
+	}
+	return nil
+}
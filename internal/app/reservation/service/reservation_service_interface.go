@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+type ReservationService interface {
+	Create(ctx context.Context, dto domain.CreateReservationDTO) (domain.Reservation, error)
+	GetById(ctx context.Context, id uuid.UUID) (domain.Reservation, error)
+	Confirm(ctx context.Context, id uuid.UUID) (domain.Reservation, error)
+	Cancel(ctx context.Context, id uuid.UUID) (domain.Reservation, error)
+	ReleaseExpired(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,310 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/reservation/repository"
+	stock "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/stock/service"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+)
+
+type inlineTx struct{}
+
+func (inlineTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// memoryReservations keeps reservations in a map; warehouseID stands in for
+// the default warehouse and availableIn records where stock was checked.
+type memoryReservations struct {
+	repository.ReservationRepository
+	reservations map[uuid.UUID]domain.Reservation
+	available    int
+	warehouseID  uuid.UUID
+	availableIn  uuid.UUID
+	calls        []string
+}
+
+func (m *memoryReservations) Create(ctx context.Context, r *domain.Reservation) (domain.Reservation, error) {
+	m.reservations[r.ID] = *r
+	return *r, nil
+}
+
+func (m *memoryReservations) GetByIdForUpdate(ctx context.Context, id uuid.UUID) (domain.Reservation, error) {
+	r, ok := m.reservations[id]
+	if !ok {
+		return domain.Reservation{}, ers.ErrReservationNotFound
+	}
+	return r, nil
+}
+
+func (m *memoryReservations) ResolveWarehouse(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	if id == uuid.Nil {
+		return m.warehouseID, nil
+	}
+	return id, nil
+}
+
+func (m *memoryReservations) AvailableForUpdate(ctx context.Context, productID uuid.UUID, warehouseID uuid.UUID) (int, error) {
+	m.availableIn = warehouseID
+	return m.available, nil
+}
+
+func (m *memoryReservations) UpdateStatus(
+	ctx context.Context,
+	id uuid.UUID,
+	status domain.ReservationStatus,
+	updatedAt time.Time,
+) (domain.Reservation, error) {
+	m.calls = append(m.calls, "update_status")
+	r := m.reservations[id]
+	r.Status = status
+	r.UpdatedAt = updatedAt
+	m.reservations[id] = r
+	return r, nil
+}
+
+type recordingStock struct {
+	stock.StockService
+	movements []domain.RecordMovementDTO
+	calls     *[]string
+}
+
+func (s *recordingStock) Record(ctx context.Context, dto domain.RecordMovementDTO) (domain.StockMovement, error) {
+	*s.calls = append(*s.calls, "record")
+	s.movements = append(s.movements, dto)
+	return domain.StockMovement{ProductID: dto.ProductID, WarehouseID: dto.WarehouseID}, nil
+}
+
+func newTestReservationService(repo *memoryReservations) (*reservationService, *recordingStock) {
+	sales := &recordingStock{calls: &repo.calls}
+	return &reservationService{
+		repo:       repo,
+		stock:      sales,
+		tx:         inlineTx{},
+		defaultTTL: 15 * time.Minute,
+		maxTTL:     24 * time.Hour,
+	}, sales
+}
+
+func TestValidateReservation_Success(t *testing.T) {
+	s := &reservationService{maxTTL: time.Hour}
+
+	dto := domain.CreateReservationDTO{
+		ProductID: uuid.New(),
+		Quantity:  2,
+		TTL:       30 * time.Minute,
+	}
+
+	if err := s.validateReservation(dto); err != nil {
+		t.Fatalf("reservation validation failed: %s", err)
+	}
+}
+
+func TestValidateReservation_NonPositiveQuantity(t *testing.T) {
+	s := &reservationService{maxTTL: time.Hour}
+
+	dto := domain.CreateReservationDTO{
+		ProductID: uuid.New(),
+		Quantity:  0,
+	}
+
+	err := s.validateReservation(dto)
+	if err == nil {
+		t.Fatalf("expected error for zero quantity, but got nil")
+	}
+
+	if !errors.Is(err, ers.ErrInvalidInput) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrInvalidInput, err)
+	}
+}
+
+func TestValidateReservation_TTLOverMax(t *testing.T) {
+	s := &reservationService{maxTTL: time.Hour}
+
+	dto := domain.CreateReservationDTO{
+		ProductID: uuid.New(),
+		Quantity:  1,
+		TTL:       2 * time.Hour,
+	}
+
+	if err := s.validateReservation(dto); !errors.Is(err, ers.ErrInvalidInput) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrInvalidInput, err)
+	}
+}
+
+func TestCreate_DefaultTTL(t *testing.T) {
+	repo := &memoryReservations{reservations: map[uuid.UUID]domain.Reservation{}, available: 5}
+	s, _ := newTestReservationService(repo)
+
+	created, err := s.Create(context.Background(), domain.CreateReservationDTO{ProductID: uuid.New(), Quantity: 5})
+	if err != nil {
+		t.Fatalf("reservation create failed: %s", err)
+	}
+
+	if created.Status != domain.ReservationActive {
+		t.Errorf("expected status %s, but got %s", domain.ReservationActive, created.Status)
+	}
+	if ttl := created.ExpiresAt.Sub(created.CreatedAt); ttl != s.defaultTTL {
+		t.Errorf("expected ttl %s, but got %s", s.defaultTTL, ttl)
+	}
+}
+
+func TestCreate_InsufficientStock(t *testing.T) {
+	repo := &memoryReservations{reservations: map[uuid.UUID]domain.Reservation{}, available: 2}
+	s, _ := newTestReservationService(repo)
+
+	_, err := s.Create(context.Background(), domain.CreateReservationDTO{ProductID: uuid.New(), Quantity: 3})
+	if !errors.Is(err, ers.ErrInsufficientStock) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrInsufficientStock, err)
+	}
+
+	if len(repo.reservations) != 0 {
+		t.Errorf("expected no reservation to be stored, but got %d", len(repo.reservations))
+	}
+}
+
+func TestConfirm_RecordsSale(t *testing.T) {
+	reservation := domain.Reservation{
+		ID:        uuid.New(),
+		ProductID: uuid.New(),
+		Quantity:  4,
+		Status:    domain.ReservationActive,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	repo := &memoryReservations{reservations: map[uuid.UUID]domain.Reservation{reservation.ID: reservation}}
+	s, sales := newTestReservationService(repo)
+
+	confirmed, err := s.Confirm(context.Background(), reservation.ID)
+	if err != nil {
+		t.Fatalf("reservation confirm failed: %s", err)
+	}
+
+	if confirmed.Status != domain.ReservationConfirmed {
+		t.Errorf("expected status %s, but got %s", domain.ReservationConfirmed, confirmed.Status)
+	}
+	if len(sales.movements) != 1 {
+		t.Fatalf("expected one stock movement, but got %d", len(sales.movements))
+	}
+
+	sale := sales.movements[0]
+	if sale.Type != domain.MovementSale || sale.Quantity != reservation.Quantity || sale.CorrelationID != reservation.ID.String() {
+		t.Errorf("expected a sale of %d for reservation %s, but got %+v", reservation.Quantity, reservation.ID, sale)
+	}
+	// the hold must be released before the sale, or the sale is blocked by it
+	if len(repo.calls) != 2 || repo.calls[0] != "update_status" {
+		t.Errorf("expected the status update before the sale, but got %v", repo.calls)
+	}
+}
+
+func TestCancel_NoStockMovement(t *testing.T) {
+	reservation := domain.Reservation{
+		ID:        uuid.New(),
+		ProductID: uuid.New(),
+		Quantity:  1,
+		Status:    domain.ReservationActive,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	repo := &memoryReservations{reservations: map[uuid.UUID]domain.Reservation{reservation.ID: reservation}}
+	s, sales := newTestReservationService(repo)
+
+	cancelled, err := s.Cancel(context.Background(), reservation.ID)
+	if err != nil {
+		t.Fatalf("reservation cancel failed: %s", err)
+	}
+
+	if cancelled.Status != domain.ReservationCancelled {
+		t.Errorf("expected status %s, but got %s", domain.ReservationCancelled, cancelled.Status)
+	}
+	if len(sales.movements) != 0 {
+		t.Errorf("expected no stock movement, but got %d", len(sales.movements))
+	}
+}
+
+func TestConfirm_ExpiredReservation(t *testing.T) {
+	reservation := domain.Reservation{
+		ID:        uuid.New(),
+		ProductID: uuid.New(),
+		Quantity:  1,
+		Status:    domain.ReservationActive,
+		ExpiresAt: time.Now().Add(-time.Minute),
+	}
+	repo := &memoryReservations{reservations: map[uuid.UUID]domain.Reservation{reservation.ID: reservation}}
+	s, sales := newTestReservationService(repo)
+
+	_, err := s.Confirm(context.Background(), reservation.ID)
+	if !errors.Is(err, ers.ErrReservationNotActive) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrReservationNotActive, err)
+	}
+
+	if len(sales.movements) != 0 {
+		t.Errorf("expected no stock movement, but got %d", len(sales.movements))
+	}
+}
+
+func TestCreate_DefaultWarehouse(t *testing.T) {
+	repo := &memoryReservations{
+		reservations: map[uuid.UUID]domain.Reservation{},
+		available:    1,
+		warehouseID:  uuid.New(),
+	}
+	s, _ := newTestReservationService(repo)
+
+	created, err := s.Create(context.Background(), domain.CreateReservationDTO{ProductID: uuid.New(), Quantity: 1})
+	if err != nil {
+		t.Fatalf("reservation create failed: %s", err)
+	}
+
+	if created.WarehouseID != repo.warehouseID || repo.availableIn != repo.warehouseID {
+		t.Errorf("expected the hold in warehouse %s, but got %s checked in %s", repo.warehouseID, created.WarehouseID, repo.availableIn)
+	}
+}
+
+func TestCreate_ChecksRequestedWarehouse(t *testing.T) {
+	repo := &memoryReservations{
+		reservations: map[uuid.UUID]domain.Reservation{},
+		available:    1,
+		warehouseID:  uuid.New(),
+	}
+	s, _ := newTestReservationService(repo)
+
+	warehouseID := uuid.New()
+	created, err := s.Create(context.Background(), domain.CreateReservationDTO{
+		ProductID:   uuid.New(),
+		WarehouseID: warehouseID,
+		Quantity:    1,
+	})
+	if err != nil {
+		t.Fatalf("reservation create failed: %s", err)
+	}
+
+	if created.WarehouseID != warehouseID || repo.availableIn != warehouseID {
+		t.Errorf("expected the hold in warehouse %s, but got %s checked in %s", warehouseID, created.WarehouseID, repo.availableIn)
+	}
+}
+
+func TestConfirm_SellsFromReservationWarehouse(t *testing.T) {
+	reservation := domain.Reservation{
+		ID:          uuid.New(),
+		ProductID:   uuid.New(),
+		WarehouseID: uuid.New(),
+		Quantity:    2,
+		Status:      domain.ReservationActive,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+	repo := &memoryReservations{reservations: map[uuid.UUID]domain.Reservation{reservation.ID: reservation}}
+	s, sales := newTestReservationService(repo)
+
+	if _, err := s.Confirm(context.Background(), reservation.ID); err != nil {
+		t.Fatalf("reservation confirm failed: %s", err)
+	}
+
+	if len(sales.movements) != 1 || sales.movements[0].WarehouseID != reservation.WarehouseID {
+		t.Errorf("expected a sale from warehouse %s, but got %+v", reservation.WarehouseID, sales.movements)
+	}
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/logger"
+)
+
+type Sweeper struct {
+	service  ReservationService
+	interval time.Duration
+	logger   logger.Logger
+}
+
+func NewSweeper(service ReservationService, interval time.Duration, logger logger.Logger) *Sweeper {
+	return &Sweeper{
+		service:  service,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+// Run releases expired reservations every interval until ctx is cancelled.
+func (s *Sweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			released, err := s.service.ReleaseExpired(ctx)
+			if err != nil {
+				s.logger.Error("failed to release expired reservations: %v", err)
+				continue
+			}
+			if released > 0 {
+				s.logger.Info("released %d expired reservations", released)
+			}
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/stock/service"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/logger"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/response"
+)
+
+const HeaderCorrelationID = "X-Correlation-ID"
+
+type StockHandler struct {
+	service service.StockService
+	logger  logger.Logger
+}
+
+func NewStockHandler(service service.StockService, logger logger.Logger) *StockHandler {
+	return &StockHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *StockHandler) Record(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodPost) {
+		return
+	}
+
+	id, err := response.PathID(r, h.logger, "id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	var req RecordMovementRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, h.logger, fmt.Errorf("%w: invalid body", ers.ErrInvalidInput))
+		return
+	}
+	if req.CorrelationID == "" {
+		req.CorrelationID = r.Header.Get(HeaderCorrelationID)
+	}
+
+	movement, err := h.service.Record(r.Context(), req.ToDomain(id))
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusCreated, movement)
+}
+
+func (h *StockHandler) Adjust(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodPost) {
+		return
+	}
+
+	id, err := response.PathID(r, h.logger, "id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	var req AdjustStockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, h.logger, fmt.Errorf("%w: invalid body", ers.ErrInvalidInput))
+		return
+	}
+	if req.CorrelationID == "" {
+		req.CorrelationID = r.Header.Get(HeaderCorrelationID)
+	}
+
+	balance, err := h.service.Adjust(r.Context(), req.ToDomain(id))
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusOK, balance)
+}
+
+func (h *StockHandler) List(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodGet) {
+		return
+	}
+
+	id, err := response.PathID(r, h.logger, "id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	var filter domain.MovementFilter
+	if filter.From, err = response.QueryTime(r, "from"); err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+	if filter.To, err = response.QueryTime(r, "to"); err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+	if value := r.URL.Query().Get("warehouse_id"); value != "" {
+		warehouseID, err := uuid.Parse(value)
+		if err != nil {
+			response.Error(w, h.logger, fmt.Errorf("%w: invalid warehouse_id", ers.ErrInvalidInput))
+			return
+		}
+		filter.WarehouseID = &warehouseID
+	}
+
+	movements, err := h.service.List(r.Context(), id, filter)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusOK, movements)
+}
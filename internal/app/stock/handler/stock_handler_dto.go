@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+type AdjustStockRequest struct {
+	WarehouseID   uuid.UUID `json:"warehouse_id"`
+	Delta         int       `json:"delta"`
+	ReasonCode    string    `json:"reason_code"`
+	CorrelationID string    `json:"correlation_id"`
+}
+
+func (r *AdjustStockRequest) ToDomain(productID uuid.UUID) domain.AdjustStockDTO {
+	return domain.AdjustStockDTO{
+		ProductID:     productID,
+		WarehouseID:   r.WarehouseID,
+		Delta:         r.Delta,
+		ReasonCode:    r.ReasonCode,
+		CorrelationID: r.CorrelationID,
+	}
+}
+
+type RecordMovementRequest struct {
+	WarehouseID   uuid.UUID           `json:"warehouse_id"`
+	Type          domain.MovementType `json:"type"`
+	Quantity      int                 `json:"quantity"`
+	ReasonCode    string              `json:"reason_code"`
+	CorrelationID string              `json:"correlation_id"`
+}
+
+func (r *RecordMovementRequest) ToDomain(productID uuid.UUID) domain.RecordMovementDTO {
+	return domain.RecordMovementDTO{
+		ProductID:     productID,
+		WarehouseID:   r.WarehouseID,
+		Type:          r.Type,
+		Quantity:      r.Quantity,
+		ReasonCode:    r.ReasonCode,
+		CorrelationID: r.CorrelationID,
+	}
+}
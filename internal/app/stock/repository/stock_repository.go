@@ -0,0 +1,287 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	core "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/repository"
+)
+
+type PostgresStockRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresStockRepository(db *sql.DB) *PostgresStockRepository {
+	return &PostgresStockRepository{
+		db: db,
+	}
+}
+
+// Apply changes the warehouse and product balances and appends the movement.
+// It must run inside a transaction.
+func (i *PostgresStockRepository) Apply(
+	ctx context.Context,
+	m *domain.StockMovement,
+	bumpVersion bool,
+) (domain.StockMovement, error) {
+	conn := core.Conn(ctx, i.db)
+
+	if err := i.resolveWarehouse(ctx, m); err != nil {
+		return domain.StockMovement{}, err
+	}
+
+	versionStep := 0
+	if bumpVersion {
+		versionStep = 1
+	}
+
+	// a decrease may not dip into stock held by active reservations
+	balanceQuery := `
+		UPDATE products p
+		SET quantity = p.quantity + $1, updated_at = $2, version = p.version + $4
+		WHERE p.id = $3 AND p.deleted_at IS NULL
+		  AND ($1 >= 0 OR p.quantity + $1 >= (
+			SELECT COALESCE(SUM(r.quantity), 0)
+			FROM reservations r
+			WHERE r.product_id = p.id AND r.status = 'active' AND r.expires_at > NOW()
+		  ))
+		RETURNING p.quantity
+	`
+
+	if err := conn.QueryRowContext(ctx, balanceQuery, m.Quantity, m.CreatedAt, m.ProductID, versionStep).Scan(&m.BalanceAfter); err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return domain.StockMovement{}, fmt.Errorf("error updating balance: %w", err)
+		}
+
+		var exists bool
+		if err := conn.QueryRowContext(
+			ctx,
+			`SELECT EXISTS (SELECT 1 FROM products WHERE id = $1 AND deleted_at IS NULL)`,
+			m.ProductID,
+		).Scan(&exists); err != nil {
+			return domain.StockMovement{}, err
+		}
+		if !exists {
+			return domain.StockMovement{}, fmt.Errorf("%w: product not found", ers.ErrProductNotFound)
+		}
+		return domain.StockMovement{}, fmt.Errorf("%w: stock cannot go below the reserved quantity", ers.ErrInsufficientStock)
+	}
+
+	if err := i.applyToWarehouse(ctx, m); err != nil {
+		return domain.StockMovement{}, err
+	}
+
+	insertQuery := `
+		INSERT INTO stock_movements (
+			id, product_id, warehouse_id, type, quantity, balance_after, warehouse_balance_after,
+			reason_code, actor, correlation_id, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	if _, err := conn.ExecContext(
+		ctx,
+		insertQuery,
+		m.ID,
+		m.ProductID,
+		m.WarehouseID,
+		m.Type,
+		m.Quantity,
+		m.BalanceAfter,
+		m.WarehouseBalanceAfter,
+		m.ReasonCode,
+		m.Actor,
+		m.CorrelationID,
+		m.CreatedAt,
+	); err != nil {
+		return domain.StockMovement{}, fmt.Errorf("error inserting stock movement: %w", err)
+	}
+
+	return *m, nil
+}
+
+func (i *PostgresStockRepository) List(
+	ctx context.Context,
+	productID uuid.UUID,
+	filter domain.MovementFilter,
+) ([]domain.StockMovement, error) {
+	movements := []domain.StockMovement{}
+
+	query := `
+		SELECT id, product_id, warehouse_id, type, quantity, balance_after, warehouse_balance_after,
+		       reason_code, actor, correlation_id, created_at
+		FROM stock_movements
+		WHERE product_id = $1
+		  AND ($2::timestamptz IS NULL OR created_at >= $2)
+		  AND ($3::timestamptz IS NULL OR created_at < $3)
+		  AND ($4::uuid IS NULL OR warehouse_id = $4)
+		ORDER BY created_at, id
+	`
+
+	rows, err := core.Conn(ctx, i.db).QueryContext(
+		ctx,
+		query,
+		productID,
+		filter.From,
+		filter.To,
+		filter.WarehouseID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		if err := rows.Close(); err != nil {
+		}
+	}(rows)
+
+	for rows.Next() {
+		var movement domain.StockMovement
+
+		if err := rows.Scan(
+			&movement.ID,
+			&movement.ProductID,
+			&movement.WarehouseID,
+			&movement.Type,
+			&movement.Quantity,
+			&movement.BalanceAfter,
+			&movement.WarehouseBalanceAfter,
+			&movement.ReasonCode,
+			&movement.Actor,
+			&movement.CorrelationID,
+			&movement.CreatedAt,
+		); err != nil {
+			return movements, err
+		}
+
+		movements = append(movements, movement)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return movements, nil
+}
+
+func (i *PostgresStockRepository) Levels(
+	ctx context.Context,
+	productID uuid.UUID,
+) ([]domain.WarehouseStock, error) {
+	levels := []domain.WarehouseStock{}
+
+	query := `
+		SELECT s.warehouse_id, w.code, s.product_id, s.quantity
+		FROM warehouse_stock s
+		JOIN warehouses w ON w.id = s.warehouse_id
+		WHERE s.product_id = $1
+		ORDER BY w.code
+	`
+
+	rows, err := core.Conn(ctx, i.db).QueryContext(ctx, query, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		if err := rows.Close(); err != nil {
+		}
+	}(rows)
+
+	for rows.Next() {
+		var level domain.WarehouseStock
+
+		if err := rows.Scan(
+			&level.WarehouseID,
+			&level.WarehouseCode,
+			&level.ProductID,
+			&level.Quantity,
+		); err != nil {
+			return levels, err
+		}
+
+		levels = append(levels, level)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return levels, nil
+}
+
+func (i *PostgresStockRepository) applyToWarehouse(ctx context.Context, m *domain.StockMovement) error {
+	conn := core.Conn(ctx, i.db)
+
+	// as for the product total, a decrease may not take stock reserved in this warehouse
+	updateQuery := `
+		UPDATE warehouse_stock s
+		SET quantity = s.quantity + $1
+		WHERE s.warehouse_id = $2 AND s.product_id = $3
+		  AND ($1 >= 0 OR s.quantity + $1 >= (
+			SELECT COALESCE(SUM(r.quantity), 0)
+			FROM reservations r
+			WHERE r.product_id = s.product_id AND r.warehouse_id = s.warehouse_id
+			  AND r.status = 'active' AND r.expires_at > NOW()
+		  ))
+		RETURNING s.quantity
+	`
+
+	err := conn.QueryRowContext(ctx, updateQuery, m.Quantity, m.WarehouseID, m.ProductID).Scan(&m.WarehouseBalanceAfter)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("error updating warehouse balance: %w", err)
+	}
+	if m.Quantity < 0 {
+		return fmt.Errorf("%w: warehouse stock cannot go below the reserved quantity", ers.ErrInsufficientStock)
+	}
+
+	insertQuery := `
+		INSERT INTO warehouse_stock (warehouse_id, product_id, quantity)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (warehouse_id, product_id)
+		DO UPDATE SET quantity = warehouse_stock.quantity + EXCLUDED.quantity
+		RETURNING quantity
+	`
+
+	if err := conn.QueryRowContext(ctx, insertQuery, m.WarehouseID, m.ProductID, m.Quantity).Scan(&m.WarehouseBalanceAfter); err != nil {
+		return fmt.Errorf("error inserting warehouse balance: %w", err)
+	}
+	return nil
+}
+
+// resolveWarehouse fills in the default warehouse and checks that an explicit one exists.
+func (i *PostgresStockRepository) resolveWarehouse(ctx context.Context, m *domain.StockMovement) error {
+	conn := core.Conn(ctx, i.db)
+
+	if m.WarehouseID == uuid.Nil {
+		if err := conn.QueryRowContext(
+			ctx,
+			`SELECT id FROM warehouses WHERE is_default`,
+		).Scan(&m.WarehouseID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("%w: no default warehouse configured", ers.ErrWarehouseNotFound)
+			}
+			return err
+		}
+		return nil
+	}
+
+	var exists bool
+	if err := conn.QueryRowContext(
+		ctx,
+		`SELECT EXISTS (SELECT 1 FROM warehouses WHERE id = $1)`,
+		m.WarehouseID,
+	).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%w: warehouse not found", ers.ErrWarehouseNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+type StockRepository interface {
+	Apply(ctx context.Context, m *domain.StockMovement, bumpVersion bool) (domain.StockMovement, error)
+	List(ctx context.Context, productID uuid.UUID, filter domain.MovementFilter) ([]domain.StockMovement, error)
+	Levels(ctx context.Context, productID uuid.UUID) ([]domain.WarehouseStock, error)
+}
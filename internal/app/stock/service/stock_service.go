@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	outbox "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/outbox/service"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/stock/repository"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/auth"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	core "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/repository"
+)
+
+type stockService struct {
+	repo   repository.StockRepository
+	events outbox.OutboxService
+	tx     core.Transactor
+}
+
+func NewStockService(
+	repo repository.StockRepository,
+	events outbox.OutboxService,
+	tx core.Transactor,
+) StockService {
+	return &stockService{
+		repo:   repo,
+		events: events,
+		tx:     tx,
+	}
+}
+
+// Record appends a movement and updates the product balance. Receipts, sales
+// and returns take a positive quantity and get their sign from the type;
+// adjustments and transfers take a signed quantity.
+func (s *stockService) Record(ctx context.Context, dto domain.RecordMovementDTO) (domain.StockMovement, error) {
+	if err := s.validateMovement(dto); err != nil {
+		return domain.StockMovement{}, err
+	}
+
+	quantity := dto.Quantity
+	if dto.Type == domain.MovementSale {
+		quantity = -quantity
+	}
+
+	movement := domain.StockMovement{
+		ID:            uuid.New(),
+		ProductID:     dto.ProductID,
+		WarehouseID:   dto.WarehouseID,
+		Type:          dto.Type,
+		Quantity:      quantity,
+		ReasonCode:    dto.ReasonCode,
+		Actor:         auth.ActorFrom(ctx).ID,
+		CorrelationID: dto.CorrelationID,
+		CreatedAt:     time.Now(),
+	}
+
+	var recorded domain.StockMovement
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		recorded, err = s.repo.Apply(ctx, &movement, !dto.KeepVersion)
+		if err != nil {
+			return err
+		}
+		return s.events.Add(ctx, domain.EventStockChanged, domain.AggregateProduct, recorded.ProductID, domain.StockChanged{
+			ProductID:         recorded.ProductID,
+			WarehouseID:       recorded.WarehouseID,
+			MovementID:        recorded.ID,
+			MovementType:      recorded.Type,
+			Delta:             recorded.Quantity,
+			Quantity:          recorded.BalanceAfter,
+			WarehouseQuantity: recorded.WarehouseBalanceAfter,
+			ReasonCode:        recorded.ReasonCode,
+			CorrelationID:     recorded.CorrelationID,
+		})
+	})
+	if err != nil {
+		return domain.StockMovement{}, err
+	}
+
+	return recorded, nil
+}
+
+// Adjust applies a signed delta in a single UPDATE, so concurrent callers never lose each other's changes.
+func (s *stockService) Adjust(ctx context.Context, dto domain.AdjustStockDTO) (domain.StockBalance, error) {
+	movement, err := s.Record(ctx, domain.RecordMovementDTO{
+		ProductID:     dto.ProductID,
+		WarehouseID:   dto.WarehouseID,
+		Type:          domain.MovementAdjustment,
+		Quantity:      dto.Delta,
+		ReasonCode:    dto.ReasonCode,
+		CorrelationID: dto.CorrelationID,
+	})
+	if err != nil {
+		return domain.StockBalance{}, err
+	}
+
+	return domain.StockBalance{
+		ProductID:         movement.ProductID,
+		Quantity:          movement.BalanceAfter,
+		WarehouseID:       movement.WarehouseID,
+		WarehouseQuantity: movement.WarehouseBalanceAfter,
+		MovementID:        movement.ID,
+	}, nil
+}
+
+func (s *stockService) List(
+	ctx context.Context,
+	productID uuid.UUID,
+	filter domain.MovementFilter,
+) ([]domain.StockMovement, error) {
+	if productID == uuid.Nil {
+		return nil, fmt.Errorf("%w: invalid product id", ers.ErrInvalidInput)
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, fmt.Errorf("%w: 'from' must be before 'to'", ers.ErrInvalidInput)
+	}
+	return s.repo.List(ctx, productID, filter)
+}
+
+func (s *stockService) Levels(ctx context.Context, productID uuid.UUID) ([]domain.WarehouseStock, error) {
+	return s.repo.Levels(ctx, productID)
+}
+
+func (s *stockService) validateMovement(dto domain.RecordMovementDTO) error {
+	if dto.ProductID == uuid.Nil {
+		return fmt.Errorf("%w: product id is required", ers.ErrInvalidInput)
+	}
+
+	switch dto.Type {
+	case domain.MovementReceipt, domain.MovementSale, domain.MovementReturn:
+		if dto.Quantity <= 0 {
+			return fmt.Errorf("%w: %s quantity must be positive", ers.ErrInvalidInput, dto.Type)
+		}
+	case domain.MovementAdjustment, domain.MovementTransfer:
+		if dto.Type == domain.MovementTransfer && !dto.AllowTransfer {
+			return fmt.Errorf("%w: transfer movements are recorded by shipping and receiving a transfer", ers.ErrInvalidInput)
+		}
+		if dto.Quantity == 0 {
+			return fmt.Errorf("%w: %s quantity cannot be zero", ers.ErrInvalidInput, dto.Type)
+		}
+	default:
+		return fmt.Errorf("%w: unknown movement type %q", ers.ErrInvalidInput, dto.Type)
+	}
+
+	if utf8.RuneCountInString(dto.ReasonCode) > 64 {
+		return fmt.Errorf("%w: reason code is too long", ers.ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(dto.CorrelationID) > 128 {
+		return fmt.Errorf("%w: correlation id is too long", ers.ErrInvalidInput)
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+type StockService interface {
+	Record(ctx context.Context, dto domain.RecordMovementDTO) (domain.StockMovement, error)
+	Adjust(ctx context.Context, dto domain.AdjustStockDTO) (domain.StockBalance, error)
+	List(ctx context.Context, productID uuid.UUID, filter domain.MovementFilter) ([]domain.StockMovement, error)
+	Levels(ctx context.Context, productID uuid.UUID) ([]domain.WarehouseStock, error)
+}
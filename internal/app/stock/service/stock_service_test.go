@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	outbox "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/outbox/service"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/stock/repository"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+)
+
+type inlineTx struct{}
+
+func (inlineTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// memoryStock keeps a single unreserved product balance and refuses to take it
+// below zero, like the guarded UPDATE in the Postgres repository.
+type memoryStock struct {
+	repository.StockRepository
+	quantity  int
+	movements []domain.StockMovement
+}
+
+func (m *memoryStock) Apply(ctx context.Context, movement *domain.StockMovement, bumpVersion bool) (domain.StockMovement, error) {
+	if m.quantity+movement.Quantity < 0 {
+		return domain.StockMovement{}, fmt.Errorf("%w: stock cannot go below the reserved quantity", ers.ErrInsufficientStock)
+	}
+	m.quantity += movement.Quantity
+	movement.BalanceAfter = m.quantity
+	movement.WarehouseBalanceAfter = m.quantity
+	m.movements = append(m.movements, *movement)
+	return *movement, nil
+}
+
+type memoryEvents struct {
+	outbox.OutboxService
+	events []domain.EventType
+}
+
+func (m *memoryEvents) Add(
+	ctx context.Context,
+	eventType domain.EventType,
+	aggregateType string,
+	aggregateID uuid.UUID,
+	payload interface{},
+) error {
+	m.events = append(m.events, eventType)
+	return nil
+}
+
+func TestValidateMovement_Success(t *testing.T) {
+	s := &stockService{}
+
+	movement := domain.RecordMovementDTO{
+		ProductID:     uuid.New(),
+		Type:          domain.MovementAdjustment,
+		Quantity:      -3,
+		ReasonCode:    "damaged",
+		CorrelationID: "inventory-2024-03",
+	}
+
+	if err := s.validateMovement(movement); err != nil {
+		t.Fatalf("movement validation failed: %s", err)
+	}
+}
+
+func TestValidateMovement_NegativeSale(t *testing.T) {
+	s := &stockService{}
+
+	movement := domain.RecordMovementDTO{
+		ProductID: uuid.New(),
+		Type:      domain.MovementSale,
+		Quantity:  -1,
+	}
+
+	err := s.validateMovement(movement)
+	if err == nil {
+		t.Fatalf("expected error for negative sale quantity, but got nil")
+	}
+
+	if !errors.Is(err, ers.ErrInvalidInput) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrInvalidInput, err)
+	}
+}
+
+func TestValidateMovement_UnknownType(t *testing.T) {
+	s := &stockService{}
+
+	movement := domain.RecordMovementDTO{
+		ProductID: uuid.New(),
+		Type:      "gift",
+		Quantity:  1,
+	}
+
+	if err := s.validateMovement(movement); !errors.Is(err, ers.ErrInvalidInput) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrInvalidInput, err)
+	}
+}
+
+func TestValidateMovement_TransferWithoutDocument(t *testing.T) {
+	s := &stockService{}
+
+	movement := domain.RecordMovementDTO{
+		ProductID: uuid.New(),
+		Type:      domain.MovementTransfer,
+		Quantity:  5,
+	}
+
+	if err := s.validateMovement(movement); !errors.Is(err, ers.ErrInvalidInput) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrInvalidInput, err)
+	}
+
+	movement.AllowTransfer = true
+	if err := s.validateMovement(movement); err != nil {
+		t.Errorf("expected a transfer from the transfer service to pass, but got %v", err)
+	}
+}
+
+func TestAdjust_AppliesSignedDelta(t *testing.T) {
+	repo := &memoryStock{quantity: 10}
+	events := &memoryEvents{}
+	s := &stockService{repo: repo, events: events, tx: inlineTx{}}
+
+	balance, err := s.Adjust(context.Background(), domain.AdjustStockDTO{
+		ProductID:  uuid.New(),
+		Delta:      -4,
+		ReasonCode: "damaged",
+	})
+	if err != nil {
+		t.Fatalf("stock adjust failed: %s", err)
+	}
+
+	if balance.Quantity != 6 {
+		t.Errorf("expected quantity 6, but got %d", balance.Quantity)
+	}
+	if len(repo.movements) != 1 || repo.movements[0].Type != domain.MovementAdjustment {
+		t.Errorf("expected one adjustment movement, but got %+v", repo.movements)
+	}
+	if balance.MovementID != repo.movements[0].ID {
+		t.Errorf("expected movement id %s, but got %s", repo.movements[0].ID, balance.MovementID)
+	}
+	if len(events.events) != 1 || events.events[0] != domain.EventStockChanged {
+		t.Errorf("expected a %s event, but got %v", domain.EventStockChanged, events.events)
+	}
+}
+
+func TestAdjust_ZeroDelta(t *testing.T) {
+	repo := &memoryStock{quantity: 10}
+	s := &stockService{repo: repo, events: &memoryEvents{}, tx: inlineTx{}}
+
+	_, err := s.Adjust(context.Background(), domain.AdjustStockDTO{ProductID: uuid.New()})
+	if !errors.Is(err, ers.ErrInvalidInput) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrInvalidInput, err)
+	}
+
+	if len(repo.movements) != 0 {
+		t.Errorf("expected no movement, but got %d", len(repo.movements))
+	}
+}
+
+func TestAdjust_InsufficientStock(t *testing.T) {
+	repo := &memoryStock{quantity: 3}
+	events := &memoryEvents{}
+	s := &stockService{repo: repo, events: events, tx: inlineTx{}}
+
+	_, err := s.Adjust(context.Background(), domain.AdjustStockDTO{ProductID: uuid.New(), Delta: -5})
+	if !errors.Is(err, ers.ErrInsufficientStock) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrInsufficientStock, err)
+	}
+
+	if repo.quantity != 3 || len(events.events) != 0 {
+		t.Errorf("expected the balance to stay at 3 without events, but got %d and %v", repo.quantity, events.events)
+	}
+}
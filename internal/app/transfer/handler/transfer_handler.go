@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/transfer/service"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/logger"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/response"
+)
+
+type TransferHandler struct {
+	service service.TransferService
+	logger  logger.Logger
+}
+
+func NewTransferHandler(service service.TransferService, logger logger.Logger) *TransferHandler {
+	return &TransferHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *TransferHandler) Create(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodPost) {
+		return
+	}
+
+	var req CreateTransferRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, h.logger, fmt.Errorf("%w: invalid body", ers.ErrInvalidInput))
+		return
+	}
+
+	transfer, err := h.service.Create(r.Context(), req.ToDomain())
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusCreated, transfer)
+}
+
+func (h *TransferHandler) GetById(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodGet) {
+		return
+	}
+
+	id, err := response.PathID(r, h.logger, "id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	transfer, err := h.service.GetById(r.Context(), id)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusOK, transfer)
+}
+
+func (h *TransferHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodGet) {
+		return
+	}
+
+	var filter domain.TransferFilter
+	if value := r.URL.Query().Get("status"); value != "" {
+		status := domain.TransferStatus(value)
+		filter.Status = &status
+	}
+
+	transfers, err := h.service.GetAll(r.Context(), filter)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusOK, transfers)
+}
+
+func (h *TransferHandler) Ship(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, h.service.Ship)
+}
+
+func (h *TransferHandler) Receive(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, h.service.Receive)
+}
+
+func (h *TransferHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, h.service.Cancel)
+}
+
+func (h *TransferHandler) InTransit(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodGet) {
+		return
+	}
+
+	var productID *uuid.UUID
+	if value := r.URL.Query().Get("product_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			response.Error(w, h.logger, fmt.Errorf("%w: invalid product_id", ers.ErrInvalidInput))
+			return
+		}
+		productID = &id
+	}
+
+	stock, err := h.service.InTransit(r.Context(), productID)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusOK, stock)
+}
+
+func (h *TransferHandler) transition(
+	w http.ResponseWriter,
+	r *http.Request,
+	action func(ctx context.Context, id uuid.UUID) (domain.Transfer, error),
+) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodPost) {
+		return
+	}
+
+	id, err := response.PathID(r, h.logger, "id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	transfer, err := action(r.Context(), id)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusOK, transfer)
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+type TransferLineRequest struct {
+	ProductID uuid.UUID `json:"product_id"`
+	Quantity  int       `json:"quantity"`
+}
+
+type CreateTransferRequest struct {
+	SourceWarehouseID      uuid.UUID             `json:"source_warehouse_id"`
+	DestinationWarehouseID uuid.UUID             `json:"destination_warehouse_id"`
+	Note                   string                `json:"note"`
+	Lines                  []TransferLineRequest `json:"lines"`
+}
+
+func (r *CreateTransferRequest) ToDomain() domain.Transfer {
+	transfer := domain.Transfer{
+		SourceWarehouseID:      r.SourceWarehouseID,
+		DestinationWarehouseID: r.DestinationWarehouseID,
+		Note:                   r.Note,
+		Lines:                  make([]domain.TransferLine, 0, len(r.Lines)),
+	}
+
+	for _, line := range r.Lines {
+		transfer.Lines = append(transfer.Lines, domain.TransferLine{
+			ProductID: line.ProductID,
+			Quantity:  line.Quantity,
+		})
+	}
+
+	return transfer
+}
@@ -0,0 +1,292 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	core "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/repository"
+	"github.com/lib/pq"
+)
+
+const foreignKeyViolation = "23503"
+
+type PostgresTransferRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresTransferRepository(db *sql.DB) *PostgresTransferRepository {
+	return &PostgresTransferRepository{
+		db: db,
+	}
+}
+
+// Create inserts the document and its lines. It must run inside a transaction.
+func (i *PostgresTransferRepository) Create(
+	ctx context.Context,
+	t *domain.Transfer,
+) (domain.Transfer, error) {
+	conn := core.Conn(ctx, i.db)
+
+	query := `
+		INSERT INTO transfers (id, source_warehouse_id, destination_warehouse_id, status, note, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	if _, err := conn.ExecContext(
+		ctx,
+		query,
+		t.ID,
+		t.SourceWarehouseID,
+		t.DestinationWarehouseID,
+		t.Status,
+		t.Note,
+		t.CreatedAt,
+		t.UpdatedAt,
+	); err != nil {
+		return domain.Transfer{}, fmt.Errorf("error inserting transfer: %w", err)
+	}
+
+	for _, line := range t.Lines {
+		if _, err := conn.ExecContext(
+			ctx,
+			`INSERT INTO transfer_lines (transfer_id, product_id, quantity) VALUES ($1, $2, $3)`,
+			t.ID,
+			line.ProductID,
+			line.Quantity,
+		); err != nil {
+			var pqErr *pq.Error
+			if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation {
+				return domain.Transfer{}, fmt.Errorf("%w: product %s not found", ers.ErrProductNotFound, line.ProductID)
+			}
+			return domain.Transfer{}, fmt.Errorf("error inserting transfer line: %w", err)
+		}
+	}
+
+	return *t, nil
+}
+
+func (i *PostgresTransferRepository) GetById(
+	ctx context.Context,
+	id uuid.UUID,
+) (domain.Transfer, error) {
+	query := `
+		SELECT id, source_warehouse_id, destination_warehouse_id, status, note,
+		       created_at, updated_at, shipped_at, received_at
+		FROM transfers
+		WHERE id = $1
+	`
+
+	return i.get(ctx, query, id)
+}
+
+func (i *PostgresTransferRepository) GetByIdForUpdate(
+	ctx context.Context,
+	id uuid.UUID,
+) (domain.Transfer, error) {
+	query := `
+		SELECT id, source_warehouse_id, destination_warehouse_id, status, note,
+		       created_at, updated_at, shipped_at, received_at
+		FROM transfers
+		WHERE id = $1
+		FOR UPDATE
+	`
+
+	return i.get(ctx, query, id)
+}
+
+func (i *PostgresTransferRepository) GetAll(
+	ctx context.Context,
+	filter domain.TransferFilter,
+) ([]domain.Transfer, error) {
+	transfers := []domain.Transfer{}
+
+	query := `
+		SELECT id, source_warehouse_id, destination_warehouse_id, status, note,
+		       created_at, updated_at, shipped_at, received_at
+		FROM transfers
+		WHERE ($1::text IS NULL OR status = $1)
+		ORDER BY created_at DESC
+	`
+
+	rows, err := core.Conn(ctx, i.db).QueryContext(ctx, query, filter.Status)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		if err := rows.Close(); err != nil {
+		}
+	}(rows)
+
+	for rows.Next() {
+		var transfer domain.Transfer
+
+		if err := rows.Scan(
+			&transfer.ID,
+			&transfer.SourceWarehouseID,
+			&transfer.DestinationWarehouseID,
+			&transfer.Status,
+			&transfer.Note,
+			&transfer.CreatedAt,
+			&transfer.UpdatedAt,
+			&transfer.ShippedAt,
+			&transfer.ReceivedAt,
+		); err != nil {
+			return transfers, err
+		}
+
+		transfers = append(transfers, transfer)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	for idx := range transfers {
+		if transfers[idx].Lines, err = i.lines(ctx, transfers[idx].ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return transfers, nil
+}
+
+func (i *PostgresTransferRepository) UpdateStatus(
+	ctx context.Context,
+	id uuid.UUID,
+	status domain.TransferStatus,
+	at time.Time,
+) (domain.Transfer, error) {
+	query := `
+		UPDATE transfers
+		SET status = $1,
+		    updated_at = $2,
+		    shipped_at = CASE WHEN $1 = 'shipped' THEN $2 ELSE shipped_at END,
+		    received_at = CASE WHEN $1 = 'received' THEN $2 ELSE received_at END
+		WHERE id = $3
+		RETURNING id, source_warehouse_id, destination_warehouse_id, status, note,
+		          created_at, updated_at, shipped_at, received_at
+	`
+
+	return i.get(ctx, query, status, at, id)
+}
+
+func (i *PostgresTransferRepository) InTransit(
+	ctx context.Context,
+	productID *uuid.UUID,
+) ([]domain.InTransitStock, error) {
+	stock := []domain.InTransitStock{}
+
+	query := `
+		SELECT t.id, l.product_id, t.source_warehouse_id, t.destination_warehouse_id, l.quantity
+		FROM transfers t
+		JOIN transfer_lines l ON l.transfer_id = t.id
+		WHERE t.status = 'shipped' AND ($1::uuid IS NULL OR l.product_id = $1)
+		ORDER BY t.shipped_at, l.product_id
+	`
+
+	rows, err := core.Conn(ctx, i.db).QueryContext(ctx, query, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		if err := rows.Close(); err != nil {
+		}
+	}(rows)
+
+	for rows.Next() {
+		var item domain.InTransitStock
+
+		if err := rows.Scan(
+			&item.TransferID,
+			&item.ProductID,
+			&item.SourceWarehouseID,
+			&item.DestinationWarehouseID,
+			&item.Quantity,
+		); err != nil {
+			return stock, err
+		}
+
+		stock = append(stock, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return stock, nil
+}
+
+func (i *PostgresTransferRepository) get(
+	ctx context.Context,
+	query string,
+	args ...interface{},
+) (domain.Transfer, error) {
+	var transfer domain.Transfer
+
+	if err := core.Conn(ctx, i.db).QueryRowContext(ctx, query, args...).Scan(
+		&transfer.ID,
+		&transfer.SourceWarehouseID,
+		&transfer.DestinationWarehouseID,
+		&transfer.Status,
+		&transfer.Note,
+		&transfer.CreatedAt,
+		&transfer.UpdatedAt,
+		&transfer.ShippedAt,
+		&transfer.ReceivedAt,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Transfer{}, fmt.Errorf("%w: transfer not found", ers.ErrTransferNotFound)
+		}
+		return domain.Transfer{}, err
+	}
+
+	lines, err := i.lines(ctx, transfer.ID)
+	if err != nil {
+		return domain.Transfer{}, err
+	}
+	transfer.Lines = lines
+
+	return transfer, nil
+}
+
+func (i *PostgresTransferRepository) lines(
+	ctx context.Context,
+	transferID uuid.UUID,
+) ([]domain.TransferLine, error) {
+	lines := []domain.TransferLine{}
+
+	rows, err := core.Conn(ctx, i.db).QueryContext(
+		ctx,
+		`SELECT product_id, quantity FROM transfer_lines WHERE transfer_id = $1 ORDER BY product_id`,
+		transferID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		if err := rows.Close(); err != nil {
+		}
+	}(rows)
+
+	for rows.Next() {
+		var line domain.TransferLine
+
+		if err := rows.Scan(&line.ProductID, &line.Quantity); err != nil {
+			return lines, err
+		}
+
+		lines = append(lines, line)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return lines, nil
+}
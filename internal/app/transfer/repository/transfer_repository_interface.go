@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+type TransferRepository interface {
+	Create(ctx context.Context, t *domain.Transfer) (domain.Transfer, error)
+	GetById(ctx context.Context, id uuid.UUID) (domain.Transfer, error)
+	GetByIdForUpdate(ctx context.Context, id uuid.UUID) (domain.Transfer, error)
+	GetAll(ctx context.Context, filter domain.TransferFilter) ([]domain.Transfer, error)
+	UpdateStatus(ctx context.Context, id uuid.UUID, status domain.TransferStatus, at time.Time) (domain.Transfer, error)
+	InTransit(ctx context.Context, productID *uuid.UUID) ([]domain.InTransitStock, error)
+}
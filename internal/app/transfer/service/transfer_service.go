@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	stock "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/stock/service"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/transfer/repository"
+	warehouse "github.com/jamal23041989/go-marketplace-inventory-service/internal/app/warehouse/service"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	core "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/repository"
+)
+
+type transferService struct {
+	repo       repository.TransferRepository
+	stock      stock.StockService
+	warehouses warehouse.WarehouseService
+	tx         core.Transactor
+}
+
+func NewTransferService(
+	repo repository.TransferRepository,
+	stock stock.StockService,
+	warehouses warehouse.WarehouseService,
+	tx core.Transactor,
+) TransferService {
+	return &transferService{
+		repo:       repo,
+		stock:      stock,
+		warehouses: warehouses,
+		tx:         tx,
+	}
+}
+
+func (s *transferService) Create(ctx context.Context, t domain.Transfer) (domain.Transfer, error) {
+	if err := s.validateTransfer(t); err != nil {
+		return domain.Transfer{}, err
+	}
+	if _, err := s.warehouses.GetById(ctx, t.SourceWarehouseID); err != nil {
+		return domain.Transfer{}, err
+	}
+	if _, err := s.warehouses.GetById(ctx, t.DestinationWarehouseID); err != nil {
+		return domain.Transfer{}, err
+	}
+
+	t.ID = uuid.New()
+	t.Status = domain.TransferDraft
+	t.CreatedAt = time.Now()
+	t.UpdatedAt = time.Now()
+	t.ShippedAt = nil
+	t.ReceivedAt = nil
+
+	var created domain.Transfer
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		created, err = s.repo.Create(ctx, &t)
+		return err
+	})
+	if err != nil {
+		return domain.Transfer{}, err
+	}
+
+	return created, nil
+}
+
+func (s *transferService) GetById(ctx context.Context, id uuid.UUID) (domain.Transfer, error) {
+	if id == uuid.Nil {
+		return domain.Transfer{}, fmt.Errorf("%w: invalid transfer id", ers.ErrInvalidInput)
+	}
+	return s.repo.GetById(ctx, id)
+}
+
+func (s *transferService) GetAll(ctx context.Context, filter domain.TransferFilter) ([]domain.Transfer, error) {
+	return s.repo.GetAll(ctx, filter)
+}
+
+// Ship debits every line from the source warehouse; the goods stay in transit until received.
+func (s *transferService) Ship(ctx context.Context, id uuid.UUID) (domain.Transfer, error) {
+	return s.transition(ctx, id, domain.TransferDraft, domain.TransferShipped, func(ctx context.Context, t domain.Transfer) error {
+		for _, line := range t.Lines {
+			if _, err := s.stock.Record(ctx, domain.RecordMovementDTO{
+				ProductID:     line.ProductID,
+				WarehouseID:   t.SourceWarehouseID,
+				Type:          domain.MovementTransfer,
+				Quantity:      -line.Quantity,
+				ReasonCode:    "transfer_shipped",
+				CorrelationID: t.ID.String(),
+				AllowTransfer: true,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Receive credits every line to the destination warehouse.
+func (s *transferService) Receive(ctx context.Context, id uuid.UUID) (domain.Transfer, error) {
+	return s.transition(ctx, id, domain.TransferShipped, domain.TransferReceived, func(ctx context.Context, t domain.Transfer) error {
+		for _, line := range t.Lines {
+			if _, err := s.stock.Record(ctx, domain.RecordMovementDTO{
+				ProductID:     line.ProductID,
+				WarehouseID:   t.DestinationWarehouseID,
+				Type:          domain.MovementTransfer,
+				Quantity:      line.Quantity,
+				ReasonCode:    "transfer_received",
+				CorrelationID: t.ID.String(),
+				AllowTransfer: true,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *transferService) Cancel(ctx context.Context, id uuid.UUID) (domain.Transfer, error) {
+	return s.transition(ctx, id, domain.TransferDraft, domain.TransferCancelled, nil)
+}
+
+func (s *transferService) InTransit(ctx context.Context, productID *uuid.UUID) ([]domain.InTransitStock, error) {
+	return s.repo.InTransit(ctx, productID)
+}
+
+// transition locks the transfer, checks its current status and applies the stock
+// side effects and the new status in one database transaction.
+func (s *transferService) transition(
+	ctx context.Context,
+	id uuid.UUID,
+	from domain.TransferStatus,
+	to domain.TransferStatus,
+	apply func(ctx context.Context, t domain.Transfer) error,
+) (domain.Transfer, error) {
+	if id == uuid.Nil {
+		return domain.Transfer{}, fmt.Errorf("%w: invalid transfer id", ers.ErrInvalidInput)
+	}
+
+	var updated domain.Transfer
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := s.repo.GetByIdForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if current.Status != from {
+			return fmt.Errorf("%w: cannot move transfer from %s to %s", ers.ErrInvalidTransferState, current.Status, to)
+		}
+
+		if apply != nil {
+			if err := apply(ctx, current); err != nil {
+				return err
+			}
+		}
+
+		updated, err = s.repo.UpdateStatus(ctx, id, to, time.Now())
+		return err
+	})
+	if err != nil {
+		return domain.Transfer{}, err
+	}
+
+	return updated, nil
+}
+
+func (s *transferService) validateTransfer(t domain.Transfer) error {
+	if t.SourceWarehouseID == uuid.Nil || t.DestinationWarehouseID == uuid.Nil {
+		return fmt.Errorf("%w: source and destination warehouses are required", ers.ErrInvalidInput)
+	}
+	if t.SourceWarehouseID == t.DestinationWarehouseID {
+		return fmt.Errorf("%w: source and destination warehouses must differ", ers.ErrInvalidInput)
+	}
+	if len(t.Lines) == 0 {
+		return fmt.Errorf("%w: transfer must have at least one line", ers.ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(t.Note) > 500 {
+		return fmt.Errorf("%w: transfer note is too long", ers.ErrInvalidInput)
+	}
+
+	seen := make(map[uuid.UUID]bool, len(t.Lines))
+	for _, line := range t.Lines {
+		if line.ProductID == uuid.Nil {
+			return fmt.Errorf("%w: product id is required on every line", ers.ErrInvalidInput)
+		}
+		if line.Quantity <= 0 {
+			return fmt.Errorf("%w: line quantity must be positive", ers.ErrInvalidInput)
+		}
+		if seen[line.ProductID] {
+			return fmt.Errorf("%w: product %s appears twice", ers.ErrInvalidInput, line.ProductID)
+		}
+		seen[line.ProductID] = true
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+type TransferService interface {
+	Create(ctx context.Context, t domain.Transfer) (domain.Transfer, error)
+	GetById(ctx context.Context, id uuid.UUID) (domain.Transfer, error)
+	GetAll(ctx context.Context, filter domain.TransferFilter) ([]domain.Transfer, error)
+	Ship(ctx context.Context, id uuid.UUID) (domain.Transfer, error)
+	Receive(ctx context.Context, id uuid.UUID) (domain.Transfer, error)
+	Cancel(ctx context.Context, id uuid.UUID) (domain.Transfer, error)
+	InTransit(ctx context.Context, productID *uuid.UUID) ([]domain.InTransitStock, error)
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+)
+
+func TestValidateTransfer_Success(t *testing.T) {
+	s := &transferService{}
+
+	transfer := domain.Transfer{
+		SourceWarehouseID:      uuid.New(),
+		DestinationWarehouseID: uuid.New(),
+		Note:                   "Пополнение склада в Казани",
+		Lines: []domain.TransferLine{
+			{ProductID: uuid.New(), Quantity: 10},
+			{ProductID: uuid.New(), Quantity: 3},
+		},
+	}
+
+	if err := s.validateTransfer(transfer); err != nil {
+		t.Fatalf("transfer validation failed: %s", err)
+	}
+}
+
+func TestValidateTransfer_SameWarehouse(t *testing.T) {
+	s := &transferService{}
+
+	warehouseID := uuid.New()
+	transfer := domain.Transfer{
+		SourceWarehouseID:      warehouseID,
+		DestinationWarehouseID: warehouseID,
+		Lines:                  []domain.TransferLine{{ProductID: uuid.New(), Quantity: 1}},
+	}
+
+	err := s.validateTransfer(transfer)
+	if err == nil {
+		t.Fatalf("expected error for identical warehouses, but got nil")
+	}
+
+	if !errors.Is(err, ers.ErrInvalidInput) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrInvalidInput, err)
+	}
+}
+
+func TestValidateTransfer_DuplicateProduct(t *testing.T) {
+	s := &transferService{}
+
+	productID := uuid.New()
+	transfer := domain.Transfer{
+		SourceWarehouseID:      uuid.New(),
+		DestinationWarehouseID: uuid.New(),
+		Lines: []domain.TransferLine{
+			{ProductID: productID, Quantity: 1},
+			{ProductID: productID, Quantity: 2},
+		},
+	}
+
+	if err := s.validateTransfer(transfer); !errors.Is(err, ers.ErrInvalidInput) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrInvalidInput, err)
+	}
+}
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+type CreateWarehouseRequest struct {
+	Code    string `json:"code"`
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
+
+func (r *CreateWarehouseRequest) ToDomain() domain.Warehouse {
+	return domain.Warehouse{
+		Code:    r.Code,
+		Name:    r.Name,
+		Address: r.Address,
+	}
+}
+
+type UpdateWarehouseRequest struct {
+	Name    *string `json:"name"`
+	Address *string `json:"address"`
+}
+
+func (r *UpdateWarehouseRequest) ToUpdateDTO() domain.UpdateWarehouseDTO {
+	return domain.UpdateWarehouseDTO{
+		Name:    r.Name,
+		Address: r.Address,
+	}
+}
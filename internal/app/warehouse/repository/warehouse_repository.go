@@ -0,0 +1,258 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	core "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/repository"
+	"github.com/lib/pq"
+)
+
+const (
+	uniqueViolation     = "23505"
+	foreignKeyViolation = "23503"
+)
+
+type PostgresWarehouseRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresWarehouseRepository(db *sql.DB) *PostgresWarehouseRepository {
+	return &PostgresWarehouseRepository{
+		db: db,
+	}
+}
+
+func (i *PostgresWarehouseRepository) Create(
+	ctx context.Context,
+	w *domain.Warehouse,
+) (domain.Warehouse, error) {
+	query := `
+		INSERT INTO warehouses (id, code, name, address, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	if _, err := core.Conn(ctx, i.db).ExecContext(
+		ctx,
+		query,
+		w.ID,
+		w.Code,
+		w.Name,
+		w.Address,
+		w.CreatedAt,
+		w.UpdatedAt,
+	); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return domain.Warehouse{}, fmt.Errorf("%w: code %q is taken", ers.ErrWarehouseExists, w.Code)
+		}
+		return domain.Warehouse{}, fmt.Errorf("error inserting warehouse: %w", err)
+	}
+
+	return *w, nil
+}
+
+func (i *PostgresWarehouseRepository) GetById(
+	ctx context.Context,
+	id uuid.UUID,
+) (domain.Warehouse, error) {
+	var warehouse domain.Warehouse
+
+	query := `
+		SELECT id, code, name, address, is_default, created_at, updated_at
+		FROM warehouses
+		WHERE id = $1
+	`
+
+	if err := core.Conn(ctx, i.db).QueryRowContext(ctx, query, id).Scan(
+		&warehouse.ID,
+		&warehouse.Code,
+		&warehouse.Name,
+		&warehouse.Address,
+		&warehouse.IsDefault,
+		&warehouse.CreatedAt,
+		&warehouse.UpdatedAt,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Warehouse{}, fmt.Errorf("%w: warehouse not found", ers.ErrWarehouseNotFound)
+		}
+		return domain.Warehouse{}, err
+	}
+
+	return warehouse, nil
+}
+
+func (i *PostgresWarehouseRepository) GetAll(
+	ctx context.Context,
+) ([]domain.Warehouse, error) {
+	warehouses := []domain.Warehouse{}
+
+	query := `
+		SELECT id, code, name, address, is_default, created_at, updated_at
+		FROM warehouses
+		ORDER BY code
+	`
+
+	rows, err := core.Conn(ctx, i.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		if err := rows.Close(); err != nil {
+		}
+	}(rows)
+
+	for rows.Next() {
+		var warehouse domain.Warehouse
+
+		if err := rows.Scan(
+			&warehouse.ID,
+			&warehouse.Code,
+			&warehouse.Name,
+			&warehouse.Address,
+			&warehouse.IsDefault,
+			&warehouse.CreatedAt,
+			&warehouse.UpdatedAt,
+		); err != nil {
+			return warehouses, err
+		}
+
+		warehouses = append(warehouses, warehouse)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return warehouses, nil
+}
+
+func (i *PostgresWarehouseRepository) Update(
+	ctx context.Context,
+	id uuid.UUID,
+	w domain.Warehouse,
+) (domain.Warehouse, error) {
+	query := `
+		UPDATE warehouses
+		SET name = $1, address = $2, updated_at = $3
+		WHERE id = $4
+		RETURNING id, code, name, address, is_default, created_at, updated_at
+	`
+
+	var updated domain.Warehouse
+	if err := core.Conn(ctx, i.db).QueryRowContext(
+		ctx,
+		query,
+		w.Name, w.Address, w.UpdatedAt, id,
+	).Scan(
+		&updated.ID,
+		&updated.Code,
+		&updated.Name,
+		&updated.Address,
+		&updated.IsDefault,
+		&updated.CreatedAt,
+		&updated.UpdatedAt,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Warehouse{}, fmt.Errorf("%w: warehouse not found", ers.ErrWarehouseNotFound)
+		}
+		return domain.Warehouse{}, fmt.Errorf("error updating warehouse: %w", err)
+	}
+
+	return updated, nil
+}
+
+// Delete removes an empty, non-default warehouse. Warehouses with stock history stay for the ledger.
+func (i *PostgresWarehouseRepository) Delete(
+	ctx context.Context,
+	id uuid.UUID,
+) error {
+	conn := core.Conn(ctx, i.db)
+
+	var hasStock bool
+	if err := conn.QueryRowContext(
+		ctx,
+		`SELECT EXISTS (SELECT 1 FROM warehouse_stock WHERE warehouse_id = $1 AND quantity > 0)`,
+		id,
+	).Scan(&hasStock); err != nil {
+		return err
+	}
+	if hasStock {
+		return fmt.Errorf("%w: warehouse still holds stock", ers.ErrWarehouseInUse)
+	}
+
+	if _, err := conn.ExecContext(ctx, `DELETE FROM warehouse_stock WHERE warehouse_id = $1`, id); err != nil {
+		return err
+	}
+
+	result, err := conn.ExecContext(ctx, `DELETE FROM warehouses WHERE id = $1 AND NOT is_default`, id)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation {
+			return fmt.Errorf("%w: warehouse has stock history", ers.ErrWarehouseInUse)
+		}
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		if _, err := i.GetById(ctx, id); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: default warehouse cannot be deleted", ers.ErrWarehouseInUse)
+	}
+
+	return nil
+}
+
+func (i *PostgresWarehouseRepository) Stock(
+	ctx context.Context,
+	id uuid.UUID,
+) ([]domain.WarehouseStock, error) {
+	levels := []domain.WarehouseStock{}
+
+	query := `
+		SELECT s.warehouse_id, w.code, s.product_id, s.quantity
+		FROM warehouse_stock s
+		JOIN warehouses w ON w.id = s.warehouse_id
+		WHERE s.warehouse_id = $1 AND s.quantity > 0
+		ORDER BY s.product_id
+	`
+
+	rows, err := core.Conn(ctx, i.db).QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		if err := rows.Close(); err != nil {
+		}
+	}(rows)
+
+	for rows.Next() {
+		var level domain.WarehouseStock
+
+		if err := rows.Scan(
+			&level.WarehouseID,
+			&level.WarehouseCode,
+			&level.ProductID,
+			&level.Quantity,
+		); err != nil {
+			return levels, err
+		}
+
+		levels = append(levels, level)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return levels, nil
+}
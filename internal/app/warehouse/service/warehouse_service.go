@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/warehouse/repository"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	core "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/repository"
+)
+
+var codePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+
+type warehouseService struct {
+	repo repository.WarehouseRepository
+	tx   core.Transactor
+}
+
+func NewWarehouseService(repo repository.WarehouseRepository, tx core.Transactor) WarehouseService {
+	return &warehouseService{
+		repo: repo,
+		tx:   tx,
+	}
+}
+
+func (s *warehouseService) Create(ctx context.Context, w domain.Warehouse) (domain.Warehouse, error) {
+	if !codePattern.MatchString(w.Code) {
+		return domain.Warehouse{}, fmt.Errorf(
+			"%w: warehouse code must be 1-32 lowercase letters, digits, '-' or '_'",
+			ers.ErrInvalidInput,
+		)
+	}
+	if err := s.validateWarehouse(w); err != nil {
+		return domain.Warehouse{}, err
+	}
+
+	w.ID = uuid.New()
+	w.IsDefault = false
+	w.CreatedAt = time.Now()
+	w.UpdatedAt = time.Now()
+
+	return s.repo.Create(ctx, &w)
+}
+
+func (s *warehouseService) GetById(ctx context.Context, id uuid.UUID) (domain.Warehouse, error) {
+	if id == uuid.Nil {
+		return domain.Warehouse{}, fmt.Errorf("%w: invalid warehouse id", ers.ErrInvalidInput)
+	}
+	return s.repo.GetById(ctx, id)
+}
+
+func (s *warehouseService) GetAll(ctx context.Context) ([]domain.Warehouse, error) {
+	return s.repo.GetAll(ctx)
+}
+
+func (s *warehouseService) Update(
+	ctx context.Context,
+	id uuid.UUID,
+	dto domain.UpdateWarehouseDTO,
+) (domain.Warehouse, error) {
+	if id == uuid.Nil {
+		return domain.Warehouse{}, fmt.Errorf("%w: invalid warehouse id", ers.ErrInvalidInput)
+	}
+
+	current, err := s.repo.GetById(ctx, id)
+	if err != nil {
+		return domain.Warehouse{}, err
+	}
+
+	if dto.Name != nil {
+		current.Name = *dto.Name
+	}
+	if dto.Address != nil {
+		current.Address = *dto.Address
+	}
+	current.UpdatedAt = time.Now()
+
+	if err := s.validateWarehouse(current); err != nil {
+		return domain.Warehouse{}, err
+	}
+
+	return s.repo.Update(ctx, id, current)
+}
+
+func (s *warehouseService) Delete(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return fmt.Errorf("%w: invalid warehouse id", ers.ErrInvalidInput)
+	}
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		return s.repo.Delete(ctx, id)
+	})
+}
+
+func (s *warehouseService) Stock(ctx context.Context, id uuid.UUID) ([]domain.WarehouseStock, error) {
+	if _, err := s.GetById(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.Stock(ctx, id)
+}
+
+func (s *warehouseService) validateWarehouse(w domain.Warehouse) error {
+	if w.Name == "" {
+		return fmt.Errorf("%w: warehouse name is required", ers.ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(w.Name) > 200 {
+		return fmt.Errorf("%w: warehouse name is too long", ers.ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(w.Address) > 500 {
+		return fmt.Errorf("%w: warehouse address is too long", ers.ErrInvalidInput)
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+type WarehouseService interface {
+	Create(ctx context.Context, w domain.Warehouse) (domain.Warehouse, error)
+	GetById(ctx context.Context, id uuid.UUID) (domain.Warehouse, error)
+	GetAll(ctx context.Context) ([]domain.Warehouse, error)
+	Update(ctx context.Context, id uuid.UUID, dto domain.UpdateWarehouseDTO) (domain.Warehouse, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	Stock(ctx context.Context, id uuid.UUID) ([]domain.WarehouseStock, error)
+}
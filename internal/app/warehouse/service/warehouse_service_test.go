@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+)
+
+func TestValidateWarehouse_Success(t *testing.T) {
+	s := &warehouseService{}
+
+	warehouse := domain.Warehouse{
+		Code:    "kzn-1",
+		Name:    "Склад в Казани",
+		Address: "Казань, ул. Тихорецкая, 5",
+	}
+
+	if err := s.validateWarehouse(warehouse); err != nil {
+		t.Fatalf("warehouse validation failed: %s", err)
+	}
+}
+
+func TestValidateWarehouse_MissingName(t *testing.T) {
+	s := &warehouseService{}
+
+	err := s.validateWarehouse(domain.Warehouse{Code: "kzn-1"})
+	if err == nil {
+		t.Fatalf("expected error for missing name, but got nil")
+	}
+
+	if !errors.Is(err, ers.ErrInvalidInput) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrInvalidInput, err)
+	}
+}
+
+func TestCreate_InvalidCode(t *testing.T) {
+	s := &warehouseService{}
+
+	_, err := s.Create(context.Background(), domain.Warehouse{Code: "Склад 1", Name: "Склад в Казани"})
+	if !errors.Is(err, ers.ErrInvalidInput) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrInvalidInput, err)
+	}
+}
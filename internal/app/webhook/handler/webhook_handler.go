@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/webhook/service"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/logger"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/response"
+)
+
+type WebhookHandler struct {
+	service service.WebhookService
+	logger  logger.Logger
+}
+
+func NewWebhookHandler(service service.WebhookService, logger logger.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *WebhookHandler) Create(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodPost) {
+		return
+	}
+
+	var req CreateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, h.logger, fmt.Errorf("%w: invalid body", ers.ErrInvalidInput))
+		return
+	}
+
+	webhook, err := h.service.Create(r.Context(), req.ToDTO())
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusCreated, webhook)
+}
+
+func (h *WebhookHandler) GetById(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodGet) {
+		return
+	}
+
+	id, err := response.PathID(r, h.logger, "id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	webhook, err := h.service.GetById(r.Context(), id)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusOK, webhook)
+}
+
+func (h *WebhookHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodGet) {
+		return
+	}
+
+	webhooks, err := h.service.GetAll(r.Context())
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusOK, webhooks)
+}
+
+func (h *WebhookHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodDelete) {
+		return
+	}
+
+	id, err := response.PathID(r, h.logger, "id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), id); err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusNoContent, nil)
+}
+
+func (h *WebhookHandler) Deliveries(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodGet) {
+		return
+	}
+
+	id, err := response.PathID(r, h.logger, "id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	filter := domain.DeliveryFilter{
+		Status: domain.DeliveryStatus(r.URL.Query().Get("status")),
+	}
+	if value := r.URL.Query().Get("limit"); value != "" {
+		if filter.Limit, err = strconv.Atoi(value); err != nil {
+			response.Error(w, h.logger, fmt.Errorf("%w: limit must be an integer", ers.ErrInvalidInput))
+			return
+		}
+	}
+
+	deliveries, err := h.service.Deliveries(r.Context(), id, filter)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusOK, deliveries)
+}
+
+func (h *WebhookHandler) Retry(w http.ResponseWriter, r *http.Request) {
+	if !response.CheckMethod(w, r, h.logger, http.MethodPost) {
+		return
+	}
+
+	id, err := response.PathID(r, h.logger, "id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+	deliveryID, err := response.PathID(r, h.logger, "delivery_id")
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	delivery, err := h.service.Retry(r.Context(), id, deliveryID)
+	if err != nil {
+		response.Error(w, h.logger, err)
+		return
+	}
+
+	response.JSON(w, h.logger, http.StatusOK, delivery)
+}
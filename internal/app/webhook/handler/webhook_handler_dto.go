@@ -0,0 +1,22 @@
+package handler
+
+import (
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+type CreateWebhookRequest struct {
+	URL        string             `json:"url"`
+	Events     []domain.EventType `json:"events"`
+	ProductIDs []uuid.UUID        `json:"product_ids"`
+	Secret     string             `json:"secret"`
+}
+
+func (r *CreateWebhookRequest) ToDTO() domain.CreateWebhookDTO {
+	return domain.CreateWebhookDTO{
+		URL:        r.URL,
+		Events:     r.Events,
+		ProductIDs: r.ProductIDs,
+		Secret:     r.Secret,
+	}
+}
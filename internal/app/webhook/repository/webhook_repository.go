@@ -0,0 +1,385 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	core "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/repository"
+	"github.com/lib/pq"
+)
+
+const deliveryColumns = `
+	d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.status, d.attempts,
+	d.response_status, d.last_error, d.next_attempt_at, d.delivered_at, d.created_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+type PostgresWebhookRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresWebhookRepository(db *sql.DB) *PostgresWebhookRepository {
+	return &PostgresWebhookRepository{
+		db: db,
+	}
+}
+
+func (i *PostgresWebhookRepository) Create(ctx context.Context, webhook *domain.Webhook) error {
+	query := `
+		INSERT INTO webhooks (id, url, secret, events, product_ids, active, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	if _, err := core.Conn(ctx, i.db).ExecContext(
+		ctx,
+		query,
+		webhook.ID,
+		webhook.URL,
+		webhook.Secret,
+		pq.Array(eventStrings(webhook.Events)),
+		pq.Array(uuidStrings(webhook.ProductIDs)),
+		webhook.Active,
+		webhook.CreatedAt,
+	); err != nil {
+		return fmt.Errorf("error inserting webhook: %w", err)
+	}
+
+	return nil
+}
+
+func (i *PostgresWebhookRepository) GetById(ctx context.Context, id uuid.UUID) (domain.Webhook, error) {
+	query := `
+		SELECT id, url, secret, events, product_ids, active, created_at
+		FROM webhooks
+		WHERE id = $1
+	`
+
+	webhook, err := scanWebhook(core.Conn(ctx, i.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Webhook{}, fmt.Errorf("%w: id %s", ers.ErrWebhookNotFound, id)
+		}
+		return domain.Webhook{}, fmt.Errorf("error getting webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+func (i *PostgresWebhookRepository) GetAll(ctx context.Context) ([]domain.Webhook, error) {
+	return i.list(ctx, `
+		SELECT id, url, secret, events, product_ids, active, created_at
+		FROM webhooks
+		ORDER BY created_at
+	`)
+}
+
+func (i *PostgresWebhookRepository) ListActive(ctx context.Context) ([]domain.Webhook, error) {
+	return i.list(ctx, `
+		SELECT id, url, secret, events, product_ids, active, created_at
+		FROM webhooks
+		WHERE active
+		ORDER BY created_at
+	`)
+}
+
+func (i *PostgresWebhookRepository) list(ctx context.Context, query string) ([]domain.Webhook, error) {
+	webhooks := []domain.Webhook{}
+
+	rows, err := core.Conn(ctx, i.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		if err := rows.Close(); err != nil {
+		}
+	}(rows)
+
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return webhooks, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+func (i *PostgresWebhookRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := core.Conn(ctx, i.db).ExecContext(ctx, `DELETE FROM webhooks WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("error deleting webhook: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: id %s", ers.ErrWebhookNotFound, id)
+	}
+
+	return nil
+}
+
+// Enqueue ignores a delivery that already exists for the same webhook and
+// event, so an event relayed twice is still delivered once.
+func (i *PostgresWebhookRepository) Enqueue(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	query := `
+		INSERT INTO webhook_deliveries (id, webhook_id, event_id, event_type, payload, status, next_attempt_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (webhook_id, event_id) DO NOTHING
+	`
+
+	if _, err := core.Conn(ctx, i.db).ExecContext(
+		ctx,
+		query,
+		delivery.ID,
+		delivery.WebhookID,
+		delivery.EventID,
+		delivery.EventType,
+		[]byte(delivery.Payload),
+		delivery.Status,
+		delivery.NextAttemptAt,
+		delivery.CreatedAt,
+	); err != nil {
+		return fmt.Errorf("error enqueueing webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimDue leases pending deliveries whose next attempt is due: the next
+// attempt moves to leaseUntil, so other dispatchers skip them while they are
+// sent outside the claiming transaction. SKIP LOCKED keeps concurrent claims
+// off each other's rows.
+func (i *PostgresWebhookRepository) ClaimDue(
+	ctx context.Context,
+	now time.Time,
+	leaseUntil time.Time,
+	limit int,
+) ([]DueDelivery, error) {
+	due := []DueDelivery{}
+
+	query := `
+		WITH due AS (
+			SELECT id
+			FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at, created_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		), claimed AS (
+			UPDATE webhook_deliveries d
+			SET next_attempt_at = $2
+			FROM due
+			WHERE d.id = due.id
+			RETURNING d.*
+		)
+		SELECT ` + deliveryColumns + `, w.url, w.secret
+		FROM claimed d
+		JOIN webhooks w ON w.id = d.webhook_id
+		ORDER BY d.created_at
+	`
+
+	rows, err := core.Conn(ctx, i.db).QueryContext(ctx, query, now, leaseUntil, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		if err := rows.Close(); err != nil {
+		}
+	}(rows)
+
+	for rows.Next() {
+		var d DueDelivery
+		delivery, err := scanDelivery(rows, &d.URL, &d.Secret)
+		if err != nil {
+			return due, err
+		}
+		d.WebhookDelivery = delivery
+		due = append(due, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return due, nil
+}
+
+func (i *PostgresWebhookRepository) UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, response_status = $4, last_error = $5,
+			next_attempt_at = $6, delivered_at = $7
+		WHERE id = $1
+	`
+
+	if _, err := core.Conn(ctx, i.db).ExecContext(
+		ctx,
+		query,
+		delivery.ID,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.ResponseStatus,
+		delivery.LastError,
+		delivery.NextAttemptAt,
+		delivery.DeliveredAt,
+	); err != nil {
+		return fmt.Errorf("error updating webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+func (i *PostgresWebhookRepository) GetDelivery(
+	ctx context.Context,
+	webhookID uuid.UUID,
+	id uuid.UUID,
+) (domain.WebhookDelivery, error) {
+	query := `
+		SELECT ` + deliveryColumns + `
+		FROM webhook_deliveries d
+		WHERE d.webhook_id = $1 AND d.id = $2
+	`
+
+	delivery, err := scanDelivery(core.Conn(ctx, i.db).QueryRowContext(ctx, query, webhookID, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.WebhookDelivery{}, fmt.Errorf("%w: id %s", ers.ErrDeliveryNotFound, id)
+		}
+		return domain.WebhookDelivery{}, fmt.Errorf("error getting webhook delivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+func (i *PostgresWebhookRepository) ListDeliveries(
+	ctx context.Context,
+	webhookID uuid.UUID,
+	filter domain.DeliveryFilter,
+) ([]domain.WebhookDelivery, error) {
+	deliveries := []domain.WebhookDelivery{}
+
+	query := `
+		SELECT ` + deliveryColumns + `
+		FROM webhook_deliveries d
+		WHERE d.webhook_id = $1 AND ($2 = '' OR d.status = $2)
+		ORDER BY d.created_at DESC, d.id
+		LIMIT $3
+	`
+
+	rows, err := core.Conn(ctx, i.db).QueryContext(ctx, query, webhookID, filter.Status, filter.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		if err := rows.Close(); err != nil {
+		}
+	}(rows)
+
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return deliveries, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func scanWebhook(row rowScanner) (domain.Webhook, error) {
+	var (
+		webhook    domain.Webhook
+		events     []string
+		productIDs []string
+	)
+
+	if err := row.Scan(
+		&webhook.ID,
+		&webhook.URL,
+		&webhook.Secret,
+		pq.Array(&events),
+		pq.Array(&productIDs),
+		&webhook.Active,
+		&webhook.CreatedAt,
+	); err != nil {
+		return domain.Webhook{}, err
+	}
+
+	webhook.Events = make([]domain.EventType, 0, len(events))
+	for _, e := range events {
+		webhook.Events = append(webhook.Events, domain.EventType(e))
+	}
+	webhook.ProductIDs = make([]uuid.UUID, 0, len(productIDs))
+	for _, id := range productIDs {
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			return domain.Webhook{}, err
+		}
+		webhook.ProductIDs = append(webhook.ProductIDs, parsed)
+	}
+
+	return webhook, nil
+}
+
+func scanDelivery(row rowScanner, extra ...interface{}) (domain.WebhookDelivery, error) {
+	var (
+		delivery domain.WebhookDelivery
+		payload  []byte
+	)
+
+	dest := []interface{}{
+		&delivery.ID,
+		&delivery.WebhookID,
+		&delivery.EventID,
+		&delivery.EventType,
+		&payload,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.ResponseStatus,
+		&delivery.LastError,
+		&delivery.NextAttemptAt,
+		&delivery.DeliveredAt,
+		&delivery.CreatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+	delivery.Payload = payload
+
+	return delivery, nil
+}
+
+func eventStrings(events []domain.EventType) []string {
+	out := make([]string, 0, len(events))
+	for _, e := range events {
+		out = append(out, string(e))
+	}
+	return out
+}
+
+func uuidStrings(ids []uuid.UUID) []string {
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, id.String())
+	}
+	return out
+}
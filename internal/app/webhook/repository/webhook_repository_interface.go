@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+// DueDelivery is a pending delivery together with where and how to send it.
+type DueDelivery struct {
+	domain.WebhookDelivery
+	URL    string
+	Secret string
+}
+
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *domain.Webhook) error
+	GetById(ctx context.Context, id uuid.UUID) (domain.Webhook, error)
+	GetAll(ctx context.Context) ([]domain.Webhook, error)
+	ListActive(ctx context.Context) ([]domain.Webhook, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	Enqueue(ctx context.Context, delivery *domain.WebhookDelivery) error
+	ClaimDue(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]DueDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+	GetDelivery(ctx context.Context, webhookID uuid.UUID, id uuid.UUID) (domain.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, webhookID uuid.UUID, filter domain.DeliveryFilter) ([]domain.WebhookDelivery, error)
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/webhook/repository"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/backoff"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/logger"
+	core "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/repository"
+)
+
+const (
+	HeaderSignature = "X-Webhook-Signature"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderEventID   = "X-Webhook-Event-ID"
+	HeaderEventType = "X-Webhook-Event-Type"
+
+	baseRetryDelay = 10 * time.Second
+	maxRetryDelay  = time.Hour
+	maxErrorLength = 500
+	// claimLease keeps a claimed delivery away from other dispatchers while it
+	// is sent; if this one dies mid-batch, the delivery is retried once the
+	// lease runs out.
+	claimLease = 10 * time.Minute
+)
+
+// Sign returns the hex HMAC-SHA256 of "<timestamp>.<body>". Receivers should
+// recompute it with their secret and reject stale timestamps.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Dispatcher sends queued webhook deliveries. Failures are retried with
+// exponential backoff; after maxAttempts the delivery is marked dead.
+type Dispatcher struct {
+	repo        repository.WebhookRepository
+	tx          core.Transactor
+	client      *http.Client
+	maxAttempts int
+	batchSize   int
+	interval    time.Duration
+	logger      logger.Logger
+}
+
+func NewDispatcher(
+	repo repository.WebhookRepository,
+	tx core.Transactor,
+	client *http.Client,
+	maxAttempts int,
+	batchSize int,
+	interval time.Duration,
+	logger logger.Logger,
+) *Dispatcher {
+	return &Dispatcher{
+		repo:        repo,
+		tx:          tx,
+		client:      client,
+		maxAttempts: maxAttempts,
+		batchSize:   batchSize,
+		interval:    interval,
+		logger:      logger,
+	}
+}
+
+// Run dispatches due deliveries every interval until ctx is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			delivered, err := d.DispatchOnce(ctx)
+			if err != nil {
+				d.logger.Error("failed to dispatch webhooks: %v", err)
+				continue
+			}
+			if delivered > 0 {
+				d.logger.Info("delivered %d webhooks", delivered)
+			}
+		}
+	}
+}
+
+// DispatchOnce sends one batch of due deliveries and returns how many succeeded.
+// The batch is claimed in a short transaction and sent outside of it, so a slow
+// endpoint holds no locks; each delivery is then updated on its own.
+func (d *Dispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	var due []repository.DueDelivery
+
+	err := d.tx.WithinTx(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		claimed, err := d.repo.ClaimDue(ctx, now, now.Add(claimLease), d.batchSize)
+		if err != nil {
+			return err
+		}
+		due = claimed
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, job := range due {
+		delivery := job.WebhookDelivery
+		status, sendErr := d.send(ctx, job)
+
+		now := time.Now()
+		delivery.Attempts++
+		delivery.ResponseStatus = status
+
+		switch {
+		case sendErr == nil:
+			delivery.Status = domain.DeliveryDelivered
+			delivery.LastError = ""
+			delivery.DeliveredAt = &now
+			delivery.NextAttemptAt = nil
+			delivered++
+		case delivery.Attempts >= d.maxAttempts:
+			delivery.Status = domain.DeliveryDead
+			delivery.LastError = truncate(sendErr.Error())
+			delivery.NextAttemptAt = nil
+			d.logger.Warn("webhook delivery %s is dead after %d attempts: %v", delivery.ID, delivery.Attempts, sendErr)
+		default:
+			next := now.Add(retryDelay(delivery.Attempts))
+			delivery.LastError = truncate(sendErr.Error())
+			delivery.NextAttemptAt = &next
+		}
+
+		if err := d.repo.UpdateDelivery(ctx, &delivery); err != nil {
+			return delivered, err
+		}
+	}
+
+	return delivered, nil
+}
+
+func (d *Dispatcher) send(ctx context.Context, job repository.DueDelivery) (int, error) {
+	timestamp := time.Now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, job.URL, bytes.NewReader(job.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEventID, job.EventID.String())
+	req.Header.Set(HeaderEventType, string(job.EventType))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(job.Secret, timestamp, job.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("endpoint responded with %s", resp.Status)
+	}
+
+	return resp.StatusCode, nil
+}
+
+func retryDelay(attempt int) time.Duration {
+	return backoff.Exponential(baseRetryDelay, maxRetryDelay, attempt)
+}
+
+func truncate(s string) string {
+	if len(s) > maxErrorLength {
+		return s[:maxErrorLength]
+	}
+	return s
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/webhook/repository"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/logger"
+)
+
+type memoryDeliveries struct {
+	repository.WebhookRepository
+	due     []repository.DueDelivery
+	updated map[uuid.UUID]domain.WebhookDelivery
+}
+
+func (m *memoryDeliveries) ClaimDue(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]repository.DueDelivery, error) {
+	return m.due, nil
+}
+
+func (m *memoryDeliveries) UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	m.updated[delivery.ID] = *delivery
+	return nil
+}
+
+type inlineTx struct{}
+
+func (inlineTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// trackingTx reports whether a call is made while its transaction is open.
+type trackingTx struct {
+	open *bool
+}
+
+func (t trackingTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	*t.open = true
+	defer func() { *t.open = false }()
+	return fn(ctx)
+}
+
+func newDue(url string, attempts int) repository.DueDelivery {
+	return repository.DueDelivery{
+		WebhookDelivery: domain.WebhookDelivery{
+			ID:        uuid.New(),
+			WebhookID: uuid.New(),
+			EventID:   uuid.New(),
+			EventType: domain.EventStockChanged,
+			Payload:   []byte(`{"type":"stock.changed"}`),
+			Status:    domain.DeliveryPending,
+			Attempts:  attempts,
+		},
+		URL:    url,
+		Secret: "0123456789abcdef",
+	}
+}
+
+func TestDispatcher_SignsAndDelivers(t *testing.T) {
+	var signatureOK bool
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, _ := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+		signatureOK = r.Header.Get(HeaderSignature) == Sign("0123456789abcdef", timestamp, body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	job := newDue(receiver.URL, 0)
+	repo := &memoryDeliveries{due: []repository.DueDelivery{job}, updated: map[uuid.UUID]domain.WebhookDelivery{}}
+	d := NewDispatcher(repo, inlineTx{}, receiver.Client(), 3, 10, time.Second, logger.New(io.Discard))
+
+	delivered, err := d.DispatchOnce(context.Background())
+	if err != nil {
+		t.Fatalf("dispatch failed: %s", err)
+	}
+	if delivered != 1 {
+		t.Fatalf("expected 1 delivery, got %d", delivered)
+	}
+	if !signatureOK {
+		t.Errorf("receiver could not verify the signature")
+	}
+
+	got := repo.updated[job.ID]
+	if got.Status != domain.DeliveryDelivered || got.ResponseStatus != http.StatusNoContent || got.DeliveredAt == nil {
+		t.Errorf("unexpected delivery state: %+v", got)
+	}
+}
+
+func TestDispatcher_SendsOutsideTransaction(t *testing.T) {
+	open := false
+	var sentInTx bool
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sentInTx = open
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	job := newDue(receiver.URL, 0)
+	repo := &memoryDeliveries{due: []repository.DueDelivery{job}, updated: map[uuid.UUID]domain.WebhookDelivery{}}
+	d := NewDispatcher(repo, trackingTx{open: &open}, receiver.Client(), 3, 10, time.Second, logger.New(io.Discard))
+
+	if _, err := d.DispatchOnce(context.Background()); err != nil {
+		t.Fatalf("dispatch failed: %s", err)
+	}
+	if sentInTx {
+		t.Errorf("expected the delivery to be sent outside the claim transaction")
+	}
+	if repo.updated[job.ID].Status != domain.DeliveryDelivered {
+		t.Errorf("expected status %s, but got %s", domain.DeliveryDelivered, repo.updated[job.ID].Status)
+	}
+}
+
+func TestDispatcher_RetriesThenDeadLetters(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	retrying := newDue(receiver.URL, 0)
+	exhausted := newDue(receiver.URL, 2)
+	repo := &memoryDeliveries{
+		due:     []repository.DueDelivery{retrying, exhausted},
+		updated: map[uuid.UUID]domain.WebhookDelivery{},
+	}
+	d := NewDispatcher(repo, inlineTx{}, receiver.Client(), 3, 10, time.Second, logger.New(io.Discard))
+
+	delivered, err := d.DispatchOnce(context.Background())
+	if err != nil {
+		t.Fatalf("dispatch failed: %s", err)
+	}
+	if delivered != 0 {
+		t.Fatalf("expected no deliveries, got %d", delivered)
+	}
+
+	got := repo.updated[retrying.ID]
+	if got.Status != domain.DeliveryPending || got.Attempts != 1 || got.NextAttemptAt == nil {
+		t.Errorf("expected a scheduled retry, got %+v", got)
+	}
+	if got.ResponseStatus != http.StatusServiceUnavailable || got.LastError == "" {
+		t.Errorf("expected the failure to be recorded, got %+v", got)
+	}
+
+	dead := repo.updated[exhausted.ID]
+	if dead.Status != domain.DeliveryDead || dead.NextAttemptAt != nil {
+		t.Errorf("expected a dead delivery, got %+v", dead)
+	}
+}
+
+func TestWebhook_Matches(t *testing.T) {
+	productID := uuid.New()
+	webhook := domain.Webhook{
+		Active:     true,
+		Events:     []domain.EventType{domain.EventStockChanged},
+		ProductIDs: []uuid.UUID{productID},
+	}
+
+	event := domain.Event{Type: domain.EventStockChanged, AggregateType: domain.AggregateProduct, AggregateID: productID}
+	if !webhook.Matches(event) {
+		t.Errorf("expected stock change of a watched product to match")
+	}
+
+	event.AggregateID = uuid.New()
+	if webhook.Matches(event) {
+		t.Errorf("expected other products to be filtered out")
+	}
+
+	event.AggregateID = productID
+	event.Type = domain.EventProductUpdated
+	if webhook.Matches(event) {
+		t.Errorf("expected other event types to be filtered out")
+	}
+}
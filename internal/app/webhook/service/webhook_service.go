@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/app/webhook/repository"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+)
+
+const (
+	DefaultDeliveryLimit = 100
+	MaxDeliveryLimit     = 1000
+
+	minSecretLength = 16
+)
+
+type webhookService struct {
+	repo repository.WebhookRepository
+}
+
+func NewWebhookService(repo repository.WebhookRepository) WebhookService {
+	return &webhookService{
+		repo: repo,
+	}
+}
+
+// Create registers a webhook. A secret is generated unless the caller brings
+// its own; either way it is returned only in this response.
+func (s *webhookService) Create(ctx context.Context, dto domain.CreateWebhookDTO) (domain.Webhook, error) {
+	if err := s.validateWebhook(ctx, dto); err != nil {
+		return domain.Webhook{}, err
+	}
+
+	webhook := domain.Webhook{
+		ID:         uuid.New(),
+		URL:        dto.URL,
+		Events:     dto.Events,
+		ProductIDs: dto.ProductIDs,
+		Secret:     dto.Secret,
+		Active:     true,
+		CreatedAt:  time.Now(),
+	}
+	if webhook.Events == nil {
+		webhook.Events = []domain.EventType{}
+	}
+	if webhook.ProductIDs == nil {
+		webhook.ProductIDs = []uuid.UUID{}
+	}
+	if webhook.Secret == "" {
+		secret, err := newSecret()
+		if err != nil {
+			return domain.Webhook{}, err
+		}
+		webhook.Secret = secret
+	}
+
+	if err := s.repo.Create(ctx, &webhook); err != nil {
+		return domain.Webhook{}, err
+	}
+
+	return webhook, nil
+}
+
+func (s *webhookService) GetById(ctx context.Context, id uuid.UUID) (domain.Webhook, error) {
+	webhook, err := s.repo.GetById(ctx, id)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+	webhook.Secret = ""
+	return webhook, nil
+}
+
+func (s *webhookService) GetAll(ctx context.Context) ([]domain.Webhook, error) {
+	webhooks, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+	return webhooks, nil
+}
+
+func (s *webhookService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *webhookService) Deliveries(
+	ctx context.Context,
+	id uuid.UUID,
+	filter domain.DeliveryFilter,
+) ([]domain.WebhookDelivery, error) {
+	switch {
+	case filter.Limit == 0:
+		filter.Limit = DefaultDeliveryLimit
+	case filter.Limit < 0 || filter.Limit > MaxDeliveryLimit:
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", ers.ErrInvalidInput, MaxDeliveryLimit)
+	}
+
+	switch filter.Status {
+	case "", domain.DeliveryPending, domain.DeliveryDelivered, domain.DeliveryDead:
+	default:
+		return nil, fmt.Errorf("%w: unknown delivery status %q", ers.ErrInvalidInput, filter.Status)
+	}
+
+	if _, err := s.repo.GetById(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListDeliveries(ctx, id, filter)
+}
+
+// Retry moves a dead delivery back to pending with a fresh attempt budget.
+func (s *webhookService) Retry(
+	ctx context.Context,
+	id uuid.UUID,
+	deliveryID uuid.UUID,
+) (domain.WebhookDelivery, error) {
+	delivery, err := s.repo.GetDelivery(ctx, id, deliveryID)
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+	if delivery.Status != domain.DeliveryDead {
+		return domain.WebhookDelivery{}, fmt.Errorf(
+			"%w: only dead deliveries can be retried, this one is %s",
+			ers.ErrInvalidDeliveryState,
+			delivery.Status,
+		)
+	}
+
+	now := time.Now()
+	delivery.Status = domain.DeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = &now
+
+	if err := s.repo.UpdateDelivery(ctx, &delivery); err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+
+	return delivery, nil
+}
+
+func (s *webhookService) Publish(ctx context.Context, event domain.Event) error {
+	webhooks, err := s.repo.ListActive(ctx)
+	if err != nil {
+		return err
+	}
+
+	var body []byte
+	for _, webhook := range webhooks {
+		if !webhook.Matches(event) {
+			continue
+		}
+
+		if body == nil {
+			if body, err = json.Marshal(event); err != nil {
+				return fmt.Errorf("error encoding event: %w", err)
+			}
+		}
+
+		now := time.Now()
+		if err := s.repo.Enqueue(ctx, &domain.WebhookDelivery{
+			ID:            uuid.New(),
+			WebhookID:     webhook.ID,
+			EventID:       event.ID,
+			EventType:     event.Type,
+			Payload:       body,
+			Status:        domain.DeliveryPending,
+			NextAttemptAt: &now,
+			CreatedAt:     now,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *webhookService) validateWebhook(ctx context.Context, dto domain.CreateWebhookDTO) error {
+	u, err := url.Parse(dto.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return fmt.Errorf("%w: webhook url must be an absolute http(s) url", ers.ErrInvalidInput)
+	}
+	if err := checkTarget(ctx, u.Hostname()); err != nil {
+		return fmt.Errorf("%w: webhook url: %s", ers.ErrInvalidInput, err)
+	}
+
+	for _, e := range dto.Events {
+		switch e {
+		case domain.EventProductCreated, domain.EventProductUpdated, domain.EventProductDeleted, domain.EventStockChanged:
+		default:
+			return fmt.Errorf("%w: unknown event %q", ers.ErrInvalidInput, e)
+		}
+	}
+
+	for _, id := range dto.ProductIDs {
+		if id == uuid.Nil {
+			return fmt.Errorf("%w: product id cannot be empty", ers.ErrInvalidInput)
+		}
+	}
+
+	if dto.Secret != "" && len(dto.Secret) < minSecretLength {
+		return fmt.Errorf("%w: secret must be at least %d characters", ers.ErrInvalidInput, minSecretLength)
+	}
+
+	return nil
+}
+
+func newSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("error generating webhook secret: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
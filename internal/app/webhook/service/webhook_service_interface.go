@@ -0,0 +1,20 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+type WebhookService interface {
+	Create(ctx context.Context, dto domain.CreateWebhookDTO) (domain.Webhook, error)
+	GetById(ctx context.Context, id uuid.UUID) (domain.Webhook, error)
+	GetAll(ctx context.Context) ([]domain.Webhook, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	Deliveries(ctx context.Context, id uuid.UUID, filter domain.DeliveryFilter) ([]domain.WebhookDelivery, error)
+	Retry(ctx context.Context, id uuid.UUID, deliveryID uuid.UUID) (domain.WebhookDelivery, error)
+
+	// Publish queues the event for every matching webhook; it makes the service an outbox publisher.
+	Publish(ctx context.Context, event domain.Event) error
+}
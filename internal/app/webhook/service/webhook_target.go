@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// internalIP reports whether ip points back into our own network: loopback,
+// private ranges, link-local (cloud metadata lives there) or unspecified.
+func internalIP(ip net.IP) bool {
+	return ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsUnspecified()
+}
+
+// checkTarget resolves host and fails if any of its addresses is internal, so
+// a partner cannot point a webhook at our own services.
+func checkTarget(ctx context.Context, host string) error {
+	if ip := net.ParseIP(host); ip != nil {
+		if internalIP(ip) {
+			return fmt.Errorf("address %s is not public", ip)
+		}
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return fmt.Errorf("cannot resolve %s", host)
+	}
+	for _, addr := range addrs {
+		if internalIP(addr.IP) {
+			return fmt.Errorf("%s resolves to %s, which is not public", host, addr.IP)
+		}
+	}
+	return nil
+}
+
+// NewClient returns the HTTP client for webhook deliveries. Registration
+// already rejects internal targets, but DNS can change afterwards, so the
+// dialer checks every address it actually connects to, redirects included.
+func NewClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || internalIP(ip) {
+				return fmt.Errorf("refusing to deliver webhook to %s: address is not public", host)
+			}
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConnsPerHost: 4,
+		},
+	}
+}
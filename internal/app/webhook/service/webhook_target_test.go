@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+)
+
+func TestValidateWebhook_InternalTargets(t *testing.T) {
+	s := &webhookService{}
+
+	for _, url := range []string{
+		"http://127.0.0.1:8080/hook",
+		"http://localhost/hook",
+		"http://10.0.0.5/hook",
+		"http://192.168.1.10/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://[::1]/hook",
+		"http://0.0.0.0/hook",
+	} {
+		err := s.validateWebhook(context.Background(), domain.CreateWebhookDTO{URL: url})
+		if !errors.Is(err, ers.ErrInvalidInput) {
+			t.Errorf("url %s: expected error to be %v, but got %v", url, ers.ErrInvalidInput, err)
+		}
+	}
+}
+
+func TestValidateWebhook_PublicTarget(t *testing.T) {
+	s := &webhookService{}
+
+	if err := s.validateWebhook(context.Background(), domain.CreateWebhookDTO{URL: "https://93.184.216.34/hook"}); err != nil {
+		t.Fatalf("webhook validation failed: %s", err)
+	}
+}
+
+func TestNewClient_RefusesInternalAddress(t *testing.T) {
+	var called bool
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer receiver.Close()
+
+	resp, err := NewClient(time.Second).Get(receiver.URL)
+	if err == nil {
+		resp.Body.Close()
+		t.Fatalf("expected the dial to %s to be refused, but got %s", receiver.URL, resp.Status)
+	}
+	if called {
+		t.Errorf("expected the receiver not to be reached")
+	}
+}
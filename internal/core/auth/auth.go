@@ -0,0 +1,27 @@
+package auth
+
+import "context"
+
+const (
+	HeaderActorID   = "X-Actor-ID"
+	HeaderActorRole = "X-Actor-Role"
+
+	RoleAdmin = "admin"
+)
+
+// Actor is the caller identity forwarded by the API gateway.
+type Actor struct {
+	ID   string
+	Role string
+}
+
+type actorKey struct{}
+
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+func ActorFrom(ctx context.Context) Actor {
+	actor, _ := ctx.Value(actorKey{}).(Actor)
+	return actor
+}
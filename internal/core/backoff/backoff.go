@@ -0,0 +1,16 @@
+package backoff
+
+import "time"
+
+// Exponential returns the delay before the given attempt: base for the first
+// one, doubling with every attempt after it and capped at max.
+func Exponential(base time.Duration, max time.Duration, attempt int) time.Duration {
+	delay := base
+	for i := 1; i < attempt && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		delay = max
+	}
+	return delay
+}
@@ -0,0 +1,24 @@
+package backoff
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExponential_Doubles(t *testing.T) {
+	if d := Exponential(time.Second, time.Minute, 1); d != time.Second {
+		t.Errorf("expected 1s for the first attempt, but got %s", d)
+	}
+	if d := Exponential(time.Second, time.Minute, 4); d != 8*time.Second {
+		t.Errorf("expected 8s for the fourth attempt, but got %s", d)
+	}
+}
+
+func TestExponential_Capped(t *testing.T) {
+	if d := Exponential(10*time.Second, time.Hour, 50); d != time.Hour {
+		t.Errorf("expected delay capped at %s, but got %s", time.Hour, d)
+	}
+	if d := Exponential(time.Hour, time.Minute, 1); d != time.Minute {
+		t.Errorf("expected a base over the cap to be capped at %s, but got %s", time.Minute, d)
+	}
+}
@@ -9,9 +9,17 @@ import (
 )
 
 type Config struct {
-	DB     DBConfig
-	HTTP   HTTPConfig
-	Logger LoggerConfig
+	DB          DBConfig
+	HTTP        HTTPConfig
+	GRPC        GRPCConfig
+	Logger      LoggerConfig
+	Reservation ReservationConfig
+	Product     ProductConfig
+	Outbox      OutboxConfig
+	Webhook     WebhookConfig
+	Import      ImportConfig
+	Feed        FeedConfig
+	Idempotency IdempotencyConfig
 }
 
 type DBConfig struct {
@@ -28,10 +36,70 @@ type HTTPConfig struct {
 	Timeout time.Duration `env:"HTTP_TIMEOUT" env-default:"10s"`
 }
 
+type GRPCConfig struct {
+	Port string `env:"GRPC_PORT" env-default:"9090"`
+}
+
 type LoggerConfig struct {
 	Level string `env:"LOG_LEVEL" env-default:"info"`
 }
 
+type ReservationConfig struct {
+	DefaultTTL    time.Duration `env:"RESERVATION_DEFAULT_TTL" env-default:"15m"`
+	MaxTTL        time.Duration `env:"RESERVATION_MAX_TTL" env-default:"2h"`
+	SweepInterval time.Duration `env:"RESERVATION_SWEEP_INTERVAL" env-default:"30s"`
+}
+
+type ProductConfig struct {
+	PurgeRetention time.Duration `env:"PRODUCT_PURGE_RETENTION" env-default:"720h"`
+	PurgeInterval  time.Duration `env:"PRODUCT_PURGE_INTERVAL" env-default:"1h"`
+}
+
+// OutboxConfig selects the relay publisher: "log", "webhook" (POST to
+// OUTBOX_WEBHOOK_URL) or "memory".
+type OutboxConfig struct {
+	Publisher      string        `env:"OUTBOX_PUBLISHER" env-default:"log"`
+	WebhookURL     string        `env:"OUTBOX_WEBHOOK_URL"`
+	WebhookTimeout time.Duration `env:"OUTBOX_WEBHOOK_TIMEOUT" env-default:"5s"`
+	BatchSize      int           `env:"OUTBOX_BATCH_SIZE" env-default:"100"`
+	RelayInterval  time.Duration `env:"OUTBOX_RELAY_INTERVAL" env-default:"1s"`
+}
+
+type WebhookConfig struct {
+	MaxAttempts      int           `env:"WEBHOOK_MAX_ATTEMPTS" env-default:"8"`
+	Timeout          time.Duration `env:"WEBHOOK_TIMEOUT" env-default:"5s"`
+	BatchSize        int           `env:"WEBHOOK_BATCH_SIZE" env-default:"50"`
+	DispatchInterval time.Duration `env:"WEBHOOK_DISPATCH_INTERVAL" env-default:"2s"`
+}
+
+// ImportConfig bounds product imports: files with more than SyncRows rows
+// are queued as jobs instead of being processed inside the request.
+type ImportConfig struct {
+	MaxFileSize    int64         `env:"IMPORT_MAX_FILE_SIZE" env-default:"20971520"`
+	SyncRows       int           `env:"IMPORT_SYNC_ROWS" env-default:"500"`
+	WorkerInterval time.Duration `env:"IMPORT_WORKER_INTERVAL" env-default:"2s"`
+}
+
+// FeedConfig describes the storefront advertised in the marketplace feeds and
+// where the generated feeds are cached.
+type FeedConfig struct {
+	Dir             string        `env:"FEED_DIR" env-default:"/tmp/feeds"`
+	RefreshInterval time.Duration `env:"FEED_REFRESH_INTERVAL" env-default:"1h"`
+	ShopName        string        `env:"FEED_SHOP_NAME" env-default:"Marketplace"`
+	ShopCompany     string        `env:"FEED_SHOP_COMPANY" env-default:"Marketplace"`
+	ShopURL         string        `env:"FEED_SHOP_URL" env-default:"http://localhost:8080"`
+	Currency        string        `env:"FEED_CURRENCY" env-default:"RUB"`
+}
+
+// IdempotencyConfig sets how long responses to requests with an
+// Idempotency-Key are kept for replay. MaxBodySize bounds both the request
+// body buffered for hashing and the response kept for replay.
+type IdempotencyConfig struct {
+	TTL           time.Duration `env:"IDEMPOTENCY_TTL" env-default:"24h"`
+	PurgeInterval time.Duration `env:"IDEMPOTENCY_PURGE_INTERVAL" env-default:"1h"`
+	MaxBodySize   int64         `env:"IDEMPOTENCY_MAX_BODY_SIZE" env-default:"20971520"`
+}
+
 func MustLoadConfig() *Config {
 	var cfg Config
 
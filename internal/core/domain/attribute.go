@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AttributeType string
+
+const (
+	AttributeString  AttributeType = "string"
+	AttributeNumber  AttributeType = "number"
+	AttributeEnum    AttributeType = "enum"
+	AttributeBoolean AttributeType = "boolean"
+)
+
+var attributeCodePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// ValidAttributeCode reports whether code can name an attribute; codes appear
+// in query strings as attr.<code>.
+func ValidAttributeCode(code string) bool {
+	return attributeCodePattern.MatchString(code)
+}
+
+// AttributeDefinition is a typed field defined on a category. It applies to the
+// products of the category and of all its descendants. Options lists the
+// allowed values of an enum.
+type AttributeDefinition struct {
+	ID         uuid.UUID     `json:"id"`
+	CategoryID uuid.UUID     `json:"category_id"`
+	Code       string        `json:"code"`
+	Name       string        `json:"name"`
+	Type       AttributeType `json:"type"`
+	Unit       string        `json:"unit,omitempty"`
+	Required   bool          `json:"required"`
+	Options    []string      `json:"options,omitempty"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+}
+
+type UpdateAttributeDTO struct {
+	Name     *string   `json:"name,omitempty"`
+	Unit     *string   `json:"unit,omitempty"`
+	Required *bool     `json:"required,omitempty"`
+	Options  *[]string `json:"options,omitempty"`
+}
+
+// AttributeValue is the value of one attribute on a product: a string for
+// string and enum attributes, a float64 for numbers and a bool for booleans.
+type AttributeValue struct {
+	AttributeID uuid.UUID     `json:"attribute_id"`
+	Code        string        `json:"code"`
+	Name        string        `json:"name"`
+	Type        AttributeType `json:"type"`
+	Unit        string        `json:"unit,omitempty"`
+	Value       interface{}   `json:"value"`
+}
+
+// AttributeFilter narrows a product list to products whose attribute with the
+// given code equals one of Values or, for numbers, lies within Min and Max.
+type AttributeFilter struct {
+	Code   string
+	Values []string
+	Min    *float64
+	Max    *float64
+}
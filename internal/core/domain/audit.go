@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditAction string
+
+const (
+	AuditCreate  AuditAction = "create"
+	AuditUpdate  AuditAction = "update"
+	AuditDelete  AuditAction = "delete"
+	AuditRestore AuditAction = "restore"
+)
+
+const AuditEntityProduct = "product"
+
+// AuditEntry keeps full before/after snapshots of the entity; Before is null on
+// create and After is null when nothing is left to show.
+type AuditEntry struct {
+	ID         uuid.UUID       `json:"id"`
+	EntityType string          `json:"entity_type"`
+	EntityID   uuid.UUID       `json:"entity_id"`
+	Action     AuditAction     `json:"action"`
+	Actor      string          `json:"actor"`
+	RequestID  string          `json:"request_id"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+type AuditFilter struct {
+	EntityType string
+	EntityID   *uuid.UUID
+	Actor      string
+	Action     AuditAction
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+}
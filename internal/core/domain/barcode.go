@@ -0,0 +1,38 @@
+package domain
+
+import "strings"
+
+type BarcodeType string
+
+const (
+	BarcodeEAN13  BarcodeType = "ean13"
+	BarcodeUPCA   BarcodeType = "upca"
+	BarcodeGTIN14 BarcodeType = "gtin14"
+)
+
+// Barcode is a GTIN printed on the product. The type follows from the number
+// of digits: 12 for UPC-A, 13 for EAN-13 and 14 for GTIN-14.
+type Barcode struct {
+	Code string      `json:"code"`
+	Type BarcodeType `json:"type"`
+}
+
+// GTIN14 pads the code with leading zeros to 14 digits, the form in which
+// UPC-A, EAN-13 and GTIN-14 codes for the same item compare equal.
+func (b Barcode) GTIN14() string {
+	if len(b.Code) >= 14 {
+		return b.Code
+	}
+	return strings.Repeat("0", 14-len(b.Code)) + b.Code
+}
+
+var barcodeTypes = map[int]BarcodeType{
+	12: BarcodeUPCA,
+	13: BarcodeEAN13,
+	14: BarcodeGTIN14,
+}
+
+// BarcodeTypeOf returns the type for a code of the given length, or "" if no GTIN has that length.
+func BarcodeTypeOf(code string) BarcodeType {
+	return barcodeTypes[len(code)]
+}
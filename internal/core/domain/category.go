@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Category is a node of the category tree. Path lists the ids from the root
+// down to the category itself, e.g. "/<root id>/<id>/", so a category's
+// descendants are the categories whose path starts with its own.
+type Category struct {
+	ID        uuid.UUID  `json:"id"`
+	ParentID  *uuid.UUID `json:"parent_id,omitempty"`
+	Name      string     `json:"name"`
+	Path      string     `json:"path"`
+	Children  []Category `json:"children,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// Depth is 1 for root categories.
+func (c Category) Depth() int {
+	return strings.Count(c.Path, "/") - 1
+}
+
+// UpdateCategoryDTO renames and/or moves a category. A ParentID of uuid.Nil
+// moves the category to the root.
+type UpdateCategoryDTO struct {
+	Name     *string    `json:"name,omitempty"`
+	ParentID *uuid.UUID `json:"parent_id,omitempty"`
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EventType string
+
+const (
+	EventProductCreated EventType = "product.created"
+	EventProductUpdated EventType = "product.updated"
+	EventProductDeleted EventType = "product.deleted"
+	EventStockChanged   EventType = "stock.changed"
+)
+
+const AggregateProduct = "product"
+
+// Event is a domain event waiting in the outbox. It is written in the same
+// transaction as the change it describes and published later by the relay.
+type Event struct {
+	ID            uuid.UUID       `json:"id"`
+	Type          EventType       `json:"type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   uuid.UUID       `json:"aggregate_id"`
+	Payload       json.RawMessage `json:"payload"`
+	RequestID     string          `json:"request_id,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+	Attempts      int             `json:"-"`
+}
+
+// ProductDeleted is the payload of EventProductDeleted.
+type ProductDeleted struct {
+	ID        uuid.UUID `json:"id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// StockChanged is the payload of EventStockChanged.
+type StockChanged struct {
+	ProductID         uuid.UUID    `json:"product_id"`
+	WarehouseID       uuid.UUID    `json:"warehouse_id"`
+	MovementID        uuid.UUID    `json:"movement_id"`
+	MovementType      MovementType `json:"movement_type"`
+	Delta             int          `json:"delta"`
+	Quantity          int          `json:"quantity"`
+	WarehouseQuantity int          `json:"warehouse_quantity"`
+	ReasonCode        string       `json:"reason_code,omitempty"`
+	CorrelationID     string       `json:"correlation_id,omitempty"`
+}
@@ -0,0 +1,20 @@
+package domain
+
+// FeedFormat names a marketplace feed; it is also the feed's file name without ".xml".
+type FeedFormat string
+
+const (
+	FeedYandex FeedFormat = "yml"
+	FeedGoogle FeedFormat = "google"
+)
+
+var FeedFormats = []FeedFormat{FeedYandex, FeedGoogle}
+
+// FeedShop is the storefront the feeds send buyers to. Product pages live at
+// URL + "/product/" + product id.
+type FeedShop struct {
+	Name     string
+	Company  string
+	URL      string
+	Currency string
+}
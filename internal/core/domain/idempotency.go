@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdempotencyRecord is a stored response to a request sent with an
+// Idempotency-Key. Status stays 0 while the first request is still running.
+// Token identifies the reservation, so a request whose stale reservation was
+// taken over cannot complete or release the new one.
+type IdempotencyRecord struct {
+	Actor       string
+	Key         string
+	Token       uuid.UUID
+	RequestHash string
+	Status      int
+	Header      http.Header
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
@@ -0,0 +1,246 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+)
+
+// DefaultCurrency is assumed when a price comes without a currency.
+const DefaultCurrency = "RUB"
+
+// currencyExponents maps the supported ISO 4217 codes to the number of digits
+// after the decimal point, i.e. how many minor units make up one major unit.
+var currencyExponents = map[string]int{
+	"RUB": 2,
+	"BYN": 2,
+	"KZT": 2,
+	"UZS": 2,
+	"KGS": 2,
+	"AMD": 2,
+	"GEL": 2,
+	"AZN": 2,
+	"CNY": 2,
+	"TRY": 2,
+	"USD": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"JPY": 0,
+}
+
+// CurrencyExponent returns the number of minor-unit digits of a currency.
+func CurrencyExponent(currency string) (int, bool) {
+	exponent, ok := currencyExponents[currency]
+	return exponent, ok
+}
+
+func ValidCurrency(currency string) bool {
+	_, ok := currencyExponents[currency]
+	return ok
+}
+
+// Money is an amount in the minor units of its currency, e.g. kopecks for RUB.
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// ParseMoney reads a decimal amount in major units such as "1500", "1500.5"
+// or "1500.50" and converts it to minor units of the currency.
+func ParseMoney(s string, currency string) (Money, error) {
+	exponent, ok := CurrencyExponent(currency)
+	if !ok {
+		return Money{}, fmt.Errorf("%w: unsupported currency %q", ers.ErrInvalidInput, currency)
+	}
+
+	s = strings.TrimSpace(s)
+	negative := strings.HasPrefix(s, "-")
+	whole, fraction, _ := strings.Cut(strings.TrimPrefix(s, "-"), ".")
+	if whole == "" || len(fraction) > exponent || strings.ContainsAny(whole+fraction, "+-") {
+		return Money{}, fmt.Errorf("%w: %q is not an amount in %s", ers.ErrInvalidInput, s, currency)
+	}
+	digits := whole + fraction + strings.Repeat("0", exponent-len(fraction))
+
+	amount, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q is not an amount in %s", ers.ErrInvalidInput, s, currency)
+	}
+	if negative {
+		amount = -amount
+	}
+
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// Decimal formats the amount in major units, e.g. "1500.50".
+func (m Money) Decimal() string {
+	exponent, ok := CurrencyExponent(m.Currency)
+	if !ok {
+		exponent = 2
+	}
+
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign = "-"
+	}
+	digits := strconv.FormatUint(absUint(amount), 10)
+	if exponent == 0 {
+		return sign + digits
+	}
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+
+	return sign + digits[:len(digits)-exponent] + "." + digits[len(digits)-exponent:]
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	sum := m.Amount + other.Amount
+	if (sum > m.Amount) != (other.Amount > 0) {
+		return Money{}, fmt.Errorf("%w: amount overflows", ers.ErrInvalidInput)
+	}
+	return Money{Amount: sum, Currency: m.Currency}, nil
+}
+
+func (m Money) Sub(other Money) (Money, error) {
+	if other.Amount == math.MinInt64 {
+		return Money{}, fmt.Errorf("%w: amount overflows", ers.ErrInvalidInput)
+	}
+	return m.Add(Money{Amount: -other.Amount, Currency: other.Currency})
+}
+
+// Cmp returns -1, 0 or 1 as m is less than, equal to or greater than other.
+func (m Money) Cmp(other Money) (int, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.Amount < other.Amount:
+		return -1, nil
+	case m.Amount > other.Amount:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Convert prices m in currency at rate, the cost of one unit of m's currency,
+// rounding half away from zero to the minor units of currency.
+func (m Money) Convert(currency string, rate *big.Rat) (Money, error) {
+	from, ok := CurrencyExponent(m.Currency)
+	if !ok {
+		return Money{}, fmt.Errorf("%w: unsupported currency %q", ers.ErrInvalidInput, m.Currency)
+	}
+	to, ok := CurrencyExponent(currency)
+	if !ok {
+		return Money{}, fmt.Errorf("%w: unsupported currency %q", ers.ErrInvalidInput, currency)
+	}
+
+	value := new(big.Rat).Mul(new(big.Rat).SetInt64(m.Amount), rate)
+	scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(to-from))), nil))
+	if to > from {
+		value.Mul(value, scale)
+	} else {
+		value.Quo(value, scale)
+	}
+
+	num := new(big.Int).Abs(value.Num())
+	quotient, remainder := new(big.Int).QuoRem(num, value.Denom(), new(big.Int))
+	if remainder.Mul(remainder, big.NewInt(2)).Cmp(value.Denom()) >= 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+	if value.Sign() < 0 {
+		quotient.Neg(quotient)
+	}
+	if !quotient.IsInt64() {
+		return Money{}, fmt.Errorf("%w: amount overflows", ers.ErrInvalidInput)
+	}
+
+	return Money{Amount: quotient.Int64(), Currency: currency}, nil
+}
+
+func (m Money) sameCurrency(other Money) error {
+	if m.Currency != other.Currency {
+		return fmt.Errorf("%w: %s and %s", ers.ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	return nil
+}
+
+type moneyJSON struct {
+	AmountMinor *int64  `json:"amount_minor"`
+	Amount      *string `json:"amount,omitempty"`
+	Currency    string  `json:"currency"`
+}
+
+// MarshalJSON renders both the minor units and the formatted decimal:
+// {"amount_minor": 150050, "amount": "1500.50", "currency": "RUB"}.
+func (m Money) MarshalJSON() ([]byte, error) {
+	decimal := m.Decimal()
+	return json.Marshal(moneyJSON{AmountMinor: &m.Amount, Amount: &decimal, Currency: m.Currency})
+}
+
+// UnmarshalJSON accepts either amount_minor or a decimal amount, or both when
+// they agree. An omitted currency leaves Currency empty for the caller to default.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var raw moneyJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("%w: a price is an object with amount_minor or amount and currency", ers.ErrInvalidInput)
+	}
+
+	currency := strings.ToUpper(strings.TrimSpace(raw.Currency))
+	switch {
+	case raw.Amount != nil:
+		parseCurrency := currency
+		if parseCurrency == "" {
+			parseCurrency = DefaultCurrency
+		}
+		parsed, err := ParseMoney(*raw.Amount, parseCurrency)
+		if err != nil {
+			return err
+		}
+		if raw.AmountMinor != nil && *raw.AmountMinor != parsed.Amount {
+			return fmt.Errorf("%w: amount and amount_minor disagree", ers.ErrInvalidInput)
+		}
+		*m = Money{Amount: parsed.Amount, Currency: currency}
+	case raw.AmountMinor != nil:
+		*m = Money{Amount: *raw.AmountMinor, Currency: currency}
+	default:
+		return fmt.Errorf("%w: a price needs amount_minor or amount", ers.ErrInvalidInput)
+	}
+
+	return nil
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func absUint(n int64) uint64 {
+	if n < 0 {
+		return uint64(-(n + 1)) + 1
+	}
+	return uint64(n)
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+)
+
+func TestParseMoney_MinorUnits(t *testing.T) {
+	m, err := ParseMoney("1500.5", "RUB")
+	if err != nil {
+		t.Fatalf("parsing failed: %s", err)
+	}
+	if m.Amount != 150050 || m.Decimal() != "1500.50" {
+		t.Errorf("expected 150050 kopecks formatted as 1500.50, but got %d and %s", m.Amount, m.Decimal())
+	}
+
+	if _, err := ParseMoney("1.005", "RUB"); !errors.Is(err, ers.ErrInvalidInput) {
+		t.Errorf("expected error to be %v for a third decimal, but got %v", ers.ErrInvalidInput, err)
+	}
+	if m, _ := ParseMoney("1500", "JPY"); m.Amount != 1500 || m.Decimal() != "1500" {
+		t.Errorf("expected 1500 yen without decimals, but got %d and %s", m.Amount, m.Decimal())
+	}
+}
+
+func TestMoney_Decimal(t *testing.T) {
+	if got := NewMoney(-5, "RUB").Decimal(); got != "-0.05" {
+		t.Errorf("expected -0.05, but got %s", got)
+	}
+	if got := NewMoney(0, "KZT").String(); got != "0.00 KZT" {
+		t.Errorf("expected 0.00 KZT, but got %s", got)
+	}
+}
+
+func TestMoney_MixedCurrency(t *testing.T) {
+	_, err := NewMoney(100, "RUB").Add(NewMoney(100, "KZT"))
+	if !errors.Is(err, ers.ErrCurrencyMismatch) {
+		t.Errorf("expected error to be %v, but got %v", ers.ErrCurrencyMismatch, err)
+	}
+
+	sum, err := NewMoney(150, "BYN").Add(NewMoney(250, "BYN"))
+	if err != nil || sum.Amount != 400 {
+		t.Errorf("expected 400, but got %d (%v)", sum.Amount, err)
+	}
+}
+
+func TestMoney_Convert(t *testing.T) {
+	// 1000.00 RUB at 5.125 KZT per rouble is 5125.00 KZT.
+	got, err := NewMoney(100000, "RUB").Convert("KZT", big.NewRat(5125, 1000))
+	if err != nil || got != NewMoney(512500, "KZT") {
+		t.Errorf("expected 5125.00 KZT, but got %s (%v)", got, err)
+	}
+
+	// 1.00 RUB at 0.005 is half a cent, rounded away from zero.
+	if got, _ := NewMoney(100, "RUB").Convert("USD", big.NewRat(1, 200)); got.Amount != 1 {
+		t.Errorf("expected 0.005 USD to round up to 0.01, but got %s", got)
+	}
+	if got, _ := NewMoney(-100, "RUB").Convert("USD", big.NewRat(1, 200)); got.Amount != -1 {
+		t.Errorf("expected -0.005 USD to round to -0.01, but got %s", got)
+	}
+
+	if got, _ := NewMoney(1000, "USD").Convert("JPY", big.NewRat(150, 1)); got != NewMoney(1500, "JPY") {
+		t.Errorf("expected 10.00 USD to be 1500 JPY, but got %s", got)
+	}
+}
+
+func TestMoney_JSON(t *testing.T) {
+	data, err := json.Marshal(NewMoney(199990, "RUB"))
+	if err != nil {
+		t.Fatalf("encoding failed: %s", err)
+	}
+	if string(data) != `{"amount_minor":199990,"amount":"1999.90","currency":"RUB"}` {
+		t.Errorf("unexpected encoding %s", data)
+	}
+
+	var m Money
+	if err := json.Unmarshal([]byte(`{"amount":"10.5","currency":"kzt"}`), &m); err != nil {
+		t.Fatalf("decoding failed: %s", err)
+	}
+	if m.Amount != 1050 || m.Currency != "KZT" {
+		t.Errorf("expected 1050 KZT, but got %d %s", m.Amount, m.Currency)
+	}
+
+	if err := json.Unmarshal([]byte(`{"amount":"10.5","amount_minor":1000}`), &m); !errors.Is(err, ers.ErrInvalidInput) {
+		t.Errorf("expected error to be %v for disagreeing amounts, but got %v", ers.ErrInvalidInput, err)
+	}
+}
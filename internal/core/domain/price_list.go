@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+)
+
+// PriceList holds explicit prices in one currency for a region. The list with
+// an empty Region is the default for its currency.
+type PriceList struct {
+	ID        uuid.UUID `json:"id"`
+	Currency  string    `json:"currency"`
+	Region    string    `json:"region,omitempty"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type PriceListItem struct {
+	ProductID uuid.UUID `json:"product_id"`
+	Price     Money     `json:"price"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ExchangeRate says that one unit of Base costs Rate units of Quote from
+// EffectiveDate until the next rate for the pair. Rate is a decimal string.
+type ExchangeRate struct {
+	Base          string    `json:"base"`
+	Quote         string    `json:"quote"`
+	EffectiveDate time.Time `json:"effective_date"`
+	Rate          string    `json:"rate"`
+}
+
+// Ratio parses Rate; it fails unless the rate is a positive decimal.
+func (r ExchangeRate) Ratio() (*big.Rat, error) {
+	ratio, ok := new(big.Rat).SetString(r.Rate)
+	if !ok || ratio.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: rate %q is not a positive decimal", ers.ErrInvalidInput, r.Rate)
+	}
+	return ratio, nil
+}
+
+type PriceSource string
+
+const (
+	PriceSourceList      PriceSource = "price_list"
+	PriceSourceBase      PriceSource = "base"
+	PriceSourceConverted PriceSource = "converted"
+)
+
+// ResolvedPrice is the price of a product in a requested currency and where it
+// came from: an explicit price list entry, the product's own price when it is
+// already in that currency, or the own price converted at Rate.
+type ResolvedPrice struct {
+	Price         Money         `json:"price"`
+	Source        PriceSource   `json:"source"`
+	PriceListID   *uuid.UUID    `json:"price_list_id,omitempty"`
+	ConvertedFrom *Money        `json:"converted_from,omitempty"`
+	Rate          *ExchangeRate `json:"rate,omitempty"`
+}
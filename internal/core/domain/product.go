@@ -6,19 +6,47 @@ import (
 	"github.com/google/uuid"
 )
 
+// Product is either a standalone item, a parent with variants, or a variant of
+// a parent. A variant has ParentID and VariantValues set and, when
+// InheritsPrice is true, takes its Price from the parent, currency included.
+// ResolvedPrice is only set on reads that ask for a currency.
 type Product struct {
-	ID          uuid.UUID
-	Name        string
-	Description string
-	Price       int64
-	Quantity    int
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID                uuid.UUID
+	ParentID          *uuid.UUID        `json:",omitempty"`
+	VariantValues     map[string]string `json:",omitempty"`
+	SKU               string            `json:",omitempty"`
+	Barcodes          []Barcode         `json:",omitempty"`
+	Name              string
+	Description       string
+	Price             Money
+	InheritsPrice     bool           `json:",omitempty"`
+	ResolvedPrice     *ResolvedPrice `json:",omitempty"`
+	Quantity          int
+	Reserved          int
+	Available         int
+	Version           int64
+	Stock             []WarehouseStock   `json:",omitempty"`
+	VariantAttributes []VariantAttribute `json:",omitempty"`
+	Variants          []Product          `json:",omitempty"`
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	DeletedAt         *time.Time `json:",omitempty"`
 }
 
+func (p Product) IsVariant() bool {
+	return p.ParentID != nil
+}
+
+// UpdateProductDTO carries the fields of a partial update. InheritPrice set to
+// true drops a variant's own price in favour of the parent's. A Quantity change
+// is applied in WarehouseID, or in the default warehouse when it is nil.
 type UpdateProductDTO struct {
-	Name        *string `json:"name,omitempty"`
-	Description *string `json:"description,omitempty"`
-	Price       *int64  `json:"price,omitempty"`
-	Quantity    *int    `json:"quantity,omitempty"`
+	SKU          *string    `json:"sku,omitempty"`
+	Barcodes     *[]string  `json:"barcodes,omitempty"`
+	Name         *string    `json:"name,omitempty"`
+	Description  *string    `json:"description,omitempty"`
+	Price        *Money     `json:"price,omitempty"`
+	InheritPrice *bool      `json:"inherit_price,omitempty"`
+	Quantity     *int       `json:"quantity,omitempty"`
+	WarehouseID  *uuid.UUID `json:"warehouse_id,omitempty"`
 }
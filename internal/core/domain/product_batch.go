@@ -0,0 +1,32 @@
+package domain
+
+import "github.com/google/uuid"
+
+type BatchOp string
+
+const (
+	BatchCreate BatchOp = "create"
+	BatchUpdate BatchOp = "update"
+	BatchDelete BatchOp = "delete"
+	BatchAdjust BatchOp = "adjust"
+)
+
+// BatchOperation is one entry of a batch request. Which fields are read
+// depends on Op: Product for create, ID/Version/Changes for update, ID/Version
+// for delete and ID/Adjust for adjust.
+type BatchOperation struct {
+	Op      BatchOp
+	ID      uuid.UUID
+	Version int64
+	Product Product
+	Changes UpdateProductDTO
+	Adjust  AdjustStockDTO
+}
+
+// BatchResult carries either the outcome of an operation or the error that stopped it.
+type BatchResult struct {
+	Op      BatchOp
+	Product *Product
+	Balance *StockBalance
+	Err     error
+}
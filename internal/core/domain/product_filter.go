@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProductSortField string
+
+const (
+	SortByName      ProductSortField = "name"
+	SortByPrice     ProductSortField = "price"
+	SortByQuantity  ProductSortField = "quantity"
+	SortByUpdatedAt ProductSortField = "updated_at"
+)
+
+// ProductFilter selects products for the list and export endpoints. CategoryID
+// matches products in the category or in any of its descendants; every
+// attribute filter must match. A price bound also limits the list to products
+// priced in its currency.
+type ProductFilter struct {
+	Limit        int
+	Cursor       string
+	After        *ProductCursor
+	NamePrefix   string
+	MinPrice     *Money
+	MaxPrice     *Money
+	InStock      bool
+	CreatedSince *time.Time
+	UpdatedSince *time.Time
+	CategoryID   *uuid.UUID
+	Attributes   []AttributeFilter
+	SortField    ProductSortField
+	SortDesc     bool
+
+	IncludeDeleted bool
+}
+
+// ProductCursor is the keyset position of the last row on a page: the value of
+// the sort column and the id as a tie-breaker.
+type ProductCursor struct {
+	Sort  string    `json:"s"`
+	Value string    `json:"v"`
+	ID    uuid.UUID `json:"id"`
+}
+
+type ProductPage struct {
+	Items      []Product `json:"items"`
+	NextCursor string    `json:"next_cursor,omitempty"`
+}
+
+type SearchMatch string
+
+const (
+	MatchFullText SearchMatch = "fulltext"
+	MatchTrigram  SearchMatch = "trigram"
+)
+
+type ProductSearchResult struct {
+	Product              Product     `json:"product"`
+	Rank                 float64     `json:"rank"`
+	Match                SearchMatch `json:"match"`
+	NameHighlight        string      `json:"name_highlight"`
+	DescriptionHighlight string      `json:"description_highlight"`
+}
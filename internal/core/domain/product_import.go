@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ImportRow is one parsed spreadsheet row. Line is the 1-based line in the
+// source file; Errors holds problems found while parsing the cells.
+// Description, Price and Quantity are nil when the file has no such column or
+// the cell is empty, so that an update leaves those fields as they are.
+type ImportRow struct {
+	Line        int      `json:"line"`
+	SKU         string   `json:"sku,omitempty"`
+	Name        string   `json:"name"`
+	Description *string  `json:"description,omitempty"`
+	Price       *Money   `json:"price,omitempty"`
+	Quantity    *int     `json:"quantity,omitempty"`
+	Errors      []string `json:"errors,omitempty"`
+}
+
+type ImportAction string
+
+const (
+	ImportCreate ImportAction = "create"
+	ImportUpdate ImportAction = "update"
+)
+
+// ImportRowResult is the outcome of one row. Warnings note rows that were
+// applied but may not do what the file intended.
+type ImportRowResult struct {
+	Line      int          `json:"line"`
+	SKU       string       `json:"sku,omitempty"`
+	Action    ImportAction `json:"action,omitempty"`
+	ProductID *uuid.UUID   `json:"product_id,omitempty"`
+	Errors    []string     `json:"errors,omitempty"`
+	Warnings  []string     `json:"warnings,omitempty"`
+}
+
+type ImportReport struct {
+	DryRun  bool              `json:"dry_run"`
+	Total   int               `json:"total"`
+	Created int               `json:"created"`
+	Updated int               `json:"updated"`
+	Failed  int               `json:"failed"`
+	Rows    []ImportRowResult `json:"rows"`
+}
+
+type ImportJobStatus string
+
+const (
+	ImportQueued  ImportJobStatus = "queued"
+	ImportRunning ImportJobStatus = "running"
+	ImportDone    ImportJobStatus = "done"
+	ImportFailed  ImportJobStatus = "failed"
+)
+
+// ImportJob is an import too large to run inside the request. The parsed rows
+// are stored with the job and processed by the import worker.
+type ImportJob struct {
+	ID         uuid.UUID       `json:"id"`
+	Status     ImportJobStatus `json:"status"`
+	DryRun     bool            `json:"dry_run"`
+	TotalRows  int             `json:"total_rows"`
+	Actor      string          `json:"actor,omitempty"`
+	Report     *ImportReport   `json:"report,omitempty"`
+	Error      string          `json:"error,omitempty"`
+	Rows       []ImportRow     `json:"-"`
+	CreatedAt  time.Time       `json:"created_at"`
+	StartedAt  *time.Time      `json:"started_at,omitempty"`
+	FinishedAt *time.Time      `json:"finished_at,omitempty"`
+}
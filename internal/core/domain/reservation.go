@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReservationStatus string
+
+const (
+	ReservationActive    ReservationStatus = "active"
+	ReservationConfirmed ReservationStatus = "confirmed"
+	ReservationCancelled ReservationStatus = "cancelled"
+	ReservationExpired   ReservationStatus = "expired"
+)
+
+// Reservation holds stock of one warehouse; confirming it sells from that warehouse.
+type Reservation struct {
+	ID          uuid.UUID         `json:"id"`
+	ProductID   uuid.UUID         `json:"product_id"`
+	WarehouseID uuid.UUID         `json:"warehouse_id"`
+	Quantity    int               `json:"quantity"`
+	Status      ReservationStatus `json:"status"`
+	ExpiresAt   time.Time         `json:"expires_at"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// CreateReservationDTO falls back to the default warehouse when WarehouseID is uuid.Nil.
+type CreateReservationDTO struct {
+	ProductID   uuid.UUID
+	WarehouseID uuid.UUID
+	Quantity    int
+	TTL         time.Duration
+}
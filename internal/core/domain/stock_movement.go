@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MovementType string
+
+const (
+	MovementReceipt    MovementType = "receipt"
+	MovementSale       MovementType = "sale"
+	MovementAdjustment MovementType = "adjustment"
+	MovementReturn     MovementType = "return"
+	MovementTransfer   MovementType = "transfer"
+)
+
+// StockMovement is an append-only ledger entry. Quantity is the signed change
+// applied to the product balance, BalanceAfter is the product total once applied
+// and WarehouseBalanceAfter the balance of the warehouse it happened in.
+type StockMovement struct {
+	ID                    uuid.UUID    `json:"id"`
+	ProductID             uuid.UUID    `json:"product_id"`
+	WarehouseID           uuid.UUID    `json:"warehouse_id"`
+	Type                  MovementType `json:"type"`
+	Quantity              int          `json:"quantity"`
+	BalanceAfter          int          `json:"balance_after"`
+	WarehouseBalanceAfter int          `json:"warehouse_balance_after"`
+	ReasonCode            string       `json:"reason_code,omitempty"`
+	Actor                 string       `json:"actor,omitempty"`
+	CorrelationID         string       `json:"correlation_id,omitempty"`
+	CreatedAt             time.Time    `json:"created_at"`
+}
+
+type MovementFilter struct {
+	From        *time.Time
+	To          *time.Time
+	WarehouseID *uuid.UUID
+}
+
+type StockBalance struct {
+	ProductID         uuid.UUID `json:"product_id"`
+	Quantity          int       `json:"quantity"`
+	WarehouseID       uuid.UUID `json:"warehouse_id"`
+	WarehouseQuantity int       `json:"warehouse_quantity"`
+	MovementID        uuid.UUID `json:"movement_id"`
+}
+
+// AdjustStockDTO and RecordMovementDTO fall back to the default warehouse when WarehouseID is uuid.Nil.
+type AdjustStockDTO struct {
+	ProductID     uuid.UUID
+	WarehouseID   uuid.UUID
+	Delta         int
+	ReasonCode    string
+	CorrelationID string
+}
+
+// KeepVersion leaves the product version alone for callers that bump it in the same write.
+// AllowTransfer is set only by the transfer service: a transfer movement without
+// a transfer document behind it would report stock moving that never did.
+type RecordMovementDTO struct {
+	ProductID     uuid.UUID
+	WarehouseID   uuid.UUID
+	Type          MovementType
+	Quantity      int
+	ReasonCode    string
+	CorrelationID string
+	KeepVersion   bool
+	AllowTransfer bool
+}
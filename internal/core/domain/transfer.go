@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TransferStatus string
+
+const (
+	TransferDraft     TransferStatus = "draft"
+	TransferShipped   TransferStatus = "shipped"
+	TransferReceived  TransferStatus = "received"
+	TransferCancelled TransferStatus = "cancelled"
+)
+
+type Transfer struct {
+	ID                     uuid.UUID      `json:"id"`
+	SourceWarehouseID      uuid.UUID      `json:"source_warehouse_id"`
+	DestinationWarehouseID uuid.UUID      `json:"destination_warehouse_id"`
+	Status                 TransferStatus `json:"status"`
+	Note                   string         `json:"note,omitempty"`
+	Lines                  []TransferLine `json:"lines"`
+	CreatedAt              time.Time      `json:"created_at"`
+	UpdatedAt              time.Time      `json:"updated_at"`
+	ShippedAt              *time.Time     `json:"shipped_at,omitempty"`
+	ReceivedAt             *time.Time     `json:"received_at,omitempty"`
+}
+
+type TransferLine struct {
+	ProductID uuid.UUID `json:"product_id"`
+	Quantity  int       `json:"quantity"`
+}
+
+type TransferFilter struct {
+	Status *TransferStatus
+}
+
+// InTransitStock is quantity that left the source warehouse but has not been received yet.
+type InTransitStock struct {
+	TransferID             uuid.UUID `json:"transfer_id"`
+	ProductID              uuid.UUID `json:"product_id"`
+	SourceWarehouseID      uuid.UUID `json:"source_warehouse_id"`
+	DestinationWarehouseID uuid.UUID `json:"destination_warehouse_id"`
+	Quantity               int       `json:"quantity"`
+}
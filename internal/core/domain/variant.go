@@ -0,0 +1,8 @@
+package domain
+
+// VariantAttribute is one axis of a product's variant matrix, e.g. size with
+// the sizes the product comes in.
+type VariantAttribute struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
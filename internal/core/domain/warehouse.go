@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Warehouse struct {
+	ID        uuid.UUID `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Address   string    `json:"address"`
+	IsDefault bool      `json:"is_default"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type UpdateWarehouseDTO struct {
+	Name    *string `json:"name,omitempty"`
+	Address *string `json:"address,omitempty"`
+}
+
+// WarehouseStock is the on-hand quantity of one product in one warehouse.
+type WarehouseStock struct {
+	WarehouseID   uuid.UUID `json:"warehouse_id"`
+	WarehouseCode string    `json:"warehouse_code"`
+	ProductID     uuid.UUID `json:"product_id"`
+	Quantity      int       `json:"quantity"`
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Webhook is a partner endpoint subscribed to outbox events. Empty Events or
+// ProductIDs mean "all". The secret is only shown when the webhook is created.
+type Webhook struct {
+	ID         uuid.UUID   `json:"id"`
+	URL        string      `json:"url"`
+	Events     []EventType `json:"events"`
+	ProductIDs []uuid.UUID `json:"product_ids"`
+	Secret     string      `json:"secret,omitempty"`
+	Active     bool        `json:"active"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+// Matches reports whether the event should be delivered to the webhook.
+func (w Webhook) Matches(event Event) bool {
+	if !w.Active {
+		return false
+	}
+
+	if len(w.Events) > 0 {
+		found := false
+		for _, t := range w.Events {
+			if t == event.Type {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(w.ProductIDs) > 0 {
+		if event.AggregateType != AggregateProduct {
+			return false
+		}
+		for _, id := range w.ProductIDs {
+			if id == event.AggregateID {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+type CreateWebhookDTO struct {
+	URL        string
+	Events     []EventType
+	ProductIDs []uuid.UUID
+	Secret     string
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	DeliveryDead      DeliveryStatus = "dead"
+)
+
+// WebhookDelivery is one event queued for one webhook. It is retried with
+// exponential backoff and ends up dead after the configured number of attempts.
+type WebhookDelivery struct {
+	ID             uuid.UUID       `json:"id"`
+	WebhookID      uuid.UUID       `json:"webhook_id"`
+	EventID        uuid.UUID       `json:"event_id"`
+	EventType      EventType       `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         DeliveryStatus  `json:"status"`
+	Attempts       int             `json:"attempts"`
+	ResponseStatus int             `json:"response_status,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+type DeliveryFilter struct {
+	Status DeliveryStatus
+	Limit  int
+}
@@ -15,6 +15,54 @@ var (
 	ErrInvalidInput        = errors.New("invalid input data")
 	ErrMethodNotAllowed    = errors.New("method not allowed")
 	ErrInternalServerError = errors.New("internal server error")
+	ErrForbidden           = errors.New("forbidden")
+	ErrProductNotDeleted   = errors.New("product is not deleted")
+	ErrSKUExists           = errors.New("sku already exists")
+	ErrBarcodeExists       = errors.New("barcode already exists")
+	ErrVariantExists       = errors.New("variant already exists")
+	ErrProductHasVariants  = errors.New("product has variants")
+	ErrProductInTransit    = errors.New("product has stock in transit")
+	ErrCurrencyMismatch    = errors.New("currency mismatch")
+
+	ErrReservationNotFound  = errors.New("reservation not found")
+	ErrReservationNotActive = errors.New("reservation is not active")
+	ErrInsufficientStock    = errors.New("insufficient stock")
+
+	ErrWarehouseNotFound = errors.New("warehouse not found")
+	ErrWarehouseExists   = errors.New("warehouse already exists")
+	ErrWarehouseInUse    = errors.New("warehouse is in use")
+
+	ErrCategoryNotFound = errors.New("category not found")
+	ErrCategoryExists   = errors.New("category already exists")
+	ErrCategoryNotEmpty = errors.New("category is not empty")
+
+	ErrAttributeNotFound = errors.New("attribute not found")
+	ErrAttributeExists   = errors.New("attribute already exists")
+	ErrAttributeInUse    = errors.New("attribute value is in use")
+
+	ErrPriceListNotFound    = errors.New("price list not found")
+	ErrPriceListExists      = errors.New("price list already exists")
+	ErrExchangeRateNotFound = errors.New("exchange rate not found")
+
+	ErrTransferNotFound     = errors.New("transfer not found")
+	ErrInvalidTransferState = errors.New("invalid transfer state")
+
+	ErrWebhookNotFound      = errors.New("webhook not found")
+	ErrDeliveryNotFound     = errors.New("webhook delivery not found")
+	ErrInvalidDeliveryState = errors.New("invalid delivery state")
+
+	ErrImportJobNotFound = errors.New("import job not found")
+
+	ErrFeedNotFound = errors.New("feed not found")
+
+	ErrBatchAborted = errors.New("batch aborted")
+
+	ErrIdempotencyKeyReused     = errors.New("idempotency key reused with a different request")
+	ErrIdempotencyKeyInProgress = errors.New("request with this idempotency key is in progress")
+	ErrPayloadTooLarge          = errors.New("payload too large")
+
+	ErrPreconditionFailed = errors.New("precondition failed")
+	ErrVersionConflict    = errors.New("version conflict")
 )
 
 type ValidationError struct {
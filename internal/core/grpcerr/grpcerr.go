@@ -0,0 +1,94 @@
+package grpcerr
+
+import (
+	"context"
+	"errors"
+
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var codeByError = []struct {
+	err  error
+	code codes.Code
+}{
+	{ers.ErrInvalidInput, codes.InvalidArgument},
+	{ers.ErrProductNotFound, codes.NotFound},
+	{ers.ErrProductNotDeleted, codes.FailedPrecondition},
+	{ers.ErrSKUExists, codes.AlreadyExists},
+	{ers.ErrBarcodeExists, codes.AlreadyExists},
+	{ers.ErrVariantExists, codes.AlreadyExists},
+	{ers.ErrProductHasVariants, codes.FailedPrecondition},
+	{ers.ErrProductInTransit, codes.FailedPrecondition},
+	{ers.ErrCurrencyMismatch, codes.InvalidArgument},
+	{ers.ErrForbidden, codes.PermissionDenied},
+	{ers.ErrReservationNotFound, codes.NotFound},
+	{ers.ErrReservationNotActive, codes.FailedPrecondition},
+	{ers.ErrInsufficientStock, codes.FailedPrecondition},
+	{ers.ErrWarehouseNotFound, codes.NotFound},
+	{ers.ErrWarehouseExists, codes.AlreadyExists},
+	{ers.ErrWarehouseInUse, codes.FailedPrecondition},
+	{ers.ErrCategoryNotFound, codes.NotFound},
+	{ers.ErrCategoryExists, codes.AlreadyExists},
+	{ers.ErrCategoryNotEmpty, codes.FailedPrecondition},
+	{ers.ErrAttributeNotFound, codes.NotFound},
+	{ers.ErrAttributeExists, codes.AlreadyExists},
+	{ers.ErrAttributeInUse, codes.FailedPrecondition},
+	{ers.ErrPriceListNotFound, codes.NotFound},
+	{ers.ErrPriceListExists, codes.AlreadyExists},
+	{ers.ErrExchangeRateNotFound, codes.FailedPrecondition},
+	{ers.ErrTransferNotFound, codes.NotFound},
+	{ers.ErrInvalidTransferState, codes.FailedPrecondition},
+	{ers.ErrWebhookNotFound, codes.NotFound},
+	{ers.ErrDeliveryNotFound, codes.NotFound},
+	{ers.ErrInvalidDeliveryState, codes.FailedPrecondition},
+	{ers.ErrImportJobNotFound, codes.NotFound},
+	{ers.ErrFeedNotFound, codes.NotFound},
+	{ers.ErrBatchAborted, codes.Aborted},
+	{ers.ErrIdempotencyKeyReused, codes.FailedPrecondition},
+	{ers.ErrIdempotencyKeyInProgress, codes.Aborted},
+	{ers.ErrPayloadTooLarge, codes.ResourceExhausted},
+	{ers.ErrPreconditionFailed, codes.FailedPrecondition},
+	{ers.ErrVersionConflict, codes.Aborted},
+	{ers.ErrMethodNotAllowed, codes.Unimplemented},
+	{context.Canceled, codes.Canceled},
+	{context.DeadlineExceeded, codes.DeadlineExceeded},
+}
+
+// Code is the gRPC counterpart of response.StatusCode.
+func Code(err error) codes.Code {
+	var valErr *ers.ValidationError
+	if errors.As(err, &valErr) {
+		return codes.InvalidArgument
+	}
+
+	for _, m := range codeByError {
+		if errors.Is(err, m.err) {
+			return m.code
+		}
+	}
+
+	return codes.Internal
+}
+
+// Status converts a service error into a gRPC status error. Internal errors are
+// logged and hidden from the caller, like response.Error does for HTTP.
+func Status(lg logger.Logger, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	code := Code(err)
+	if code == codes.Internal {
+		lg.Error("internal error: %v", err)
+		return status.Error(codes.Internal, "internal error")
+	}
+
+	lg.Warn("grpc error: %v", err)
+	return status.Error(code, err.Error())
+}
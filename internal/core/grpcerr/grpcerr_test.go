@@ -0,0 +1,103 @@
+package grpcerr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCode_ServiceErrors(t *testing.T) {
+	tests := []struct {
+		err  error
+		code codes.Code
+	}{
+		{ers.ErrInvalidInput, codes.InvalidArgument},
+		{ers.ErrProductNotFound, codes.NotFound},
+		{ers.ErrProductNotDeleted, codes.FailedPrecondition},
+		{ers.ErrSKUExists, codes.AlreadyExists},
+		{ers.ErrBarcodeExists, codes.AlreadyExists},
+		{ers.ErrVariantExists, codes.AlreadyExists},
+		{ers.ErrProductHasVariants, codes.FailedPrecondition},
+		{ers.ErrProductInTransit, codes.FailedPrecondition},
+		{ers.ErrCurrencyMismatch, codes.InvalidArgument},
+		{ers.ErrForbidden, codes.PermissionDenied},
+		{ers.ErrReservationNotFound, codes.NotFound},
+		{ers.ErrReservationNotActive, codes.FailedPrecondition},
+		{ers.ErrInsufficientStock, codes.FailedPrecondition},
+		{ers.ErrWarehouseNotFound, codes.NotFound},
+		{ers.ErrWarehouseExists, codes.AlreadyExists},
+		{ers.ErrWarehouseInUse, codes.FailedPrecondition},
+		{ers.ErrCategoryNotFound, codes.NotFound},
+		{ers.ErrCategoryExists, codes.AlreadyExists},
+		{ers.ErrCategoryNotEmpty, codes.FailedPrecondition},
+		{ers.ErrAttributeNotFound, codes.NotFound},
+		{ers.ErrAttributeExists, codes.AlreadyExists},
+		{ers.ErrAttributeInUse, codes.FailedPrecondition},
+		{ers.ErrPriceListNotFound, codes.NotFound},
+		{ers.ErrPriceListExists, codes.AlreadyExists},
+		{ers.ErrExchangeRateNotFound, codes.FailedPrecondition},
+		{ers.ErrTransferNotFound, codes.NotFound},
+		{ers.ErrInvalidTransferState, codes.FailedPrecondition},
+		{ers.ErrWebhookNotFound, codes.NotFound},
+		{ers.ErrDeliveryNotFound, codes.NotFound},
+		{ers.ErrInvalidDeliveryState, codes.FailedPrecondition},
+		{ers.ErrImportJobNotFound, codes.NotFound},
+		{ers.ErrFeedNotFound, codes.NotFound},
+		{ers.ErrBatchAborted, codes.Aborted},
+		{ers.ErrIdempotencyKeyReused, codes.FailedPrecondition},
+		{ers.ErrIdempotencyKeyInProgress, codes.Aborted},
+		{ers.ErrPayloadTooLarge, codes.ResourceExhausted},
+		{ers.ErrPreconditionFailed, codes.FailedPrecondition},
+		{ers.ErrVersionConflict, codes.Aborted},
+		{ers.ErrMethodNotAllowed, codes.Unimplemented},
+		{ers.ErrInternalServerError, codes.Internal},
+		{&ers.ValidationError{Field: "price", Value: "-1"}, codes.InvalidArgument},
+		{context.Canceled, codes.Canceled},
+		{context.DeadlineExceeded, codes.DeadlineExceeded},
+		{errors.New("connection refused"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		if got := Code(tt.err); got != tt.code {
+			t.Errorf("expected %v to map to %s, but got %s", tt.err, tt.code, got)
+		}
+
+		wrapped := fmt.Errorf("loading product: %w", fmt.Errorf("%w: details", tt.err))
+		if got := Code(wrapped); got != tt.code {
+			t.Errorf("expected wrapped %v to map to %s, but got %s", tt.err, tt.code, got)
+		}
+	}
+}
+
+func TestStatus_HidesInternalErrors(t *testing.T) {
+	lg := logger.New(io.Discard)
+
+	err := Status(lg, fmt.Errorf("error updating balance: %w", errors.New("pq: deadlock detected")))
+	st, _ := status.FromError(err)
+	if st.Code() != codes.Internal || st.Message() != "internal error" {
+		t.Errorf("expected a bare internal error, but got %s: %s", st.Code(), st.Message())
+	}
+
+	err = Status(lg, fmt.Errorf("%w: product not found", ers.ErrProductNotFound))
+	st, _ = status.FromError(err)
+	if st.Code() != codes.NotFound || st.Message() != "product not found: product not found" {
+		t.Errorf("expected the service message with NotFound, but got %s: %s", st.Code(), st.Message())
+	}
+}
+
+func TestStatus_KeepsStatusErrors(t *testing.T) {
+	original := status.Error(codes.Unauthenticated, "missing token")
+	if err := Status(logger.New(io.Discard), original); err != original {
+		t.Errorf("expected a status error to pass through, but got %v", err)
+	}
+	if err := Status(logger.New(io.Discard), nil); err != nil {
+		t.Errorf("expected nil for nil, but got %v", err)
+	}
+}
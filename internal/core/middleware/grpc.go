@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"context"
+	"runtime/debug"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/auth"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/requestid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryInterceptor gives gRPC calls the same recovery, request id, logging and
+// identity handling as the HTTP middleware chain. Metadata keys are the
+// lower-cased HTTP header names.
+func (m *Middleware) UnaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (resp interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			m.logger.Error("panic recovered: %v\n%s", r, debug.Stack())
+			err = status.Error(codes.Internal, "internal error")
+		}
+	}()
+
+	start := time.Now()
+	resp, err = handler(m.grpcContext(ctx), req)
+	m.logger.Info("Method: %s, Code: %s, Time: %s", info.FullMethod, status.Code(err), time.Since(start))
+
+	return resp, err
+}
+
+func (m *Middleware) StreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			m.logger.Error("panic recovered: %v\n%s", r, debug.Stack())
+			err = status.Error(codes.Internal, "internal error")
+		}
+	}()
+
+	start := time.Now()
+	err = handler(srv, &contextStream{ServerStream: ss, ctx: m.grpcContext(ss.Context())})
+	m.logger.Info("Method: %s, Code: %s, Time: %s", info.FullMethod, status.Code(err), time.Since(start))
+
+	return err
+}
+
+func (m *Middleware) grpcContext(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	get := func(key string) string {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+
+	id := get(requestid.Header)
+	if id == "" || len(id) > 128 {
+		id = uuid.NewString()
+	}
+
+	ctx = requestid.WithID(ctx, id)
+	return auth.WithActor(ctx, auth.Actor{
+		ID:   get(auth.HeaderActorID),
+		Role: get(auth.HeaderActorRole),
+	})
+}
+
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
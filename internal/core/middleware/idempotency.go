@@ -0,0 +1,260 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/auth"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/logger"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/response"
+)
+
+const (
+	HeaderIdempotencyKey = "Idempotency-Key"
+	HeaderReplayed       = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+
+	// a request still marked as running after this long is assumed to have died with its server
+	idempotencyLockTimeout = time.Minute
+)
+
+// replayedHeaders are the response headers stored and sent again on replay.
+var replayedHeaders = []string{"Content-Type", "Location", "ETag"}
+
+type IdempotencyStore interface {
+	Reserve(ctx context.Context, record *domain.IdempotencyRecord, staleBefore time.Time) (bool, error)
+	Get(ctx context.Context, actor, key string) (*domain.IdempotencyRecord, error)
+	Complete(ctx context.Context, record *domain.IdempotencyRecord) error
+	Release(ctx context.Context, record *domain.IdempotencyRecord) error
+	Purge(ctx context.Context, now time.Time) (int64, error)
+}
+
+// Idempotency makes POST, PUT, PATCH and DELETE requests carrying an
+// Idempotency-Key safe to retry: the first response is stored for ttl and
+// replayed for the same key and request, while reusing the key for a different
+// request is rejected. Keys are scoped to the caller, so it must run after Identity.
+// Server errors are not stored, so such requests can be retried for real.
+// Bodies over maxBodySize are rejected with 413 before reaching the handler, and
+// responses over it are passed through without being stored.
+func (m *Middleware) Idempotency(store IdempotencyStore, ttl time.Duration, maxBodySize int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(HeaderIdempotencyKey)
+			if key == "" || !mutating(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				response.Error(w, m.logger, fmt.Errorf(
+					"%w: %s is longer than %d characters",
+					ers.ErrInvalidInput, HeaderIdempotencyKey, maxIdempotencyKeyLength,
+				))
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+			if err != nil {
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					response.Error(w, m.logger, fmt.Errorf(
+						"%w: requests with an %s are limited to %d bytes",
+						ers.ErrPayloadTooLarge, HeaderIdempotencyKey, maxBodySize,
+					))
+					return
+				}
+				response.Error(w, m.logger, fmt.Errorf("%w: invalid body", ers.ErrInvalidInput))
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			now := time.Now()
+			record := &domain.IdempotencyRecord{
+				Actor:       auth.ActorFrom(r.Context()).ID,
+				Key:         key,
+				Token:       uuid.New(),
+				RequestHash: requestHash(r, body),
+				CreatedAt:   now,
+				ExpiresAt:   now.Add(ttl),
+			}
+
+			reserved, err := store.Reserve(r.Context(), record, now.Add(-idempotencyLockTimeout))
+			if err != nil {
+				response.Error(w, m.logger, err)
+				return
+			}
+			if !reserved {
+				m.replay(w, r, store, record)
+				return
+			}
+
+			rec := newResponseCapture(w, maxBodySize)
+			completed := false
+			defer func() {
+				// the client may be gone, but the outcome must still be recorded
+				ctx := context.WithoutCancel(r.Context())
+
+				// a panicking handler ends up as a 500 from Recovery; a response
+				// too large to keep cannot be replayed, so a retry runs again
+				if !completed || rec.status >= http.StatusInternalServerError || rec.overflow {
+					if rec.overflow {
+						m.logger.Warn("response to idempotency key %q exceeds %d bytes and is not stored", record.Key, maxBodySize)
+					}
+					if err := store.Release(ctx, record); err != nil {
+						m.logger.Error("failed to release idempotency key: %v", err)
+					}
+					return
+				}
+
+				record.Status = rec.status
+				if record.Status == 0 {
+					record.Status = http.StatusOK
+				}
+				record.Header = rec.stored
+				record.Body = rec.body.Bytes()
+				if err := store.Complete(ctx, record); err != nil {
+					m.logger.Error("failed to store idempotent response: %v", err)
+				}
+			}()
+
+			next.ServeHTTP(rec, r)
+			completed = true
+		})
+	}
+}
+
+func (m *Middleware) replay(w http.ResponseWriter, r *http.Request, store IdempotencyStore, record *domain.IdempotencyRecord) {
+	stored, err := store.Get(r.Context(), record.Actor, record.Key)
+	if err != nil {
+		response.Error(w, m.logger, err)
+		return
+	}
+	if stored == nil || stored.Status == 0 {
+		response.Error(w, m.logger, fmt.Errorf("%w: retry later", ers.ErrIdempotencyKeyInProgress))
+		return
+	}
+	if stored.RequestHash != record.RequestHash {
+		response.Error(w, m.logger, fmt.Errorf("%w: key %q", ers.ErrIdempotencyKeyReused, record.Key))
+		return
+	}
+
+	for name, values := range stored.Header {
+		w.Header()[name] = values
+	}
+	w.Header().Set(HeaderReplayed, "true")
+	w.WriteHeader(stored.Status)
+	if _, err := w.Write(stored.Body); err != nil {
+		m.logger.Error("failed to write replayed response: %v", err)
+	}
+}
+
+func mutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// requestHash covers the method, the target and the conditional headers as
+// well as the body, so a key cannot be replayed against another product or version.
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s %s\n%s\n%s\n", r.Method, r.URL.RequestURI(), r.Header.Get("If-Match"), r.Header.Get("Content-Type"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseCapture passes the response through while keeping a copy of up to
+// limit bytes for the store; overflow marks a response that did not fit.
+type responseCapture struct {
+	http.ResponseWriter
+	status   int
+	stored   http.Header
+	body     bytes.Buffer
+	limit    int64
+	overflow bool
+}
+
+func newResponseCapture(w http.ResponseWriter, limit int64) *responseCapture {
+	return &responseCapture{ResponseWriter: w, limit: limit}
+}
+
+func (c *responseCapture) WriteHeader(status int) {
+	if c.status != 0 {
+		return
+	}
+	c.status = status
+	c.stored = http.Header{}
+	for _, name := range replayedHeaders {
+		if values := c.Header().Values(name); len(values) > 0 {
+			c.stored[name] = values
+		}
+	}
+	c.ResponseWriter.WriteHeader(status)
+}
+
+func (c *responseCapture) Write(b []byte) (int, error) {
+	if c.status == 0 {
+		c.WriteHeader(http.StatusOK)
+	}
+	if !c.overflow {
+		if int64(c.body.Len()+len(b)) > c.limit {
+			c.overflow = true
+			c.body = bytes.Buffer{}
+		} else {
+			c.body.Write(b)
+		}
+	}
+	return c.ResponseWriter.Write(b)
+}
+
+func (c *responseCapture) Unwrap() http.ResponseWriter {
+	return c.ResponseWriter
+}
+
+type IdempotencyPurger struct {
+	store    IdempotencyStore
+	interval time.Duration
+	logger   logger.Logger
+}
+
+func NewIdempotencyPurger(store IdempotencyStore, interval time.Duration, logger logger.Logger) *IdempotencyPurger {
+	return &IdempotencyPurger{
+		store:    store,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+// Run deletes expired idempotency keys every interval until ctx is cancelled.
+func (p *IdempotencyPurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := p.store.Purge(ctx, time.Now())
+			if err != nil {
+				p.logger.Error("failed to purge idempotency keys: %v", err)
+				continue
+			}
+			if purged > 0 {
+				p.logger.Info("purged %d expired idempotency keys", purged)
+			}
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/logger"
+)
+
+type memoryIdempotencyStore struct {
+	records map[string]*domain.IdempotencyRecord
+}
+
+func (m *memoryIdempotencyStore) Reserve(ctx context.Context, record *domain.IdempotencyRecord, staleBefore time.Time) (bool, error) {
+	existing, ok := m.records[record.Actor+"/"+record.Key]
+	if ok && existing.ExpiresAt.After(record.CreatedAt) && (existing.Status != 0 || !existing.CreatedAt.Before(staleBefore)) {
+		return false, nil
+	}
+	stored := *record
+	m.records[record.Actor+"/"+record.Key] = &stored
+	return true, nil
+}
+
+func (m *memoryIdempotencyStore) Get(ctx context.Context, actor, key string) (*domain.IdempotencyRecord, error) {
+	return m.records[actor+"/"+key], nil
+}
+
+func (m *memoryIdempotencyStore) Complete(ctx context.Context, record *domain.IdempotencyRecord) error {
+	if existing, ok := m.records[record.Actor+"/"+record.Key]; !ok || existing.Token != record.Token {
+		return nil
+	}
+	stored := *record
+	m.records[record.Actor+"/"+record.Key] = &stored
+	return nil
+}
+
+func (m *memoryIdempotencyStore) Release(ctx context.Context, record *domain.IdempotencyRecord) error {
+	if existing, ok := m.records[record.Actor+"/"+record.Key]; ok && existing.Token == record.Token {
+		delete(m.records, record.Actor+"/"+record.Key)
+	}
+	return nil
+}
+
+func (m *memoryIdempotencyStore) Purge(ctx context.Context, now time.Time) (int64, error) {
+	return 0, nil
+}
+
+func TestIdempotency_ReplaysAndRejectsReuse(t *testing.T) {
+	calls := 0
+	handler := New(logger.New(io.Discard)).Idempotency(
+		&memoryIdempotencyStore{records: map[string]*domain.IdempotencyRecord{}},
+		time.Hour,
+		1<<20,
+	)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Location", "/product/1")
+		w.WriteHeader(http.StatusCreated)
+		io.Copy(w, r.Body)
+	}))
+
+	send := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/products", strings.NewReader(body))
+		req.Header.Set(HeaderIdempotencyKey, "order-42")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	first := send(`{"name":"Чайник"}`)
+	second := send(`{"name":"Чайник"}`)
+	if calls != 1 {
+		t.Fatalf("expected the handler to run once, but it ran %d times", calls)
+	}
+	if second.Code != http.StatusCreated || second.Body.String() != first.Body.String() {
+		t.Errorf("expected replay of 201 %q, but got %d %q", first.Body, second.Code, second.Body)
+	}
+	if second.Header().Get("Location") != "/product/1" || second.Header().Get(HeaderReplayed) != "true" {
+		t.Errorf("expected stored headers on replay, but got %v", second.Header())
+	}
+
+	if third := send(`{"name":"Утюг"}`); third.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected status %d for a reused key, but got %d", http.StatusUnprocessableEntity, third.Code)
+	}
+}
+
+func TestIdempotency_ServerErrorReleasesKey(t *testing.T) {
+	calls := 0
+	handler := New(logger.New(io.Discard)).Idempotency(
+		&memoryIdempotencyStore{records: map[string]*domain.IdempotencyRecord{}},
+		time.Hour,
+		1<<20,
+	)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodDelete, "/product/1", nil)
+		req.Header.Set(HeaderIdempotencyKey, "delete-1")
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	if calls != 2 {
+		t.Errorf("expected the handler to run again after a server error, but it ran %d times", calls)
+	}
+}
+
+func TestIdempotency_LargeBodyRejected(t *testing.T) {
+	calls := 0
+	handler := New(logger.New(io.Discard)).Idempotency(
+		&memoryIdempotencyStore{records: map[string]*domain.IdempotencyRecord{}},
+		time.Hour,
+		16,
+	)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/products/import", strings.NewReader("sku,name,price,quantity\n"))
+	req.Header.Set(HeaderIdempotencyKey, "import-1")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusRequestEntityTooLarge || calls != 0 {
+		t.Errorf("expected status %d without running the handler, but got %d after %d calls", http.StatusRequestEntityTooLarge, rec.Code, calls)
+	}
+}
+
+func TestIdempotency_LargeResponseNotStored(t *testing.T) {
+	calls := 0
+	store := &memoryIdempotencyStore{records: map[string]*domain.IdempotencyRecord{}}
+	handler := New(logger.New(io.Discard)).Idempotency(store, time.Hour, 16)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		io.WriteString(w, strings.Repeat("x", 32))
+	}))
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/products/export", nil)
+		req.Header.Set(HeaderIdempotencyKey, "export-1")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Body.Len() != 32 {
+			t.Fatalf("expected the full response to pass through, but got %d bytes", rec.Body.Len())
+		}
+	}
+
+	if calls != 2 || len(store.records) != 0 {
+		t.Errorf("expected the response not to be stored, but the handler ran %d times and %d records remain", calls, len(store.records))
+	}
+}
+
+func TestIdempotency_TakenOverReservationKept(t *testing.T) {
+	store := &memoryIdempotencyStore{records: map[string]*domain.IdempotencyRecord{}}
+	takeover := &domain.IdempotencyRecord{Key: "order-7", Token: uuid.New(), ExpiresAt: time.Now().Add(time.Hour)}
+
+	handler := New(logger.New(io.Discard)).Idempotency(store, time.Hour, 1<<20)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the request ran past the lock timeout and a retry took the key over
+		store.records["/order-7"] = takeover
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/products", strings.NewReader(`{}`))
+	req.Header.Set(HeaderIdempotencyKey, "order-7")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if got := store.records["/order-7"]; got != takeover || got.Status != 0 {
+		t.Errorf("expected the new reservation to stay untouched, but got %+v", got)
+	}
+}
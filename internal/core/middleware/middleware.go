@@ -5,7 +5,10 @@ import (
 	"runtime/debug"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/auth"
 	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/logger"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/requestid"
 )
 
 type Middleware struct {
@@ -48,3 +51,27 @@ func (m *Middleware) Recovery(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+func (m *Middleware) Identity(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor := auth.Actor{
+			ID:   r.Header.Get(auth.HeaderActorID),
+			Role: r.Header.Get(auth.HeaderActorRole),
+		}
+
+		next.ServeHTTP(w, r.WithContext(auth.WithActor(r.Context(), actor)))
+	})
+}
+
+// RequestID propagates the caller's X-Request-ID or mints one, and echoes it in the response.
+func (m *Middleware) RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestid.Header)
+		if id == "" || len(id) > 128 {
+			id = uuid.NewString()
+		}
+		w.Header().Set(requestid.Header, id)
+
+		next.ServeHTTP(w, r.WithContext(requestid.WithID(r.Context(), id)))
+	})
+}
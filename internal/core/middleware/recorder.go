@@ -26,3 +26,8 @@ func (rw *StatusRecorder) WriteHeader(statusCode int) {
 	rw.statusCode = statusCode
 	rw.ResponseWriter.WriteHeader(statusCode)
 }
+
+// Unwrap lets http.ResponseController reach the underlying writer, e.g. to flush streamed responses.
+func (rw *StatusRecorder) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
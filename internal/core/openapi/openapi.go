@@ -0,0 +1,35 @@
+package openapi
+
+import (
+	_ "embed"
+	"net/http"
+
+	swaggerFiles "github.com/swaggo/files/v2"
+)
+
+//go:embed openapi.json
+var Spec []byte
+
+//go:embed docs.html
+var docsPage []byte
+
+// SpecHandler serves the OpenAPI document.
+func SpecHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(Spec)
+}
+
+// DocsHandler serves Swagger UI from the assets bundled into the binary. It
+// must be mounted on "/docs/".
+func DocsHandler() http.Handler {
+	assets := http.StripPrefix("/docs/", http.FileServer(http.FS(swaggerFiles.FS)))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/docs/" {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write(docsPage)
+			return
+		}
+		assets.ServeHTTP(w, r)
+	})
+}
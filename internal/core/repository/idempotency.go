@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/domain"
+)
+
+type PostgresIdempotencyStore struct {
+	db *sql.DB
+}
+
+func NewPostgresIdempotencyStore(db *sql.DB) *PostgresIdempotencyStore {
+	return &PostgresIdempotencyStore{
+		db: db,
+	}
+}
+
+// Reserve claims the key for a new request. An existing record is taken over
+// only when it has expired or is a request abandoned before staleBefore;
+// otherwise Reserve returns false and leaves it alone.
+func (i *PostgresIdempotencyStore) Reserve(
+	ctx context.Context,
+	record *domain.IdempotencyRecord,
+	staleBefore time.Time,
+) (bool, error) {
+	query := `
+		INSERT INTO idempotency_keys (actor, key, token, request_hash, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (actor, key) DO UPDATE
+		SET token = EXCLUDED.token,
+		    request_hash = EXCLUDED.request_hash,
+		    status = 0,
+		    header = NULL,
+		    body = NULL,
+		    created_at = EXCLUDED.created_at,
+		    expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+		   OR (idempotency_keys.status = 0 AND idempotency_keys.created_at < $7)
+		RETURNING key
+	`
+
+	var key string
+	err := Conn(ctx, i.db).QueryRowContext(
+		ctx,
+		query,
+		record.Actor,
+		record.Key,
+		record.Token,
+		record.RequestHash,
+		record.CreatedAt,
+		record.ExpiresAt,
+		staleBefore,
+	).Scan(&key)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error reserving idempotency key: %w", err)
+	}
+
+	return true, nil
+}
+
+// Get returns the record for the key, or nil if there is none.
+func (i *PostgresIdempotencyStore) Get(ctx context.Context, actor, key string) (*domain.IdempotencyRecord, error) {
+	var (
+		record domain.IdempotencyRecord
+		header []byte
+	)
+
+	query := `
+		SELECT actor, key, token, request_hash, status, header, body, created_at, expires_at
+		FROM idempotency_keys
+		WHERE actor = $1 AND key = $2
+	`
+
+	err := Conn(ctx, i.db).QueryRowContext(ctx, query, actor, key).Scan(
+		&record.Actor,
+		&record.Key,
+		&record.Token,
+		&record.RequestHash,
+		&record.Status,
+		&header,
+		&record.Body,
+		&record.CreatedAt,
+		&record.ExpiresAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting idempotency key: %w", err)
+	}
+
+	if len(header) > 0 {
+		if err := json.Unmarshal(header, &record.Header); err != nil {
+			return nil, fmt.Errorf("error decoding stored header: %w", err)
+		}
+	}
+
+	return &record, nil
+}
+
+// Complete stores the response, provided the reservation is still the caller's.
+func (i *PostgresIdempotencyStore) Complete(ctx context.Context, record *domain.IdempotencyRecord) error {
+	header, err := json.Marshal(record.Header)
+	if err != nil {
+		return fmt.Errorf("error encoding stored header: %w", err)
+	}
+
+	query := `
+		UPDATE idempotency_keys
+		SET status = $4, header = $5, body = $6
+		WHERE actor = $1 AND key = $2 AND token = $3
+	`
+
+	if _, err := Conn(ctx, i.db).ExecContext(
+		ctx,
+		query,
+		record.Actor,
+		record.Key,
+		record.Token,
+		record.Status,
+		header,
+		record.Body,
+	); err != nil {
+		return fmt.Errorf("error storing idempotent response: %w", err)
+	}
+
+	return nil
+}
+
+// Release forgets the key so the client can retry a request that failed on our
+// side. Like Complete, it leaves a reservation taken over by another request alone.
+func (i *PostgresIdempotencyStore) Release(ctx context.Context, record *domain.IdempotencyRecord) error {
+	query := `DELETE FROM idempotency_keys WHERE actor = $1 AND key = $2 AND token = $3`
+
+	if _, err := Conn(ctx, i.db).ExecContext(ctx, query, record.Actor, record.Key, record.Token); err != nil {
+		return fmt.Errorf("error releasing idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+func (i *PostgresIdempotencyStore) Purge(ctx context.Context, now time.Time) (int64, error) {
+	query := `DELETE FROM idempotency_keys WHERE expires_at <= $1`
+
+	result, err := Conn(ctx, i.db).ExecContext(ctx, query, now)
+	if err != nil {
+		return 0, fmt.Errorf("error purging idempotency keys: %w", err)
+	}
+
+	return result.RowsAffected()
+}
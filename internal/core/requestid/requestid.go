@@ -0,0 +1,16 @@
+package requestid
+
+import "context"
+
+const Header = "X-Request-ID"
+
+type requestIDKey struct{}
+
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
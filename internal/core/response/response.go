@@ -0,0 +1,147 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	ers "github.com/jamal23041989/go-marketplace-inventory-service/internal/core/errors"
+	"github.com/jamal23041989/go-marketplace-inventory-service/internal/core/logger"
+)
+
+var statusByError = []struct {
+	err    error
+	status int
+}{
+	{ers.ErrInvalidInput, http.StatusBadRequest},
+	{ers.ErrProductNotFound, http.StatusNotFound},
+	{ers.ErrProductNotDeleted, http.StatusConflict},
+	{ers.ErrSKUExists, http.StatusConflict},
+	{ers.ErrBarcodeExists, http.StatusConflict},
+	{ers.ErrVariantExists, http.StatusConflict},
+	{ers.ErrProductHasVariants, http.StatusConflict},
+	{ers.ErrProductInTransit, http.StatusConflict},
+	{ers.ErrCurrencyMismatch, http.StatusUnprocessableEntity},
+	{ers.ErrForbidden, http.StatusForbidden},
+	{ers.ErrReservationNotFound, http.StatusNotFound},
+	{ers.ErrReservationNotActive, http.StatusConflict},
+	{ers.ErrInsufficientStock, http.StatusConflict},
+	{ers.ErrWarehouseNotFound, http.StatusNotFound},
+	{ers.ErrWarehouseExists, http.StatusConflict},
+	{ers.ErrWarehouseInUse, http.StatusConflict},
+	{ers.ErrCategoryNotFound, http.StatusNotFound},
+	{ers.ErrCategoryExists, http.StatusConflict},
+	{ers.ErrCategoryNotEmpty, http.StatusConflict},
+	{ers.ErrAttributeNotFound, http.StatusNotFound},
+	{ers.ErrAttributeExists, http.StatusConflict},
+	{ers.ErrAttributeInUse, http.StatusConflict},
+	{ers.ErrPriceListNotFound, http.StatusNotFound},
+	{ers.ErrPriceListExists, http.StatusConflict},
+	{ers.ErrExchangeRateNotFound, http.StatusUnprocessableEntity},
+	{ers.ErrTransferNotFound, http.StatusNotFound},
+	{ers.ErrInvalidTransferState, http.StatusConflict},
+	{ers.ErrWebhookNotFound, http.StatusNotFound},
+	{ers.ErrDeliveryNotFound, http.StatusNotFound},
+	{ers.ErrInvalidDeliveryState, http.StatusConflict},
+	{ers.ErrImportJobNotFound, http.StatusNotFound},
+	{ers.ErrFeedNotFound, http.StatusNotFound},
+	{ers.ErrBatchAborted, http.StatusFailedDependency},
+	{ers.ErrIdempotencyKeyReused, http.StatusUnprocessableEntity},
+	{ers.ErrIdempotencyKeyInProgress, http.StatusConflict},
+	{ers.ErrPayloadTooLarge, http.StatusRequestEntityTooLarge},
+	{ers.ErrPreconditionFailed, http.StatusPreconditionFailed},
+	{ers.ErrVersionConflict, http.StatusConflict},
+	{ers.ErrMethodNotAllowed, http.StatusMethodNotAllowed},
+}
+
+func StatusCode(err error) int {
+	var valErr *ers.ValidationError
+	if errors.As(err, &valErr) {
+		return http.StatusBadRequest
+	}
+
+	for _, m := range statusByError {
+		if errors.Is(err, m.err) {
+			return m.status
+		}
+	}
+
+	return http.StatusInternalServerError
+}
+
+func JSON(w http.ResponseWriter, lg logger.Logger, status int, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if payload != nil {
+		if err := json.NewEncoder(w).Encode(payload); err != nil {
+			lg.Error("error encoding response: %v", err)
+		}
+	}
+}
+
+func Error(w http.ResponseWriter, lg logger.Logger, err error) {
+	status := StatusCode(err)
+	if status == http.StatusInternalServerError {
+		lg.Error("internal error: %v", err)
+	} else {
+		lg.Warn("request error: %v", err)
+	}
+
+	http.Error(w, ErrorText(err), status)
+}
+
+// ErrorText is the message sent to clients for err; internal errors are not exposed.
+func ErrorText(err error) string {
+	if StatusCode(err) == http.StatusInternalServerError {
+		return fmt.Errorf("%w: internal error", ers.ErrInternalServerError).Error()
+	}
+	return err.Error()
+}
+
+func PathID(r *http.Request, lg logger.Logger, name string) (uuid.UUID, error) {
+	idStr := r.PathValue(name)
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		lg.Warn("invalid id: %v", idStr)
+		return uuid.Nil, fmt.Errorf("%w: invalid uuid format", ers.ErrInvalidInput)
+	}
+	return id, nil
+}
+
+func CheckMethod(w http.ResponseWriter, r *http.Request, lg logger.Logger, expectedMethod string) bool {
+	if r.Method != expectedMethod {
+		lg.Warn("method not allowed: %v", expectedMethod)
+		http.Error(w, fmt.Errorf("%w: method not allowed error", ers.ErrMethodNotAllowed).Error(), http.StatusMethodNotAllowed)
+		return false
+	}
+	return true
+}
+
+func QueryTime(r *http.Request, name string) (*time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s must be an RFC3339 timestamp", ers.ErrInvalidInput, name)
+	}
+	return &t, nil
+}
+
+func QueryID(r *http.Request, name string) (*uuid.UUID, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s must be a uuid", ers.ErrInvalidInput, name)
+	}
+	return &id, nil
+}
@@ -0,0 +1,200 @@
+// Package xlsx reads and writes the subset of Office Open XML spreadsheets the
+// service exchanges with users: a single sheet of plain text and number cells.
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidFile = errors.New("not a valid xlsx file")
+
+type relationships struct {
+	Items []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type workbook struct {
+	Sheets []struct {
+		RelID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type richText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t richText) String() string {
+	if len(t.Runs) == 0 {
+		return t.Text
+	}
+	var b strings.Builder
+	for _, r := range t.Runs {
+		b.WriteString(r.Text)
+	}
+	return b.String()
+}
+
+type sharedStrings struct {
+	Items []richText `xml:"si"`
+}
+
+type worksheet struct {
+	Rows []struct {
+		Index int `xml:"r,attr"`
+		Cells []struct {
+			Ref    string   `xml:"r,attr"`
+			Type   string   `xml:"t,attr"`
+			Value  string   `xml:"v"`
+			Inline richText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// ReadRows returns the cells of the first worksheet. Row i of the result is
+// spreadsheet row i+1; missing rows and cells come back empty.
+func ReadRows(r io.ReaderAt, size int64) ([][]string, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	sheetPath, err := firstSheet(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var strs sharedStrings
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		if err := decode(f, &strs); err != nil {
+			return nil, err
+		}
+	}
+
+	f, ok := files[sheetPath]
+	if !ok {
+		return nil, fmt.Errorf("%w: missing %s", ErrInvalidFile, sheetPath)
+	}
+	var sheet worksheet
+	if err := decode(f, &sheet); err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	for i, row := range sheet.Rows {
+		index := row.Index
+		if index == 0 {
+			index = len(rows) + 1
+		}
+		for len(rows) < index {
+			rows = append(rows, nil)
+		}
+
+		var cells []string
+		for j, c := range row.Cells {
+			col := j
+			if c.Ref != "" {
+				if col, err = columnIndex(c.Ref); err != nil {
+					return nil, fmt.Errorf("%w: row %d: %v", ErrInvalidFile, i+1, err)
+				}
+			}
+			for len(cells) <= col {
+				cells = append(cells, "")
+			}
+
+			switch c.Type {
+			case "s":
+				n, err := strconv.Atoi(c.Value)
+				if err != nil || n < 0 || n >= len(strs.Items) {
+					return nil, fmt.Errorf("%w: bad shared string index in %s", ErrInvalidFile, c.Ref)
+				}
+				cells[col] = strs.Items[n].String()
+			case "inlineStr":
+				cells[col] = c.Inline.String()
+			default:
+				cells[col] = c.Value
+			}
+		}
+		rows[index-1] = cells
+	}
+
+	return rows, nil
+}
+
+func firstSheet(files map[string]*zip.File) (string, error) {
+	const fallback = "xl/worksheets/sheet1.xml"
+
+	wbFile, ok := files["xl/workbook.xml"]
+	if !ok {
+		return "", fmt.Errorf("%w: missing workbook", ErrInvalidFile)
+	}
+	var wb workbook
+	if err := decode(wbFile, &wb); err != nil {
+		return "", err
+	}
+
+	relsFile, ok := files["xl/_rels/workbook.xml.rels"]
+	if !ok || len(wb.Sheets) == 0 {
+		return fallback, nil
+	}
+	var rels relationships
+	if err := decode(relsFile, &rels); err != nil {
+		return "", err
+	}
+
+	for _, rel := range rels.Items {
+		if rel.ID == wb.Sheets[0].RelID {
+			if strings.HasPrefix(rel.Target, "/") {
+				return strings.TrimPrefix(rel.Target, "/"), nil
+			}
+			return path.Join("xl", rel.Target), nil
+		}
+	}
+	return fallback, nil
+}
+
+func decode(f *zip.File, v interface{}) error {
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	defer rc.Close()
+
+	if err := xml.NewDecoder(rc).Decode(v); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidFile, f.Name, err)
+	}
+	return nil
+}
+
+// columnIndex turns a cell reference such as "AB12" into a 0-based column.
+func columnIndex(ref string) (int, error) {
+	col := 0
+	n := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		col = col*26 + int(r-'A'+1)
+		n++
+	}
+	if n == 0 {
+		return 0, fmt.Errorf("bad cell reference %q", ref)
+	}
+	return col - 1, nil
+}
@@ -0,0 +1,66 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+)
+
+func buildWorkbook(t *testing.T, files map[string]string) *bytes.Reader {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("zip create: %s", err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatalf("zip write: %s", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("zip close: %s", err)
+	}
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestReadRows_SharedAndInlineStrings(t *testing.T) {
+	r := buildWorkbook(t, map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+			xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+			<sheets><sheet name="Products" sheetId="1" r:id="rId7"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+			<Relationship Id="rId7" Target="worksheets/products.xml"/></Relationships>`,
+		"xl/sharedStrings.xml": `<sst><si><t>name</t></si><si><r><t>Кофе </t></r><r><t>зерновой</t></r></si></sst>`,
+		"xl/worksheets/products.xml": `<worksheet><sheetData>
+			<row r="1"><c r="A1" t="s"><v>0</v></c><c r="C1" t="inlineStr"><is><t>price</t></is></c></row>
+			<row r="3"><c r="A3" t="s"><v>1</v></c><c r="C3"><v>1990</v></c></row>
+		</sheetData></worksheet>`,
+	})
+
+	rows, err := ReadRows(r, r.Size())
+	if err != nil {
+		t.Fatalf("read failed: %s", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows, got %d", len(rows))
+	}
+	if rows[0][0] != "name" || rows[0][1] != "" || rows[0][2] != "price" {
+		t.Errorf("unexpected header row: %q", rows[0])
+	}
+	if len(rows[1]) != 0 {
+		t.Errorf("expected the gap row to be empty, got %q", rows[1])
+	}
+	if rows[2][0] != "Кофе зерновой" || rows[2][2] != "1990" {
+		t.Errorf("unexpected data row: %q", rows[2])
+	}
+}
+
+func TestReadRows_NotAZip(t *testing.T) {
+	r := bytes.NewReader([]byte("name,price\n"))
+	if _, err := ReadRows(r, r.Size()); err == nil {
+		t.Fatalf("expected an error for a csv file")
+	}
+}
@@ -0,0 +1,150 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	contentTypesXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`
+
+	rootRelsXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+
+	workbookRelsXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`
+
+	workbookXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+	sheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+	sheetEnd = `</sheetData></worksheet>`
+)
+
+// Writer streams a single-sheet workbook row by row, so the output can go
+// straight to a network connection without holding the sheet in memory.
+// Strings are written inline, which spares the shared string table.
+type Writer struct {
+	zw    *zip.Writer
+	sheet *bufio.Writer
+	row   int
+}
+
+func NewWriter(w io.Writer, sheetName string) (*Writer, error) {
+	zw := zip.NewWriter(w)
+
+	var escaped strings.Builder
+	if err := xml.EscapeText(&escaped, []byte(sheetName)); err != nil {
+		return nil, err
+	}
+
+	parts := []struct{ name, body string }{
+		{"[Content_Types].xml", contentTypesXML},
+		{"_rels/.rels", rootRelsXML},
+		{"xl/workbook.xml", fmt.Sprintf(workbookXML, escaped.String())},
+		{"xl/_rels/workbook.xml.rels", workbookRelsXML},
+	}
+	for _, part := range parts {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, part.body); err != nil {
+			return nil, err
+		}
+	}
+
+	f, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	sheet := bufio.NewWriter(f)
+	if _, err := sheet.WriteString(sheetStart); err != nil {
+		return nil, err
+	}
+
+	return &Writer{zw: zw, sheet: sheet}, nil
+}
+
+// WriteRow appends a row. Integers and floats become number cells, anything
+// else is written as text.
+func (w *Writer) WriteRow(cells ...interface{}) error {
+	w.row++
+	fmt.Fprintf(w.sheet, `<row r="%d">`, w.row)
+
+	for i, cell := range cells {
+		ref := columnName(i) + strconv.Itoa(w.row)
+
+		var number string
+		switch v := cell.(type) {
+		case int:
+			number = strconv.Itoa(v)
+		case int64:
+			number = strconv.FormatInt(v, 10)
+		case float64:
+			number = strconv.FormatFloat(v, 'f', -1, 64)
+		case nil:
+			continue
+		}
+		if number != "" {
+			fmt.Fprintf(w.sheet, `<c r="%s"><v>%s</v></c>`, ref, number)
+			continue
+		}
+
+		fmt.Fprintf(w.sheet, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+		if err := xml.EscapeText(w.sheet, []byte(fmt.Sprint(cell))); err != nil {
+			return err
+		}
+		w.sheet.WriteString(`</t></is></c>`)
+	}
+
+	_, err := w.sheet.WriteString(`</row>`)
+	return err
+}
+
+// Flush pushes buffered rows to the underlying writer.
+func (w *Writer) Flush() error {
+	if err := w.sheet.Flush(); err != nil {
+		return err
+	}
+	return w.zw.Flush()
+}
+
+// Close finishes the sheet and the archive; it does not close the underlying writer.
+func (w *Writer) Close() error {
+	if _, err := w.sheet.WriteString(sheetEnd); err != nil {
+		return err
+	}
+	if err := w.sheet.Flush(); err != nil {
+		return err
+	}
+	return w.zw.Close()
+}
+
+// columnName turns a 0-based column into its letters: 0 is "A", 27 is "AB".
+func columnName(col int) string {
+	name := ""
+	for col++; col > 0; col = (col - 1) / 26 {
+		name = string(rune('A'+(col-1)%26)) + name
+	}
+	return name
+}
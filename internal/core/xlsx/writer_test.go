@@ -0,0 +1,34 @@
+package xlsx
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriter_RoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, "Products")
+	if err != nil {
+		t.Fatalf("new writer failed: %s", err)
+	}
+	if err := w.WriteRow("name", "price"); err != nil {
+		t.Fatalf("write failed: %s", err)
+	}
+	if err := w.WriteRow("Чай <улун> & мёд ", int64(45000)); err != nil {
+		t.Fatalf("write failed: %s", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("close failed: %s", err)
+	}
+
+	rows, err := ReadRows(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("read failed: %s", err)
+	}
+	if len(rows) != 2 || rows[1][0] != "Чай <улун> & мёд " || rows[1][1] != "45000" {
+		t.Errorf("unexpected rows: %q", rows)
+	}
+	if columnName(27) != "AB" {
+		t.Errorf("expected column 27 to be AB, got %s", columnName(27))
+	}
+}
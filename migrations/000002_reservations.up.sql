@@ -0,0 +1,14 @@
+-- Резервы товара на время оплаты заказа
+CREATE TABLE IF NOT EXISTS reservations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    status TEXT NOT NULL DEFAULT 'active', -- active, confirmed, cancelled, expired
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Активные резервы суммируются при каждом чтении товара и просматриваются сборщиком просроченных
+CREATE INDEX IF NOT EXISTS idx_reservations_product_active ON reservations(product_id) WHERE status = 'active';
+CREATE INDEX IF NOT EXISTS idx_reservations_expires_active ON reservations(expires_at) WHERE status = 'active';
@@ -0,0 +1,32 @@
+-- Журнал движения остатков: quantity в products поддерживается только через него
+CREATE TABLE IF NOT EXISTS stock_movements (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    type TEXT NOT NULL, -- receipt, sale, adjustment, return, transfer
+    quantity INTEGER NOT NULL CHECK (quantity <> 0), -- изменение остатка со знаком
+    balance_after INTEGER NOT NULL,
+    reason_code TEXT NOT NULL DEFAULT '',
+    actor TEXT NOT NULL DEFAULT '',
+    correlation_id TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_movements_product_created ON stock_movements(product_id, created_at);
+
+-- Записи журнала не изменяются
+CREATE OR REPLACE FUNCTION stock_movements_forbid_update() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'stock_movements is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_stock_movements_forbid_update ON stock_movements;
+CREATE TRIGGER trg_stock_movements_forbid_update
+    BEFORE UPDATE ON stock_movements
+    FOR EACH ROW EXECUTE FUNCTION stock_movements_forbid_update();
+
+-- Начальные остатки переносим в журнал, чтобы баланс сходился с суммой движений
+INSERT INTO stock_movements (product_id, type, quantity, balance_after, reason_code)
+SELECT id, 'adjustment', quantity, quantity, 'opening_balance'
+FROM products
+WHERE quantity <> 0;
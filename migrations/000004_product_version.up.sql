@@ -0,0 +1,2 @@
+-- Версия строки для оптимистичной блокировки (ETag / If-Match)
+ALTER TABLE products ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,4 @@
+ALTER TABLE IF EXISTS stock_movements DROP COLUMN IF EXISTS warehouse_balance_after;
+ALTER TABLE IF EXISTS stock_movements DROP COLUMN IF EXISTS warehouse_id;
+DROP TABLE IF EXISTS warehouse_stock;
+DROP TABLE IF EXISTS warehouses;
@@ -0,0 +1,51 @@
+-- Склады (фулфилмент-центры)
+CREATE TABLE IF NOT EXISTS warehouses (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    address TEXT NOT NULL DEFAULT '',
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Склад по умолчанию может быть только один
+CREATE UNIQUE INDEX IF NOT EXISTS idx_warehouses_default ON warehouses(is_default) WHERE is_default;
+
+INSERT INTO warehouses (code, name, is_default)
+VALUES ('default', 'Default warehouse', TRUE)
+ON CONFLICT (code) DO NOTHING;
+
+-- Остатки по складам: products.quantity равен сумме строк товара
+CREATE TABLE IF NOT EXISTS warehouse_stock (
+    warehouse_id UUID NOT NULL REFERENCES warehouses(id),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+    PRIMARY KEY (warehouse_id, product_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_warehouse_stock_product ON warehouse_stock(product_id);
+
+-- Текущие остатки переносим на склад по умолчанию
+INSERT INTO warehouse_stock (warehouse_id, product_id, quantity)
+SELECT w.id, p.id, p.quantity
+FROM products p
+CROSS JOIN warehouses w
+WHERE w.is_default AND p.quantity > 0
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE stock_movements ADD COLUMN IF NOT EXISTS warehouse_id UUID REFERENCES warehouses(id);
+ALTER TABLE stock_movements ADD COLUMN IF NOT EXISTS warehouse_balance_after INTEGER;
+
+-- Журнал append-only, поэтому триггер на время переноса отключаем
+ALTER TABLE stock_movements DISABLE TRIGGER trg_stock_movements_forbid_update;
+
+UPDATE stock_movements
+SET warehouse_id = (SELECT id FROM warehouses WHERE is_default),
+    warehouse_balance_after = balance_after
+WHERE warehouse_id IS NULL;
+
+ALTER TABLE stock_movements ENABLE TRIGGER trg_stock_movements_forbid_update;
+
+ALTER TABLE stock_movements ALTER COLUMN warehouse_id SET NOT NULL;
+ALTER TABLE stock_movements ALTER COLUMN warehouse_balance_after SET NOT NULL;
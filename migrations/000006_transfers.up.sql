@@ -0,0 +1,23 @@
+-- Перемещения товара между складами: draft -> shipped -> received
+CREATE TABLE IF NOT EXISTS transfers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    source_warehouse_id UUID NOT NULL REFERENCES warehouses(id),
+    destination_warehouse_id UUID NOT NULL REFERENCES warehouses(id),
+    status TEXT NOT NULL DEFAULT 'draft', -- draft, shipped, received, cancelled
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    shipped_at TIMESTAMP WITH TIME ZONE,
+    received_at TIMESTAMP WITH TIME ZONE,
+    CHECK (source_warehouse_id <> destination_warehouse_id)
+);
+
+CREATE TABLE IF NOT EXISTS transfer_lines (
+    transfer_id UUID NOT NULL REFERENCES transfers(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (transfer_id, product_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_transfers_status ON transfers(status);
+CREATE INDEX IF NOT EXISTS idx_transfer_lines_product ON transfer_lines(product_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_products_name_lower_prefix;
+DROP INDEX IF EXISTS idx_products_updated_at_id;
+DROP INDEX IF EXISTS idx_products_quantity_id;
+DROP INDEX IF EXISTS idx_products_price_id;
+DROP INDEX IF EXISTS idx_products_name_id;
@@ -0,0 +1,8 @@
+-- Индексы под keyset-пагинацию: (колонка сортировки, id)
+CREATE INDEX IF NOT EXISTS idx_products_name_id ON products(name, id);
+CREATE INDEX IF NOT EXISTS idx_products_price_id ON products(price, id);
+CREATE INDEX IF NOT EXISTS idx_products_quantity_id ON products(quantity, id);
+CREATE INDEX IF NOT EXISTS idx_products_updated_at_id ON products(updated_at, id);
+
+-- Фильтр по префиксу имени без учёта регистра
+CREATE INDEX IF NOT EXISTS idx_products_name_lower_prefix ON products(lower(name) text_pattern_ops);
@@ -0,0 +1,4 @@
+-- Мягкое удаление: строка помечается deleted_at и физически удаляется фоновой очисткой
+ALTER TABLE products ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_products_deleted_at ON products(deleted_at) WHERE deleted_at IS NOT NULL;
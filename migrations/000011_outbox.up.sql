@@ -0,0 +1,18 @@
+-- Исходящие доменные события: пишутся в одной транзакции с изменением, публикуются релеем
+CREATE TABLE IF NOT EXISTS outbox (
+    seq BIGSERIAL PRIMARY KEY,
+    id UUID NOT NULL UNIQUE DEFAULT uuid_generate_v4(),
+    event_type TEXT NOT NULL,
+    aggregate_type TEXT NOT NULL,
+    aggregate_id UUID NOT NULL,
+    payload JSONB NOT NULL,
+    request_id TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    published_at TIMESTAMP WITH TIME ZONE,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Релей выбирает только неопубликованные события
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox(next_attempt_at, seq) WHERE published_at IS NULL;
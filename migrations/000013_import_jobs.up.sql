@@ -0,0 +1,16 @@
+-- Фоновые задачи импорта товаров: строки файла хранятся вместе с задачей
+CREATE TABLE IF NOT EXISTS import_jobs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    status TEXT NOT NULL DEFAULT 'queued', -- queued, running, done, failed
+    dry_run BOOLEAN NOT NULL DEFAULT FALSE,
+    total_rows INTEGER NOT NULL DEFAULT 0,
+    actor TEXT NOT NULL DEFAULT '',
+    rows JSONB NOT NULL,
+    report JSONB,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP WITH TIME ZONE,
+    finished_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_import_jobs_queued ON import_jobs(created_at) WHERE status = 'queued';
@@ -0,0 +1,14 @@
+-- Ключи идемпотентности: сохранённый ответ повторяется при ретрае того же запроса
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    actor TEXT NOT NULL DEFAULT '', -- ключи уникальны в пределах клиента
+    key TEXT NOT NULL,
+    request_hash TEXT NOT NULL,
+    status INTEGER NOT NULL DEFAULT 0, -- 0 = запрос ещё выполняется
+    header JSONB,
+    body BYTEA,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (actor, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires ON idempotency_keys(expires_at);
@@ -0,0 +1,4 @@
+-- Артикул продавца: необязателен, но уникален среди неудалённых товаров
+ALTER TABLE products ADD COLUMN IF NOT EXISTS sku TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_products_sku ON products(sku) WHERE sku IS NOT NULL AND deleted_at IS NULL;
@@ -0,0 +1,10 @@
+-- Штрихкоды товаров (EAN-13, UPC-A, GTIN-14). gtin хранит код, дополненный
+-- нулями до 14 цифр, поэтому один и тот же код в разных форматах не задвоится
+CREATE TABLE IF NOT EXISTS product_barcodes (
+    gtin CHAR(14) PRIMARY KEY,
+    code TEXT NOT NULL,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_barcodes_product ON product_barcodes(product_id);
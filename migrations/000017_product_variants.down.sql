@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS product_variant_attributes;
+DROP INDEX IF EXISTS idx_products_variant_values;
+DROP INDEX IF EXISTS idx_products_parent;
+UPDATE products v SET price = (SELECT p.price FROM products p WHERE p.id = v.parent_id) WHERE v.price IS NULL;
+ALTER TABLE products ALTER COLUMN price SET NOT NULL;
+ALTER TABLE products DROP COLUMN IF EXISTS variant_values;
+ALTER TABLE products DROP COLUMN IF EXISTS parent_id;
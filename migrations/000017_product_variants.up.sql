@@ -0,0 +1,21 @@
+-- Варианты товара (размер, цвет, ...). Вариант — обычная строка products со
+-- ссылкой на родителя, поэтому у него свои SKU, остатки и резервы.
+-- NULL в price означает, что вариант наследует цену родителя
+ALTER TABLE products ADD COLUMN IF NOT EXISTS parent_id UUID REFERENCES products(id);
+ALTER TABLE products ADD COLUMN IF NOT EXISTS variant_values JSONB;
+ALTER TABLE products ALTER COLUMN price DROP NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_products_parent ON products(parent_id) WHERE parent_id IS NOT NULL;
+
+-- одна комбинация значений на родителя среди неудалённых вариантов
+CREATE UNIQUE INDEX IF NOT EXISTS idx_products_variant_values
+    ON products(parent_id, variant_values) WHERE parent_id IS NOT NULL AND deleted_at IS NULL;
+
+-- Оси матрицы вариантов, заданные на родительском товаре, в порядке отображения
+CREATE TABLE IF NOT EXISTS product_variant_attributes (
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    position INTEGER NOT NULL,
+    attribute_values TEXT[] NOT NULL,
+    PRIMARY KEY (product_id, name)
+);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_product_categories_category;
+DROP TABLE IF EXISTS product_categories;
+DROP INDEX IF EXISTS idx_categories_sibling_name;
+DROP INDEX IF EXISTS idx_categories_path;
+DROP TABLE IF EXISTS categories;
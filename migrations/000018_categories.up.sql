@@ -0,0 +1,25 @@
+-- Дерево категорий. path — материализованный путь из id предков и самой
+-- категории ("/<id корня>/.../<id>/"), поэтому все потомки ищутся по префиксу
+CREATE TABLE IF NOT EXISTS categories (
+    id UUID PRIMARY KEY,
+    parent_id UUID REFERENCES categories(id),
+    name TEXT NOT NULL,
+    path TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_categories_path ON categories(path text_pattern_ops);
+
+-- у соседних категорий имена не повторяются
+CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_sibling_name
+    ON categories(COALESCE(parent_id, '00000000-0000-0000-0000-000000000000'), lower(name));
+
+-- Товар может лежать в нескольких категориях
+CREATE TABLE IF NOT EXISTS product_categories (
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    category_id UUID NOT NULL REFERENCES categories(id),
+    PRIMARY KEY (product_id, category_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_categories_category ON product_categories(category_id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_product_attribute_values_number;
+DROP INDEX IF EXISTS idx_product_attribute_values_text;
+DROP TABLE IF EXISTS product_attribute_values;
+DROP TABLE IF EXISTS category_attributes;
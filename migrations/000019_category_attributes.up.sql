@@ -0,0 +1,28 @@
+-- Атрибуты категорий (напряжение, ткань, ...). Действуют на товары категории
+-- и всех её подкатегорий
+CREATE TABLE IF NOT EXISTS category_attributes (
+    id UUID PRIMARY KEY,
+    category_id UUID NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    code TEXT NOT NULL,
+    name TEXT NOT NULL,
+    type TEXT NOT NULL CHECK (type IN ('string', 'number', 'enum', 'boolean')),
+    unit TEXT NOT NULL DEFAULT '',
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    options TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (category_id, code)
+);
+
+-- Значения атрибутов товара: числа хранятся в value_number, чтобы фильтровать
+-- по диапазону, остальные типы — в value_text
+CREATE TABLE IF NOT EXISTS product_attribute_values (
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    attribute_id UUID NOT NULL REFERENCES category_attributes(id) ON DELETE CASCADE,
+    value_text TEXT,
+    value_number DOUBLE PRECISION,
+    PRIMARY KEY (product_id, attribute_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_attribute_values_text ON product_attribute_values(attribute_id, value_text);
+CREATE INDEX IF NOT EXISTS idx_product_attribute_values_number ON product_attribute_values(attribute_id, value_number);
@@ -0,0 +1,4 @@
+ALTER TABLE products DROP COLUMN IF EXISTS currency;
+ALTER TABLE products ALTER COLUMN price_minor TYPE NUMERIC(15, 2) USING price_minor / 100.0;
+ALTER TABLE products ALTER COLUMN price_minor SET DEFAULT 0.00;
+ALTER TABLE products RENAME COLUMN price_minor TO price;
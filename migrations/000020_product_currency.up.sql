@@ -0,0 +1,7 @@
+-- Цена хранится целым числом в минимальных единицах валюты (копейках, тиынах)
+-- вместе с кодом валюты ISO 4217. Существующие цены считаются рублёвыми
+ALTER TABLE products RENAME COLUMN price TO price_minor;
+ALTER TABLE products ALTER COLUMN price_minor DROP DEFAULT;
+ALTER TABLE products ALTER COLUMN price_minor TYPE BIGINT USING round(price_minor * 100);
+ALTER TABLE products ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'RUB'
+    CHECK (currency ~ '^[A-Z]{3}$');
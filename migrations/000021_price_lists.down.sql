@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS exchange_rates;
+DROP INDEX IF EXISTS idx_price_list_items_product;
+DROP TABLE IF EXISTS price_list_items;
+DROP TABLE IF EXISTS price_lists;
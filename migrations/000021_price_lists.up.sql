@@ -0,0 +1,33 @@
+-- Прайс-листы: явные цены товаров в валюте для региона. Пустой region — прайс-лист
+-- валюты по умолчанию, он же используется для регионов без своего
+CREATE TABLE IF NOT EXISTS price_lists (
+    id UUID PRIMARY KEY,
+    currency CHAR(3) NOT NULL CHECK (currency ~ '^[A-Z]{3}$'),
+    region TEXT NOT NULL DEFAULT '',
+    name TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (currency, region)
+);
+
+-- Цена в минимальных единицах валюты прайс-листа
+CREATE TABLE IF NOT EXISTS price_list_items (
+    price_list_id UUID NOT NULL REFERENCES price_lists(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    price_minor BIGINT NOT NULL CHECK (price_minor >= 0),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (price_list_id, product_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_price_list_items_product ON price_list_items(product_id);
+
+-- Курсы валют: 1 единица base стоит rate единиц quote начиная с effective_date.
+-- Действует последний курс с effective_date не позже текущей даты
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    base CHAR(3) NOT NULL,
+    quote CHAR(3) NOT NULL,
+    effective_date DATE NOT NULL,
+    rate NUMERIC(24, 10) NOT NULL CHECK (rate > 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (base, quote, effective_date)
+);
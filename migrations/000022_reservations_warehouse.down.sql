@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_reservations_warehouse_active;
+ALTER TABLE reservations DROP COLUMN IF EXISTS warehouse_id;
@@ -0,0 +1,10 @@
+-- Резерв держит товар на конкретном складе: с него же списывается продажа при подтверждении
+ALTER TABLE reservations ADD COLUMN IF NOT EXISTS warehouse_id UUID REFERENCES warehouses(id);
+
+UPDATE reservations
+SET warehouse_id = (SELECT id FROM warehouses WHERE is_default)
+WHERE warehouse_id IS NULL;
+
+ALTER TABLE reservations ALTER COLUMN warehouse_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_reservations_warehouse_active ON reservations(warehouse_id, product_id) WHERE status = 'active';
@@ -0,0 +1,2 @@
+-- Токен владельца резервации: запрос, чью резервацию перехватили как зависшую, не трогает чужую запись
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS token UUID NOT NULL DEFAULT uuid_generate_v4();